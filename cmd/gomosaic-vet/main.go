@@ -0,0 +1,21 @@
+// Команда gomosaic-vet проверяет аннотации gomosaic и подключается к go vet:
+//
+//	go vet -vettool=$(which gomosaic-vet) ./...
+//
+// или запускается отдельно: gomosaic-vet [-fix] ./...
+package main
+
+import (
+	"os"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic/analyzer"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func main() {
+	// Флаги разбирает singlechecker, поэтому язык выбирается только по переменным окружения
+	i18n.SetLang(i18n.Detect(nil, os.Getenv))
+	singlechecker.Main(analyzer.Analyzer)
+}
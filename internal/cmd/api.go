@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/apisnapshot"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func ApiCmd(env *Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: i18n.T("cli.api.short"),
+	}
+	cmd.AddCommand(apiSnapshotCmd(env), apiDiffCmd(env))
+	return cmd
+}
+
+func apiSnapshotCmd(env *Env) *cobra.Command {
+	var (
+		modfile  string
+		filename string
+		cmd      = &cobra.Command{
+			Use:   "snapshot [flags] packages",
+			Short: i18n.T("cli.api.snapshot.short"),
+			Long:  i18n.T("cli.api.snapshot.long"),
+			Example: examples(
+				"gomosaic api snapshot ./internal/...",
+				"",
+				i18n.T("cli.flags"),
+				"  --modfile: "+i18n.T("cli.flag.modfile"),
+				"  --file:    "+i18n.T("cli.api.flag.file", apisnapshot.DefaultFilename),
+			),
+			Args: cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				snapshot, err := takeSnapshot(env, modfile, args)
+				if err != nil {
+					printError(cmd, err)
+					return
+				}
+				if err := snapshot.Write(filename); err != nil {
+					printError(cmd, err)
+					return
+				}
+				cmd.Println(green("✓"), filename)
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "go.mod", "")
+	cmd.Flags().StringVar(&filename, "file", apisnapshot.DefaultFilename, i18n.T("cli.usage.snapshot-file"))
+
+	return cmd
+}
+
+func apiDiffCmd(env *Env) *cobra.Command {
+	var (
+		modfile  string
+		filename string
+		rev      string
+		cmd      = &cobra.Command{
+			Use:   "diff [flags] packages",
+			Short: i18n.T("cli.api.diff.short"),
+			Long:  i18n.T("cli.api.diff.long"),
+			Example: examples(
+				"gomosaic api diff ./internal/...",
+				"gomosaic api diff --rev origin/main ./internal/...",
+				"",
+				i18n.T("cli.flags"),
+				"  --modfile: "+i18n.T("cli.flag.modfile"),
+				"  --file:    "+i18n.T("cli.api.flag.file", apisnapshot.DefaultFilename),
+				"  --rev:     "+i18n.T("cli.api.flag.rev"),
+			),
+			Args: cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				old, err := loadSnapshot(filename, rev)
+				if err != nil {
+					exitError(cmd, err)
+				}
+				current, err := takeSnapshot(env, modfile, args)
+				if err != nil {
+					exitError(cmd, err)
+				}
+
+				changes := apisnapshot.Diff(old, current)
+				if len(changes) == 0 {
+					cmd.Println(green("✓"), i18n.T("cli.api.unchanged"))
+					return
+				}
+				for _, change := range changes {
+					if change.Breaking {
+						cmd.Println(red(i18n.T("cli.api.breaking")), change)
+					} else {
+						cmd.Println(yellow(i18n.T("cli.api.compatible")), change)
+					}
+				}
+				if apisnapshot.HasBreaking(changes) {
+					os.Exit(1)
+				}
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "go.mod", "")
+	cmd.Flags().StringVar(&filename, "file", apisnapshot.DefaultFilename, i18n.T("cli.usage.snapshot-file"))
+	cmd.Flags().StringVar(&rev, "rev", "", i18n.T("cli.usage.rev"))
+
+	return cmd
+}
+
+// exitError печатает ошибку и завершает команду с кодом 1, чтобы в CI сбой не считался отсутствием изменений
+func exitError(cmd *cobra.Command, err error) {
+	printError(cmd, err)
+	os.Exit(1)
+}
+
+func takeSnapshot(env *Env, modfile string, paths []string) (*apisnapshot.Snapshot, error) {
+	modfile, err := filepath.Abs(modfile)
+	if err != nil {
+		return nil, err
+	}
+	module, err := gomosaic.LoadModuleInfo(modfile)
+	if err != nil {
+		return nil, err
+	}
+	types, err := gomosaic.ParsePackage(filepath.Dir(modfile), paths)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := gomosaic.ContextWithArtifacts(context.Background(), gomosaic.NewArtifacts())
+	return apisnapshot.Take(ctx, env.PluginManager, module, types)
+}
+
+// loadSnapshot загружает снимок из lock файла или, если задана ревизия, из lock файла в этой ревизии git
+func loadSnapshot(filename, rev string) (*apisnapshot.Snapshot, error) {
+	if rev == "" {
+		return apisnapshot.Load(filename)
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	dir, base := filepath.Split(abs)
+	out, err := exec.Command("git", "-C", dir, "show", rev+":./"+base).Output() //nolint: gosec
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, i18n.Errorf("cli.api.rev-failed", filename, rev, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return apisnapshot.Unmarshal(out)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func CacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: i18n.T("cli.cache.short"),
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clean",
+		Short: i18n.T("cli.cache.clean.short"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cacheDir, err := gomosaic.DefaultCacheDir()
+			if err != nil {
+				printError(cmd, err)
+				return
+			}
+
+			if err := gomosaic.NewCache(cacheDir).Clean(); err != nil {
+				printError(cmd, err)
+				return
+			}
+
+			cmd.Println(green("✓"), i18n.T("cli.cache.cleaned"), cacheDir)
+		},
+	})
+
+	return cmd
+}
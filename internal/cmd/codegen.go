@@ -2,97 +2,64 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"path/filepath"
+	"runtime"
 
 	"github.com/spf13/cobra"
 
-	_ "github.com/go-mosaic/gomosaic/internal/plugin/http"
-	_ "github.com/go-mosaic/gomosaic/internal/plugin/logmiddleware"
-	_ "github.com/go-mosaic/gomosaic/internal/plugin/metricmiddleware"
-	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
 const codegenMinArgsCount = 3
 
-func CodegenCmd(postRun ...func()) *cobra.Command {
+func CodegenCmd(env *Env, postRun ...func()) *cobra.Command {
 	var (
-		modfile string
-		cmd     = &cobra.Command{
-			Use:   "codegen [flags] name packages outputDir",
-			Short: "Команда codegen используется для автоматической генерации различного кода на языке Go (Golang) на основе переданных параметров.",
+		modfile    string
+		configFile string
+		noCache    bool
+		noCheck    bool
+		pluginOpts []string
+		jobs       int
+		cmd        = &cobra.Command{
+			Use:   "codegen [flags] (name packages outputDir | --config file)",
+			Short: i18n.T("cli.codegen.short"),
 			Example: examples(
 				"gomosaic codegen http-server ./internal/server",
+				"gomosaic codegen -j 4 http-server-chi,log-middleware ./internal/... ./internal/server",
+				"gomosaic codegen --opt http-client.filename=api_client.go http-client ./internal/... ./pkg/client",
+				"gomosaic codegen --config gomosaic.json",
 				"",
-				"Параметры:",
-				"  name: Имя раширения которое будет генерировать код.",
-				"  packages: Список пакетов в которых необходимо искать интерфейсы и структуры для генерации кода.",
-				"  outputDir: Директория, в которую будет сохранен сгенерированный код.",
+				i18n.T("cli.args"),
+				"  name: "+i18n.T("cli.codegen.arg.name"),
+				"  packages: "+i18n.T("cli.codegen.arg.packages"),
+				"  outputDir: "+i18n.T("cli.codegen.arg.output"),
 				"",
-				"Флаги (опционально):",
-				"  --modfile:  Путь к файлу go.mod для генерации кода (при запуске из под корня проекта флаг можно не указывать).",
+				i18n.T("cli.flags"),
+				"  --modfile:      "+i18n.T("cli.flag.modfile"),
+				"  --config:       "+i18n.T("cli.codegen.flag.config"),
+				"  --no-cache:     "+i18n.T("cli.flag.no-cache"),
+				"  --no-typecheck: "+i18n.T("cli.flag.no-typecheck"),
+				"  --opt:          "+i18n.T("cli.flag.opt"),
+				"  -j:             "+i18n.T("cli.flag.jobs"),
 			),
 			Args: func(cmd *cobra.Command, args []string) error {
-				if len(args) < codegenMinArgsCount {
-					return fmt.Errorf("не верные аргументы")
+				if configFile == "" && len(args) < codegenMinArgsCount {
+					return i18n.Errorf("cli.invalid-args")
 				}
 
 				return nil
 			},
 			Run: func(cmd *cobra.Command, args []string) {
-				pluginName := args[0]
-				paths := args[1 : len(args)-1]
-				outputDir := args[len(args)-1]
-
-				outputDir, err := filepath.Abs(outputDir)
-				if err != nil {
-					cmd.Println(err)
-					return
-				}
-
-				if modfile != "" {
-					modfile, err = filepath.Abs(modfile)
-					if err != nil {
-						cmd.Println(err)
-						return
-					}
-				}
-
-				var dir string
-				if modfile == "" {
-					dir = filepath.Dir(os.Args[0])
-				} else {
-					dir = filepath.Dir(modfile)
-				}
-
-				moduleInfo, err := gomosaic.LoadModuleInfo(modfile)
+				cfg, err := loadConfig(env, configFile, modfile, pluginOpts, args)
 				if err != nil {
 					printError(cmd, err)
 					return
 				}
 
-				nameTypesInfo, err := gomosaic.ParsePackage(dir, paths)
+				runner := &jobRunner{env: env, noCache: noCache, noTypeCheck: noCheck, jobs: jobs}
+				results, err := runner.runAll(context.TODO(), cfg)
+				printResults(cmd, results)
 				if err != nil {
 					printError(cmd, err)
-					return
-				}
-
-				ctx := context.TODO()
-				ctx = gomosaic.ContextWithOutputDir(ctx, outputDir)
-
-				fs := gomosaic.NewFileSystem("dev", outputDir)
-				cg := gomosaic.NewCodeGenerator(gomosaic.DefaultPluginManager, fs)
-
-				outputFilenames, err := cg.Generate(ctx, moduleInfo, nameTypesInfo, pluginName)
-				if err != nil {
-					printError(cmd, err)
-					return
-				}
-
-				cmd.Println("Генерация " + pluginName + " успешно завершена")
-				for _, filename := range outputFilenames {
-					cmd.Println(green("✓"), filename)
 				}
 
 				for _, fn := range postRun {
@@ -103,6 +70,11 @@ func CodegenCmd(postRun ...func()) *cobra.Command {
 	)
 
 	cmd.Flags().StringVar(&modfile, "modfile", "", "")
+	cmd.Flags().StringVar(&configFile, "config", "", i18n.T("cli.usage.config"))
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, i18n.T("cli.usage.no-cache"))
+	cmd.Flags().BoolVar(&noCheck, "no-typecheck", false, i18n.T("cli.usage.no-typecheck"))
+	cmd.Flags().StringArrayVar(&pluginOpts, "opt", nil, i18n.T("cli.usage.opt"))
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.GOMAXPROCS(0), i18n.T("cli.usage.jobs"))
 
 	cobra.CheckErr(cmd.Flags().MarkHidden("modfile"))
 	return cmd
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// textPlugin создает текстовый файл и, если задано, возвращает предупреждение
+type textPlugin struct {
+	name     string
+	filename string
+	warning  string
+}
+
+func (p *textPlugin) Name() string { return p.name }
+
+func (p *textPlugin) Generate(context.Context, *gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo) (map[string]gomosaic.File, error) {
+	f := gomosaic.NewTxtFile()
+	f.WriteBytes([]byte(p.name))
+
+	var err error
+	if p.warning != "" {
+		err = gomosaic.Warn(p.warning, token.Position{})
+	}
+	return map[string]gomosaic.File{p.filename: f}, err
+}
+
+func TestCodegenContinuesAfterWarnings(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go": "package svc\n\n// @gomosaic\ntype UserService interface{}\n",
+		"gomosaic.json": `{"jobs": [
+			{"plugins": ["warn"], "packages": ["./svc"], "output": "first"},
+			{"plugins": ["text"], "packages": ["./svc"], "output": "second"}
+		]}`,
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, output := range []string{"first", "second"} {
+		if err := os.MkdirAll(filepath.Join(dir, output), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pm := gomosaic.NewPluginManager()
+	pm.RegisterPlugin(&textPlugin{name: "warn", filename: "warn.txt", warning: "устаревшая аннотация"})
+	pm.RegisterPlugin(&textPlugin{name: "text", filename: "text.txt"})
+
+	postRun := false
+	cmd := CodegenCmd(&Env{Version: "dev", PluginManager: pm}, func() { postRun = true })
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--config", filepath.Join(dir, "gomosaic.json"), "--no-cache", "--no-typecheck"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "second", "text.txt")); err != nil {
+		t.Errorf("вторая задача не выполнена: %v", err)
+	}
+	if !postRun {
+		t.Error("postRun не вызван после предупреждений")
+	}
+}
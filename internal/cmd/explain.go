@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const explainArgsCount = 2
+
+func ExplainCmd(env *Env) *cobra.Command {
+	var (
+		modfile string
+		cmd     = &cobra.Command{
+			Use:   "explain [flags] plugin package.Interface[.Method]",
+			Short: i18n.T("cli.explain.short"),
+			Long:  i18n.T("cli.explain.long"),
+			Example: examples(
+				"gomosaic explain http-server-chi ./internal/service.UserService.GetUser",
+				"gomosaic explain http-client github.com/user/project/internal/service.UserService",
+				"",
+				i18n.T("cli.args"),
+				"  plugin: "+i18n.T("cli.explain.arg.plugin"),
+				"  package.Interface[.Method]: "+i18n.T("cli.explain.arg.target"),
+				"",
+				i18n.T("cli.flags"),
+				"  --modfile: "+i18n.T("cli.flag.modfile"),
+			),
+			Args: cobra.ExactArgs(explainArgsCount),
+			Run: func(cmd *cobra.Command, args []string) {
+				explanations, err := explain(env, modfile, args[0], args[1])
+				if err != nil {
+					printError(cmd, err)
+					return
+				}
+
+				wd, _ := os.Getwd()
+				for i, explanation := range explanations {
+					if i > 0 {
+						cmd.Println()
+					}
+					cmd.Println(explanation.Title)
+					for _, field := range explanation.Fields {
+						line := "  " + green(field.Path) + " = " + field.Value
+						if field.Source != "" {
+							line += " — " + field.Source
+						}
+						if field.Position != nil && field.Position.IsValid {
+							line += " " + yellow(relPosition(wd, field.Position))
+						}
+						cmd.Println(line)
+					}
+				}
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "go.mod", "")
+
+	return cmd
+}
+
+func explain(env *Env, modfile, pluginName, target string) ([]*gomosaic.Explanation, error) {
+	plugin, err := env.PluginManager.GetPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	explainer, ok := plugin.(gomosaic.Explainer)
+	if !ok {
+		return nil, i18n.Errorf("cli.explain.unsupported", pluginName)
+	}
+
+	pkg, iface, method, err := splitExplainTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	modfile, err = filepath.Abs(modfile)
+	if err != nil {
+		return nil, err
+	}
+	module, err := gomosaic.LoadModuleInfo(modfile)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := gomosaic.ParsePackage(filepath.Dir(modfile), []string{pkg})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := gomosaic.ContextWithArtifacts(context.Background(), gomosaic.NewArtifacts())
+	return explainer.Explain(ctx, module, types, iface, method)
+}
+
+// splitExplainTarget разделяет package.Interface.Method: точки ищутся после последнего /,
+// так как путь пакета может содержать точки (github.com/user/project)
+func splitExplainTarget(target string) (pkg, iface, method string, err error) {
+	slash := strings.LastIndex(target, "/") + 1
+	parts := strings.Split(target[slash:], ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", i18n.Errorf("cli.explain.target", target)
+	}
+	pkg, iface = target[:slash]+parts[0], parts[1]
+	if len(parts) == 3 { //nolint: mnd
+		method = parts[2]
+	}
+	return pkg, iface, method, nil
+}
+
+// relPosition возвращает позицию с путем файла относительно рабочей директории
+func relPosition(wd string, pos *gomosaic.PosInfo) string {
+	p := *pos
+	if rel, err := filepath.Rel(wd, p.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		p.Filename = rel
+	}
+	return p.String()
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotationfmt"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func FmtCmd() *cobra.Command {
+	var (
+		order []string
+		list  bool
+		diff  bool
+		cmd   = &cobra.Command{
+			Use:   "fmt [flags] packages",
+			Short: i18n.T("cli.fmt.short"),
+			Long:  i18n.T("cli.fmt.long"),
+			Example: examples(
+				"gomosaic fmt ./internal/...",
+				"gomosaic fmt -l ./...",
+				"gomosaic fmt -d --order gomosaic,http-method,http-path,http ./internal/...",
+				"",
+				i18n.T("cli.flags"),
+				"  --order: "+i18n.T("cli.fmt.flag.order"),
+				"  -l:      "+i18n.T("cli.fmt.flag.list"),
+				"  -d:      "+i18n.T("cli.fmt.flag.diff"),
+			),
+			Args: cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				results, err := annotationfmt.Format("", args, annotationfmt.WithOrder(order...))
+				if err != nil {
+					printError(cmd, err)
+					return
+				}
+
+				for _, result := range results {
+					switch {
+					case list || diff:
+						if list {
+							cmd.Println(result.Filename)
+						}
+						if diff {
+							cmd.Print(result.Diff())
+						}
+					default:
+						if err := result.Write(); err != nil {
+							printError(cmd, err)
+							return
+						}
+						cmd.Println(green("✓"), result.Filename)
+					}
+				}
+
+				if (list || diff) && len(results) > 0 {
+					os.Exit(1)
+				}
+			},
+		}
+	)
+
+	cmd.Flags().StringSliceVar(&order, "order", annotationfmt.DefaultOrder, i18n.T("cli.usage.fmt-order"))
+	cmd.Flags().BoolVarP(&list, "list", "l", false, i18n.T("cli.usage.fmt-list"))
+	cmd.Flags().BoolVarP(&diff, "diff", "d", false, i18n.T("cli.usage.diff"))
+
+	return cmd
+}
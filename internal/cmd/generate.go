@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func GenerateCmd(env *Env) *cobra.Command {
+	var (
+		modfile    string
+		noCache    bool
+		noCheck    bool
+		pluginOpts []string
+		jobs       int
+		dryRun     bool
+		cmd        = &cobra.Command{
+			Use:   "generate [flags] packages",
+			Short: i18n.T("cli.generate.short", gomosaic.GenAnnotation),
+			Long:  i18n.T("cli.generate.long", gomosaic.GenAnnotation),
+			Example: examples(
+				"gomosaic generate ./...",
+				"gomosaic generate -n ./internal/...",
+				"",
+				i18n.T("cli.generate.directive"),
+				"  // @"+gomosaic.GenAnnotation+" http-server-chi,log-middleware ../transport",
+				"  package service",
+				"",
+				i18n.T("cli.flags"),
+				"  --modfile:      "+i18n.T("cli.flag.modfile"),
+				"  -n, --dry-run:  "+i18n.T("cli.generate.flag.dry-run"),
+				"  --no-cache:     "+i18n.T("cli.flag.no-cache"),
+				"  --no-typecheck: "+i18n.T("cli.flag.no-typecheck"),
+				"  --opt:          "+i18n.T("cli.flag.opt"),
+				"  -j:             "+i18n.T("cli.flag.jobs"),
+			),
+			Args: cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				modfile, err := filepath.Abs(modfile)
+				if err != nil {
+					exitError(cmd, err)
+				}
+
+				discovered, err := gomosaic.DiscoverJobs(filepath.Dir(modfile), args)
+				if err != nil {
+					exitError(cmd, err)
+				}
+				if len(discovered) == 0 {
+					cmd.Println(yellow(i18n.T("cli.generate.none", gomosaic.GenAnnotation)))
+					return
+				}
+
+				cfg := &gomosaic.Config{ModFile: modfile, Jobs: discovered}
+				if err := applyPluginOptions(cfg, pluginOpts); err != nil {
+					exitError(cmd, err)
+				}
+				if err := validatePluginOptions(cfg, env.PluginManager); err != nil {
+					exitError(cmd, err)
+				}
+
+				if dryRun {
+					for _, job := range cfg.Jobs {
+						cmd.Println(strings.Join(job.Plugins, ","), strings.Join(job.Packages, " "), relPath(job.Output))
+					}
+					return
+				}
+
+				// Директории вывода из директив, в отличие от codegen, создаются при первом запуске
+				for _, job := range cfg.Jobs {
+					if err := os.MkdirAll(job.Output, 0o755); err != nil { //nolint: mnd
+						exitError(cmd, err)
+					}
+				}
+
+				runner := &jobRunner{env: env, noCache: noCache, noTypeCheck: noCheck, jobs: jobs}
+				results, err := runner.runAll(context.TODO(), cfg)
+				printResults(cmd, results)
+				if err != nil {
+					printError(cmd, err)
+				}
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "go.mod", "")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, i18n.T("cli.usage.generate-dry-run"))
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, i18n.T("cli.usage.no-cache"))
+	cmd.Flags().BoolVar(&noCheck, "no-typecheck", false, i18n.T("cli.usage.no-typecheck"))
+	cmd.Flags().StringArrayVar(&pluginOpts, "opt", nil, i18n.T("cli.usage.opt"))
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.GOMAXPROCS(0), i18n.T("cli.usage.jobs"))
+
+	return cmd
+}
+
+// relPath возвращает путь относительно текущей директории, если это возможно
+func relPath(path string) string {
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
 var (
@@ -26,8 +27,27 @@ func examples(ex ...string) string {
 	return strings.Join(ex, "\n")
 }
 
+func printResults(cmd *cobra.Command, results []*gomosaic.GenerateResult) {
+	for _, result := range results {
+		if result.Cached {
+			cmd.Println(i18n.T("cli.result.cached", result.Plugin))
+		} else {
+			cmd.Println(i18n.T("cli.result.done", result.Plugin))
+		}
+		for _, filename := range result.OutputFiles {
+			cmd.Println(green("✓"), filename)
+		}
+	}
+}
+
 func printError(cmd *cobra.Command, err error) {
-	var isExitApp bool
+	if printDiagnostics(cmd, err) {
+		os.Exit(1)
+	}
+}
+
+// printDiagnostics печатает ошибки и предупреждения и сообщает, были ли среди них ошибки
+func printDiagnostics(cmd *cobra.Command, err error) (hasErrors bool) {
 
 	if err != nil {
 		var merr *multierror.Error
@@ -39,20 +59,20 @@ func printError(cmd *cobra.Command, err error) {
 					if gomosaic.IsErrWarning(err) {
 						warningPoints = append(warningPoints, fmt.Sprintf("* %s", yellow(err)))
 					} else {
-						isExitApp = true
+						hasErrors = true
 						errorPoints = append(errorPoints, fmt.Sprintf("* %s", red(err)))
 					}
 				}
 				var text string
 				if len(errorPoints) > 0 {
 					text += fmt.Sprintf(
-						"\n\n%d ошибки:\n\t%s\n\n",
-						len(errorPoints), strings.Join(errorPoints, "\n\t"))
+						"\n\n%s:\n\t%s\n\n",
+						i18n.T("cli.diagnostics.errors", len(errorPoints)), strings.Join(errorPoints, "\n\t"))
 				}
 				if len(warningPoints) > 0 {
 					text += fmt.Sprintf(
-						"\n\n%d предупреждения:\n\t%s\n\n",
-						len(warningPoints), strings.Join(warningPoints, "\n\t"))
+						"\n\n%s:\n\t%s\n\n",
+						i18n.T("cli.diagnostics.warnings", len(warningPoints)), strings.Join(warningPoints, "\n\t"))
 				}
 				return text
 			}
@@ -60,7 +80,5 @@ func printError(cmd *cobra.Command, err error) {
 		cmd.Println(err)
 	}
 
-	if isExitApp {
-		os.Exit(1)
-	}
+	return hasErrors
 }
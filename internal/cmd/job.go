@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+// Env параметры сборки gomosaic, общие для всех команд
+type Env struct {
+	Version       string                  // Версия, записываемая в заголовок сгенерированных файлов
+	PluginManager *gomosaic.PluginManager // Менеджер плагинов
+}
+
+// jobRunner выполняет задачи генерации с общими для команд флагами
+type jobRunner struct {
+	env         *Env
+	noCache     bool
+	noTypeCheck bool
+	jobs        int
+}
+
+// run разбирает входные пакеты задачи и запускает ее плагины
+func (r *jobRunner) run(ctx context.Context, modfile string, job *gomosaic.JobConfig) ([]*gomosaic.GenerateResult, error) {
+	return r.runAll(ctx, &gomosaic.Config{ModFile: modfile, Jobs: []*gomosaic.JobConfig{job}})
+}
+
+// runAll выполняет все задачи конфигурации, входные пакеты разбираются один раз для всех задач
+func (r *jobRunner) runAll(ctx context.Context, cfg *gomosaic.Config) ([]*gomosaic.GenerateResult, error) {
+	opts := []gomosaic.RunOption{
+		gomosaic.RunWithVersion(r.env.Version),
+		gomosaic.RunWithPluginManager(r.env.PluginManager),
+	}
+
+	if !r.noCache {
+		cacheDir, err := gomosaic.DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, gomosaic.RunWithCache(gomosaic.NewCache(cacheDir)))
+	}
+
+	if !r.noTypeCheck {
+		opts = append(opts, gomosaic.RunWithTypeCheck())
+	}
+
+	ctx = gomosaic.ContextWithConcurrency(ctx, r.jobs)
+
+	return gomosaic.Run(ctx, cfg, opts...)
+}
+
+// loadConfig возвращает конфигурацию из файла, либо собирает единственную задачу
+// из аргументов командной строки вида: name packages outputDir.
+// Параметры плагинов из флагов --opt добавляются ко всем задачам, в которых используется плагин.
+func loadConfig(env *Env, configFile, modfile string, pluginOpts, args []string) (cfg *gomosaic.Config, err error) {
+	if configFile != "" {
+		if len(args) > 0 {
+			return nil, i18n.Errorf("cli.args-with-config")
+		}
+		cfg, err = gomosaic.LoadConfig(configFile)
+	} else {
+		cfg, err = configFromArgs(modfile, args)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPluginOptions(cfg, pluginOpts); err != nil {
+		return nil, err
+	}
+
+	return cfg, validatePluginOptions(cfg, env.PluginManager)
+}
+
+func configFromArgs(modfile string, args []string) (*gomosaic.Config, error) {
+	if len(args) < codegenMinArgsCount {
+		return nil, i18n.Errorf("cli.invalid-args")
+	}
+
+	if modfile == "" {
+		modfile = "go.mod"
+	}
+
+	modfile, err := filepath.Abs(modfile)
+	if err != nil {
+		return nil, err
+	}
+
+	outputDir, err := filepath.Abs(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &gomosaic.Config{
+		ModFile: modfile,
+		Jobs: []*gomosaic.JobConfig{
+			{
+				Plugins:  strings.Split(args[0], ","),
+				Packages: args[1 : len(args)-1],
+				Output:   outputDir,
+			},
+		},
+	}, nil
+}
+
+// applyPluginOptions добавляет параметры вида plugin.key=value в задачи с этим плагином
+func applyPluginOptions(cfg *gomosaic.Config, pluginOpts []string) error {
+	for _, s := range pluginOpts {
+		plugin, key, value, err := gomosaic.ParsePluginOption(s)
+		if err != nil {
+			return err
+		}
+
+		applied := false
+		for _, job := range cfg.Jobs {
+			if !slices.Contains(job.Plugins, plugin) {
+				continue
+			}
+			if job.Options == nil {
+				job.Options = make(map[string]map[string]string)
+			}
+			if job.Options[plugin] == nil {
+				job.Options[plugin] = make(map[string]string)
+			}
+			job.Options[plugin][key] = value
+			applied = true
+		}
+
+		if !applied {
+			return i18n.Errorf("cli.option-unused-plugin", s, plugin)
+		}
+	}
+
+	return nil
+}
+
+// validatePluginOptions проверяет параметры плагинов до запуска генерации
+func validatePluginOptions(cfg *gomosaic.Config, pm *gomosaic.PluginManager) (errs error) {
+	for _, job := range cfg.Jobs {
+		for _, name := range job.Plugins {
+			values, ok := job.Options[name]
+			if !ok {
+				continue
+			}
+
+			plugin, err := pm.GetPlugin(name)
+			if err != nil {
+				return err
+			}
+
+			configurable, ok := plugin.(gomosaic.Configurable)
+			if !ok {
+				errs = multierror.Append(errs, i18n.Errorf("cli.plugin-no-options", name))
+				continue
+			}
+
+			opts := &gomosaic.PluginOptions{Plugin: name, Values: values}
+			if err := option.UnmarshalPluginOptions(opts, configurable.Options()); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/lint"
+)
+
+func LintCmd(env *Env) *cobra.Command {
+	var (
+		modfile    string
+		configPath string
+		list       bool
+		cmd        = &cobra.Command{
+			Use:   "lint [flags] packages",
+			Short: i18n.T("cli.lint.short"),
+			Long:  i18n.T("cli.lint.long"),
+			Example: examples(
+				"gomosaic lint ./internal/...",
+				"gomosaic lint --list",
+				"",
+				i18n.T("cli.flags"),
+				"  --modfile: "+i18n.T("cli.flag.modfile"),
+				"  --config:  "+i18n.T("cli.lint.flag.config", gomosaic.DefaultConfigFilename),
+				"  --list:    "+i18n.T("cli.lint.flag.list"),
+			),
+			Args: func(cmd *cobra.Command, args []string) error {
+				if list {
+					return nil
+				}
+				return cobra.MinimumNArgs(1)(cmd, args)
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				if list {
+					for _, rule := range lint.Rules(env.PluginManager) {
+						cmd.Printf("%-20s %-8s %s\n", rule.ID, rule.Severity, rule.Description)
+					}
+					return
+				}
+
+				severities, err := lintSeverities(configPath)
+				if err != nil {
+					exitError(cmd, err)
+				}
+
+				modfile, err := filepath.Abs(modfile)
+				if err != nil {
+					exitError(cmd, err)
+				}
+				module, err := gomosaic.LoadModuleInfo(modfile)
+				if err != nil {
+					exitError(cmd, err)
+				}
+				types, err := gomosaic.ParsePackage(filepath.Dir(modfile), args)
+				if err != nil {
+					exitError(cmd, err)
+				}
+
+				ctx := gomosaic.ContextWithArtifacts(context.Background(), gomosaic.NewArtifacts())
+				if err := lint.Run(ctx, env.PluginManager, module, types, severities); err != nil {
+					printError(cmd, err)
+					return
+				}
+				cmd.Println(green("✓"), i18n.T("cli.lint.ok"))
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "go.mod", "")
+	cmd.Flags().StringVar(&configPath, "config", "", i18n.T("cli.usage.lint-config"))
+	cmd.Flags().BoolVar(&list, "list", false, i18n.T("cli.usage.lint-list"))
+
+	return cmd
+}
+
+// lintSeverities загружает уровни правил из файла конфигурации. Без флага --config
+// используется gomosaic.json из текущей директории, если он есть.
+func lintSeverities(configPath string) (map[string]string, error) {
+	if configPath == "" {
+		if _, err := os.Stat(gomosaic.DefaultConfigFilename); err != nil {
+			return nil, nil
+		}
+		configPath = gomosaic.DefaultConfigFilename
+	}
+	cfg, err := gomosaic.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Lint, nil
+}
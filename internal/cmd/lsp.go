@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/internal/lsp"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func LspCmd(env *Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lsp",
+		Short: i18n.T("cli.lsp.short"),
+		Long:  i18n.T("cli.lsp.long"),
+		Example: examples(
+			"gomosaic lsp",
+		),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := lsp.NewServer(env.PluginManager, lsp.WithVersion(env.Version))
+			if err := server.Run(cmd.Context(), os.Stdin, os.Stdout); err != nil {
+				printError(cmd, err)
+			}
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/migrate"
+)
+
+func MigrateCmd() *cobra.Command {
+	var (
+		ruleNames []string
+		dryRun    bool
+		list      bool
+		cmd       = &cobra.Command{
+			Use:   "migrate [flags] packages",
+			Short: i18n.T("cli.migrate.short"),
+			Long:  i18n.T("cli.migrate.long"),
+			Example: examples(
+				"gomosaic migrate --dry-run ./internal/...",
+				"gomosaic migrate --rule http-path-params ./internal/...",
+				"gomosaic migrate --list",
+				"",
+				i18n.T("cli.flags"),
+				"  --rule:    "+i18n.T("cli.migrate.flag.rule"),
+				"  --dry-run: "+i18n.T("cli.migrate.flag.dry-run"),
+				"  --list:    "+i18n.T("cli.migrate.flag.list"),
+			),
+			Args: func(cmd *cobra.Command, args []string) error {
+				if list {
+					return cobra.NoArgs(cmd, args)
+				}
+				return cobra.MinimumNArgs(1)(cmd, args)
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				if list {
+					for _, rule := range migrate.DefaultRegistry.Rules() {
+						cmd.Println(green(rule.Name()) + ": " + rule.Description())
+					}
+					return
+				}
+
+				rules := migrate.DefaultRegistry.Rules()
+				if len(ruleNames) > 0 {
+					var err error
+					rules, err = migrate.DefaultRegistry.Lookup(ruleNames...)
+					if err != nil {
+						printError(cmd, err)
+						return
+					}
+				}
+
+				results, err := migrate.Migrate("", args, rules)
+				if err != nil {
+					printError(cmd, err)
+					return
+				}
+
+				if len(results) == 0 {
+					cmd.Println(green("✓"), i18n.T("cli.migrate.none"))
+					return
+				}
+
+				for _, result := range results {
+					if dryRun {
+						cmd.Print(result.Diff())
+						continue
+					}
+					if err := result.Write(); err != nil {
+						printError(cmd, err)
+						return
+					}
+					cmd.Println(green("✓"), result.Filename, "("+strings.Join(result.Rules, ", ")+")")
+				}
+			},
+		}
+	)
+
+	cmd.Flags().StringSliceVar(&ruleNames, "rule", nil, i18n.T("cli.usage.migrate-rule"))
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, i18n.T("cli.usage.dry-run"))
+	cmd.Flags().BoolVar(&list, "list", false, i18n.T("cli.usage.migrate-list"))
+
+	return cmd
+}
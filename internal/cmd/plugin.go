@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// Commander необязательный интерфейс плагина, который добавляет команды gomosaic <plugin> <subcommand>,
+// например serve у плагина mock сервера. Команды создаются один раз при сборке корневой команды,
+// загрузка модуля, разбор пакетов и вывод диагностик доступны через PluginContext.
+type Commander interface {
+	Commands(pc *PluginContext) []*cobra.Command
+}
+
+// PluginContext возможности gomosaic, общие для команд плагина и codegen
+type PluginContext struct {
+	env     *Env
+	plugin  gomosaic.Generator
+	modfile string
+}
+
+// Plugin возвращает плагин, которому принадлежат команды
+func (pc *PluginContext) Plugin() gomosaic.Generator {
+	return pc.plugin
+}
+
+// PluginManager возвращает менеджер плагинов сборки gomosaic
+func (pc *PluginContext) PluginManager() *gomosaic.PluginManager {
+	return pc.env.PluginManager
+}
+
+// Version возвращает версию gomosaic
+func (pc *PluginContext) Version() string {
+	return pc.env.Version
+}
+
+// Module загружает модуль из файла, заданного флагом --modfile (по умолчанию go.mod текущей директории)
+func (pc *PluginContext) Module() (*gomosaic.ModuleInfo, error) {
+	modfile, err := filepath.Abs(pc.modfile)
+	if err != nil {
+		return nil, err
+	}
+	return gomosaic.LoadModuleInfo(modfile)
+}
+
+// Load загружает модуль и разбирает типы пакетов, помеченные @gomosaic, так же, как codegen
+func (pc *PluginContext) Load(packages []string) (*gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo, error) {
+	module, err := pc.Module()
+	if err != nil {
+		return nil, nil, err
+	}
+	types, err := gomosaic.ParsePackage(module.Dir, packages)
+	if err != nil {
+		return nil, nil, err
+	}
+	return module, types, nil
+}
+
+// Context возвращает контекст с хранилищем артефактов для вызова методов плагина
+func (pc *PluginContext) Context() context.Context {
+	return gomosaic.ContextWithArtifacts(context.Background(), gomosaic.NewArtifacts())
+}
+
+// PrintError печатает ошибки и предупреждения так же, как codegen
+func (pc *PluginContext) PrintError(cmd *cobra.Command, err error) {
+	printError(cmd, err)
+}
+
+// PluginCmd возвращает команду gomosaic <plugin> с командами плагина или nil,
+// если плагин не реализует Commander или не добавляет команд
+func PluginCmd(env *Env, plugin gomosaic.Generator) *cobra.Command {
+	commander, ok := plugin.(Commander)
+	if !ok {
+		return nil
+	}
+
+	pc := &PluginContext{env: env, plugin: plugin}
+	commands := commander.Commands(pc)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	cmd := &cobra.Command{
+		Use:   plugin.Name(),
+		Short: i18n.T("cli.plugin.short", plugin.Name()),
+		Args:  cobra.NoArgs,
+	}
+	cmd.PersistentFlags().StringVar(&pc.modfile, "modfile", "go.mod", "")
+	cmd.AddCommand(commands...)
+
+	return cmd
+}
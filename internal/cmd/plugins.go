@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+func PluginsCmd(env *Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plugins",
+		Short: i18n.T("cli.plugins.short"),
+		Long:  i18n.T("cli.plugins.long"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, plugin := range env.PluginManager.Plugins() {
+				if dependent, ok := plugin.(gomosaic.Dependent); ok && len(dependent.Dependencies()) > 0 {
+					cmd.Println(green(plugin.Name()), i18n.T("cli.plugins.depends", strings.Join(dependent.Dependencies(), ", ")))
+				} else {
+					cmd.Println(green(plugin.Name()))
+				}
+
+				configurable, ok := plugin.(gomosaic.Configurable)
+				if !ok {
+					continue
+				}
+
+				for _, info := range option.Describe(configurable.Options()) {
+					line := "  " + plugin.Name() + "." + info.Name + " (" + info.Type + ")"
+					if info.Usage != "" {
+						line += ": " + info.Usage
+					}
+					if info.Valid != "" {
+						line += ", " + info.Valid
+					}
+					if info.Default != "" {
+						line += ", " + i18n.T("cli.plugins.default", info.Default)
+					}
+					cmd.Println(line)
+				}
+			}
+		},
+	}
+}
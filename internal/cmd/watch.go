@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const (
+	defaultWatchInterval = 500 * time.Millisecond
+	defaultWatchDebounce = 300 * time.Millisecond
+)
+
+func WatchCmd(env *Env) *cobra.Command {
+	var (
+		modfile    string
+		configFile string
+		noCache    bool
+		noCheck    bool
+		pluginOpts []string
+		jobs       int
+		interval   time.Duration
+		debounce   time.Duration
+		cmd        = &cobra.Command{
+			Use:   "watch [flags] (name packages outputDir | --config file)",
+			Short: i18n.T("cli.watch.short"),
+			Example: examples(
+				"gomosaic watch http-server-chi,log-middleware ./internal/... ./internal/server",
+				"gomosaic watch --config gomosaic.json",
+				"",
+				i18n.T("cli.watch.args"),
+				"",
+				i18n.T("cli.flags"),
+				"  --config:       "+i18n.T("cli.codegen.flag.config"),
+				"  --no-cache:     "+i18n.T("cli.watch.flag.no-cache"),
+				"  --interval:     "+i18n.T("cli.watch.flag.interval"),
+				"  --debounce:     "+i18n.T("cli.watch.flag.debounce"),
+				"  --no-typecheck: "+i18n.T("cli.flag.no-typecheck"),
+				"  --opt:          "+i18n.T("cli.flag.opt"),
+				"  -j:             "+i18n.T("cli.flag.jobs"),
+			),
+			Args: func(cmd *cobra.Command, args []string) error {
+				if configFile == "" && len(args) < codegenMinArgsCount {
+					return i18n.Errorf("cli.invalid-args")
+				}
+
+				return nil
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				cfg, err := loadConfig(env, configFile, modfile, pluginOpts, args)
+				if err != nil {
+					printError(cmd, err)
+					return
+				}
+
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				w := &watcher{
+					cmd:      cmd,
+					cfg:      cfg,
+					runner:   &jobRunner{env: env, noCache: noCache, noTypeCheck: noCheck, jobs: jobs},
+					interval: interval,
+					debounce: debounce,
+				}
+				w.watch(ctx)
+			},
+		}
+	)
+
+	cmd.Flags().StringVar(&modfile, "modfile", "", "")
+	cmd.Flags().StringVar(&configFile, "config", "", i18n.T("cli.usage.config"))
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, i18n.T("cli.usage.no-cache"))
+	cmd.Flags().BoolVar(&noCheck, "no-typecheck", false, i18n.T("cli.usage.no-typecheck"))
+	cmd.Flags().StringArrayVar(&pluginOpts, "opt", nil, i18n.T("cli.usage.opt"))
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.GOMAXPROCS(0), i18n.T("cli.usage.jobs"))
+	cmd.Flags().DurationVar(&interval, "interval", defaultWatchInterval, i18n.T("cli.usage.interval"))
+	cmd.Flags().DurationVar(&debounce, "debounce", defaultWatchDebounce, i18n.T("cli.usage.debounce"))
+
+	cobra.CheckErr(cmd.Flags().MarkHidden("modfile"))
+	return cmd
+}
+
+// fileStamp состояние файла или директории, по которому определяется изменение
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchTarget отслеживаемая задача и снимок состояния ее входных файлов.
+// Перегенерацию вызывают только Go файлы пакетов с маркером @gomosaic. Файлы остальных
+// пакетов и директории всех пакетов отслеживаются, чтобы заметить добавленные и удаленные
+// файлы и пакеты, в которых появился маркер. Пока снимок не удался, files равен nil.
+type watchTarget struct {
+	job   *gomosaic.JobConfig
+	files map[string]fileStamp
+	other map[string]fileStamp
+}
+
+// watcher опрашивает входные файлы задач и перегенерирует код только тех задач,
+// файлы которых изменились. Внутри задачи плагины без изменений модели
+// пропускаются за счет кеша генерации.
+type watcher struct {
+	cmd      *cobra.Command
+	cfg      *gomosaic.Config
+	runner   *jobRunner
+	interval time.Duration
+	debounce time.Duration
+}
+
+func (w *watcher) watch(ctx context.Context) {
+	targets := make([]*watchTarget, len(w.cfg.Jobs))
+	for i, job := range w.cfg.Jobs {
+		targets[i] = &watchTarget{job: job}
+		w.generate(ctx, targets[i], nil)
+	}
+
+	w.cmd.Println(i18n.T("cli.watch.waiting"))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var (
+		dir        = filepath.Dir(w.cfg.ModFile)
+		pending    = make(map[*watchTarget][]string)
+		lastChange time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, target := range targets {
+				// неудавшийся снимок повторяется на каждом тике, после успеха задача перегенерируется
+				if target.files == nil {
+					if target.snapshot(dir) == nil {
+						pending[target] = appendUnique(pending[target])
+						lastChange = now
+					}
+					continue
+				}
+
+				changed, err := target.changes(dir)
+				if err != nil {
+					printDiagnostics(w.cmd, err)
+					continue
+				}
+				if len(changed) > 0 {
+					pending[target] = appendUnique(pending[target], changed...)
+					lastChange = now
+				}
+			}
+
+			if len(pending) == 0 || now.Sub(lastChange) < w.debounce {
+				continue
+			}
+
+			for _, target := range targets {
+				if changed, ok := pending[target]; ok {
+					w.generate(ctx, target, changed)
+				}
+			}
+			clear(pending)
+		}
+	}
+}
+
+// generate запускает задачу и обновляет снимок ее входных файлов.
+// Ошибки печатаются, но не прерывают наблюдение.
+func (w *watcher) generate(ctx context.Context, target *watchTarget, changed []string) {
+	if len(changed) > 0 {
+		w.cmd.Println(i18n.T("cli.watch.changed", time.Now().Format(time.TimeOnly)))
+		for _, filename := range changed {
+			w.cmd.Println(yellow("~"), filename)
+		}
+	}
+
+	results, err := w.runner.run(ctx, w.cfg.ModFile, target.job)
+	printResults(w.cmd, results)
+	if err != nil {
+		printDiagnostics(w.cmd, err)
+	}
+
+	if err := target.snapshot(filepath.Dir(w.cfg.ModFile)); err != nil {
+		printDiagnostics(w.cmd, err)
+	}
+}
+
+// snapshot заново определяет входные файлы задачи и запоминает их состояние
+func (t *watchTarget) snapshot(dir string) error {
+	t.files, t.other = nil, nil
+
+	marked, unmarked, err := gomosaic.MarkedPackageFiles(dir, t.job.Packages)
+	if err != nil {
+		return err
+	}
+
+	files, err := statFiles(marked)
+	if err != nil {
+		return err
+	}
+
+	names := slices.Clone(unmarked)
+	for _, filename := range slices.Concat(marked, unmarked) {
+		names = appendUnique(names, filepath.Dir(filename))
+	}
+	other, err := statFiles(names)
+	if err != nil {
+		return err
+	}
+
+	t.files, t.other = files, other
+	return nil
+}
+
+// changes возвращает отсортированный список измененных, добавленных и удаленных
+// с момента снимка Go файлов помеченных пакетов. Если изменились только остальные
+// файлы или директории, снимок обновляется, а список пуст.
+func (t *watchTarget) changes(dir string) (changed []string, err error) {
+	if !modified(t.files) && !modified(t.other) {
+		return nil, nil
+	}
+
+	prev := t.files
+	if err := t.snapshot(dir); err != nil {
+		return nil, err
+	}
+
+	for name, stamp := range t.files {
+		if prev[name] != stamp {
+			changed = append(changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := t.files[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// modified сообщает, изменилось ли состояние хотя бы одного файла снимка.
+// Удаленный файл получает нулевое состояние и тоже считается измененным.
+func modified(files map[string]fileStamp) bool {
+	for name, stamp := range files {
+		if current, _ := statFile(name); current != stamp {
+			return true
+		}
+	}
+	return false
+}
+
+func statFiles(names []string) (map[string]fileStamp, error) {
+	files := make(map[string]fileStamp, len(names))
+	for _, name := range names {
+		stamp, err := statFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = stamp
+	}
+	return files, nil
+}
+
+func statFile(name string) (fileStamp, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
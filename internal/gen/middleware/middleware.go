@@ -17,20 +17,24 @@ type Generator struct {
 	params        []jen.Code
 	methods       []jen.Code
 	qualFunc      jenutils.QualFunc
+	sources       gomosaic.DeclSourceRecorder
 }
 
 func NewGenerator(
 	nameTypeInfo *gomosaic.NameTypeInfo,
 	name string,
 	qualFunc jenutils.QualFunc,
+	sources gomosaic.DeclSourceRecorder,
+	caser *strcase.Caser,
 	params []jen.Code,
 
 ) *Generator {
 	return &Generator{
 		nameTypeInfo:  nameTypeInfo,
-		structName:    strcase.ToCamel(nameTypeInfo.Name) + name + "Middleware",
-		constructName: name + strcase.ToCamel(nameTypeInfo.Name) + "Middleware",
+		structName:    caser.ToCamel(nameTypeInfo.Name) + name + "Middleware",
+		constructName: name + caser.ToCamel(nameTypeInfo.Name) + "Middleware",
 		qualFunc:      qualFunc,
+		sources:       sources,
 		params:        params,
 	}
 }
@@ -48,6 +52,9 @@ func (g *Generator) Generate() (jen.Code, error) {
 
 	ifaceType := jen.Do(g.qualFunc(g.nameTypeInfo.Package.Path, g.nameTypeInfo.Name))
 
+	g.sources.SetDeclSource(g.structName, g.nameTypeInfo, nil)
+	g.sources.SetDeclSource(g.constructName, g.nameTypeInfo, nil)
+
 	group.Type().Id(g.structName).StructFunc(func(group *jen.Group) {
 		group.Id("next").Do(g.qualFunc(g.nameTypeInfo.Package.Path, g.nameTypeInfo.Name))
 
@@ -106,6 +113,8 @@ func (g *Generator) GenerateMethod(m *gomosaic.MethodInfo, beforeNextBodyFn, aft
 		callFunc = jen.Add(resultList).Op(":=").Add(callFunc)
 	}
 
+	g.sources.SetDeclSource(g.structName+"."+m.Name, g.nameTypeInfo, m)
+
 	code := jen.Func().
 		Params(
 			jen.Id("m").Op("*").Id(g.structName),
@@ -0,0 +1,173 @@
+package lsp
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// scheduleDiagnostics пересчитывает диагностики пакета документа после задержки.
+// Повторные изменения в течение задержки откладывают пересчет.
+func (s *Server) scheduleDiagnostics(ctx context.Context, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[uri]; ok {
+		timer.Stop()
+	}
+	s.timers[uri] = time.AfterFunc(s.delay, func() {
+		s.publishDiagnostics(ctx, uri)
+	})
+}
+
+// publishDiagnostics загружает пакет документа с учетом несохраненных изменений открытых файлов,
+// проверяет аннотации загрузчиками плагинов и отправляет диагностики по файлам пакета
+func (s *Server) publishDiagnostics(ctx context.Context, uri string) {
+	doc, ok := s.document(uri)
+	if !ok {
+		return
+	}
+
+	files, err := s.diagnose(ctx, doc)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logError(err)
+		}
+		return
+	}
+
+	if current, ok := s.document(uri); !ok || current.version != doc.version {
+		// Документ изменился во время проверки, диагностики будут пересчитаны заново
+		return
+	}
+
+	s.mu.Lock()
+	var publish []*PublishDiagnosticsParams
+	for fileURI, diagnostics := range files {
+		if len(diagnostics) == 0 && !s.published[fileURI] {
+			continue
+		}
+		s.published[fileURI] = len(diagnostics) > 0
+		publish = append(publish, &PublishDiagnosticsParams{URI: fileURI, Diagnostics: diagnostics})
+	}
+	s.mu.Unlock()
+
+	for _, params := range publish {
+		s.notify("textDocument/publishDiagnostics", params)
+	}
+}
+
+// diagnose возвращает диагностики для всех файлов пакета документа
+func (s *Server) diagnose(ctx context.Context, doc *document) (map[string][]Diagnostic, error) {
+	dir := filepath.Dir(doc.path)
+
+	module := &gomosaic.ModuleInfo{Dir: dir}
+	if goModPath, ok := gomosaic.FindGoMod(dir); ok {
+		var err error
+		module, err = gomosaic.LoadModuleInfo(goModPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+		Dir:     dir,
+		Overlay: s.overlay(),
+	}
+	pkgs, err := s.load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]Diagnostic)
+	for _, pkg := range pkgs {
+		for _, filename := range pkg.GoFiles {
+			files[pathToURI(filename)] = []Diagnostic{}
+		}
+	}
+	files[doc.uri] = []Diagnostic{}
+
+	types, err := gomosaic.ParseLoadedPackages(pkgs)
+	if err == nil {
+		err = gomosaic.CheckAnnotations(ctx, s.pluginManager, module, types)
+	}
+
+	lines := make(map[string][]string)
+	for _, e := range gomosaic.Diagnostics(err) {
+		pos, message := gomosaic.DiagnosticPosition(e)
+		severity := severityError
+		if gomosaic.IsErrWarning(e) {
+			severity = severityWarning
+		}
+
+		fileURI := doc.uri
+		if pos.IsValid() && pos.Filename != "" {
+			fileURI = pathToURI(pos.Filename)
+		}
+
+		fileLines, ok := lines[fileURI]
+		if !ok {
+			fileLines = s.fileLines(fileURI)
+			lines[fileURI] = fileLines
+		}
+
+		files[fileURI] = append(files[fileURI], Diagnostic{
+			Range:    diagnosticRange(fileLines, pos),
+			Severity: severity,
+			Code:     gomosaic.MessageID(e),
+			Source:   "gomosaic",
+			Message:  message,
+		})
+	}
+
+	return files, nil
+}
+
+// diagnosticRange возвращает диапазон аннотации на строке позиции ошибки
+// (позиция аннотации указывает на конец комментария) или начало файла
+func diagnosticRange(lines []string, pos token.Position) Range {
+	if !pos.IsValid() || pos.Line > len(lines) {
+		return Range{}
+	}
+
+	n := pos.Line - 1
+	line := lines[n]
+	start := strings.Index(line, "@")
+	if start == -1 {
+		start = len(line) - len(strings.TrimLeft(line, " \t"))
+	}
+	return lineRange(n, line, start, len(line))
+}
+
+// overlay возвращает содержимое открытых документов для загрузки пакетов
+func (s *Server) overlay() map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	overlay := make(map[string][]byte, len(s.docs))
+	for _, doc := range s.docs {
+		overlay[doc.path] = []byte(doc.text)
+	}
+	return overlay
+}
+
+// fileLines возвращает строки открытого документа или файла на диске
+func (s *Server) fileLines(uri string) []string {
+	if doc, ok := s.document(uri); ok {
+		return strings.Split(strings.ReplaceAll(doc.text, "\r\n", "\n"), "\n")
+	}
+	data, err := os.ReadFile(uriToPath(uri))
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+}
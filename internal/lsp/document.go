@@ -0,0 +1,242 @@
+package lsp
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// document открытый в редакторе файл
+type document struct {
+	uri     string
+	path    string
+	version int
+	text    string
+}
+
+func (d *document) line(n int) string {
+	lines := strings.Split(d.text, "\n")
+	if n < 0 || n >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[n], "\r")
+}
+
+// uriToPath возвращает путь к файлу для URI вида file:///path
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI возвращает URI вида file:///path для пути к файлу
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// byteOffset переводит позицию символа в строке в единицах UTF-16 (как в LSP) в смещение в байтах
+func byteOffset(line string, character int) int {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// utf16Offset переводит смещение в байтах в позицию символа в единицах UTF-16
+func utf16Offset(line string, offset int) (units int) {
+	for i, r := range line {
+		if i >= offset {
+			break
+		}
+		units += utf16.RuneLen(r)
+	}
+	return units
+}
+
+// lineRange возвращает диапазон строки line между смещениями start и end в байтах
+func lineRange(n int, line string, start, end int) Range {
+	return Range{
+		Start: Position{Line: n, Character: utf16Offset(line, start)},
+		End:   Position{Line: n, Character: utf16Offset(line, end)},
+	}
+}
+
+// span часть строки с аннотацией
+type span struct {
+	start, end int // смещения в байтах
+	text       string
+}
+
+func (s span) contains(offset int) bool {
+	return s.start <= offset && offset <= s.end
+}
+
+// annotationLine строка комментария с аннотацией вида // @key value name=value
+type annotationLine struct {
+	key    span   // ключ вместе с @
+	values []span // значения, параметры и опции
+}
+
+// parseAnnotationLine разбирает строку, которая состоит только из комментария с аннотацией.
+// Части разделяются пробелами вне кавычек, как в annotation.Parse.
+func parseAnnotationLine(line string) (*annotationLine, bool) {
+	rest := strings.TrimLeftFunc(line, unicode.IsSpace)
+	if !strings.HasPrefix(rest, "//") {
+		return nil, false
+	}
+	rest = strings.TrimPrefix(rest, "//")
+	offset := len(line) - len(rest)
+	offset += len(rest) - len(strings.TrimLeftFunc(rest, unicode.IsSpace))
+	if !strings.HasPrefix(line[offset:], "@") {
+		return nil, false
+	}
+
+	spans := splitSpans(line, offset)
+	return &annotationLine{key: spans[0], values: spans[1:]}, true
+}
+
+func splitSpans(line string, offset int) (spans []span) {
+	var (
+		start    = -1
+		inQuotes bool
+		escape   bool
+	)
+	for i, r := range line[offset:] {
+		i += offset
+		switch {
+		case escape:
+			escape = false
+		case r == '\\':
+			escape = true
+		case r == '"' || r == '\'':
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			if start != -1 {
+				spans = append(spans, span{start: start, end: i, text: line[start:i]})
+				start = -1
+			}
+			continue
+		}
+		if start == -1 {
+			start = i
+		}
+	}
+	if start != -1 {
+		spans = append(spans, span{start: start, end: len(line), text: line[start:]})
+	}
+	return spans
+}
+
+// valueAt возвращает номер значения под смещением offset и часть значения до offset.
+// Если offset находится между значениями, возвращается номер нового значения.
+func (a *annotationLine) valueAt(offset int) (idx int, current span) {
+	for i, v := range a.values {
+		if v.contains(offset) {
+			return i, v
+		}
+		if v.end < offset {
+			idx = i + 1
+		}
+	}
+	return idx, span{start: offset, end: offset}
+}
+
+// commentTarget объект модели, к которому относится комментарий
+type commentTarget struct {
+	target gomosaic.AnnotationTarget
+	method *ast.FuncType // сигнатура метода интерфейса для методов, параметров и результатов
+	fset   *token.FileSet
+}
+
+// targetAt находит объект, к которому относится комментарий на строке n (с нуля):
+// интерфейс, метод интерфейса, параметр или результат метода на следующей после комментария строке.
+func (d *document) targetAt(n int) *commentTarget {
+	fset := token.NewFileSet()
+	file, _ := parser.ParseFile(fset, d.path, d.text, parser.ParseComments|parser.SkipObjectResolution|parser.AllErrors)
+	if file == nil {
+		return nil
+	}
+
+	line := n + 1
+	next := 0
+	for _, group := range file.Comments {
+		if fset.Position(group.Pos()).Line <= line && line <= fset.Position(group.End()).Line {
+			next = fset.Position(group.End()).Line + 1
+			break
+		}
+	}
+	if next == 0 {
+		return nil
+	}
+
+	lineOf := func(node ast.Node) int { return fset.Position(node.Pos()).Line }
+
+	var result *commentTarget
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if result != nil || !ok {
+			return result == nil
+		}
+		iface, ok := spec.Type.(*ast.InterfaceType)
+		if !ok {
+			return false
+		}
+		if lineOf(spec.Name) == next {
+			result = &commentTarget{target: gomosaic.TargetInterface, fset: fset}
+			return false
+		}
+		for _, m := range iface.Methods.List {
+			fn, ok := m.Type.(*ast.FuncType)
+			if !ok || len(m.Names) == 0 {
+				continue
+			}
+			if lineOf(m.Names[0]) == next {
+				result = &commentTarget{target: gomosaic.TargetMethod, method: fn, fset: fset}
+				return false
+			}
+			if findField(fn.Params, next, lineOf) {
+				result = &commentTarget{target: gomosaic.TargetParam, method: fn, fset: fset}
+				return false
+			}
+			if findField(fn.Results, next, lineOf) {
+				result = &commentTarget{target: gomosaic.TargetResult, method: fn, fset: fset}
+				return false
+			}
+		}
+		return false
+	})
+	return result
+}
+
+func findField(fields *ast.FieldList, line int, lineOf func(ast.Node) int) bool {
+	if fields == nil {
+		return false
+	}
+	for _, field := range fields.List {
+		if lineOf(field) == line {
+			return true
+		}
+	}
+	return false
+}
+
+// identRange возвращает диапазон идентификатора в документе
+func (d *document) identRange(fset *token.FileSet, ident *ast.Ident) Range {
+	pos := fset.Position(ident.Pos())
+	line := d.line(pos.Line - 1)
+	start := pos.Column - 1
+	return lineRange(pos.Line-1, line, start, start+len(ident.Name))
+}
@@ -0,0 +1,248 @@
+package lsp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+// pathAnnotation аннотация пути HTTP метода с параметрами вида :name
+const pathAnnotation = "http-path"
+
+// cursor аннотация под курсором
+type cursor struct {
+	doc        *document
+	line       int
+	text       string // строка под курсором
+	offset     int    // смещение курсора в строке в байтах
+	annotation *annotationLine
+}
+
+func (s *Server) cursorAt(params TextDocumentPositionParams) (*cursor, bool) {
+	doc, ok := s.document(params.TextDocument.URI)
+	if !ok {
+		return nil, false
+	}
+
+	text := doc.line(params.Position.Line)
+	a, ok := parseAnnotationLine(text)
+	if !ok {
+		return nil, false
+	}
+
+	return &cursor{
+		doc:        doc,
+		line:       params.Position.Line,
+		text:       text,
+		offset:     byteOffset(text, params.Position.Character),
+		annotation: a,
+	}, true
+}
+
+func (c *cursor) target() gomosaic.AnnotationTarget {
+	if t := c.doc.targetAt(c.line); t != nil {
+		return t.target
+	}
+	return ""
+}
+
+// lookup возвращает описание аннотации с ключом key. Если объект комментария
+// определить не удалось, подходит описание для любого объекта.
+func (s *Server) lookup(key string, target gomosaic.AnnotationTarget) (*gomosaic.AnnotationDoc, bool) {
+	if target != "" {
+		return gomosaic.LookupAnnotation(s.schemas, key, target)
+	}
+	for _, schema := range s.schemas {
+		for _, doc := range schema.Annotations {
+			if doc.Key == key {
+				return doc, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) completion(params TextDocumentPositionParams) *CompletionList {
+	list := &CompletionList{Items: []CompletionItem{}}
+
+	c, ok := s.cursorAt(params)
+	if !ok {
+		return list
+	}
+	target := c.target()
+
+	if c.annotation.key.contains(c.offset) {
+		editRange := lineRange(c.line, c.text, c.annotation.key.start+1, c.annotation.key.end)
+		seen := make(map[string]bool)
+		for _, schema := range s.schemas {
+			for _, doc := range schema.Annotations {
+				if (target != "" && doc.Target != target) || seen[doc.Key] {
+					continue
+				}
+				seen[doc.Key] = true
+				list.Items = append(list.Items, CompletionItem{
+					Label:         doc.Key,
+					Kind:          completionKindKeyword,
+					Detail:        doc.Title,
+					Documentation: &MarkupContent{Kind: markupKindMarkdown, Value: formatDoc(doc)},
+					TextEdit:      &TextEdit{Range: editRange, NewText: doc.Key},
+				})
+			}
+		}
+		return list
+	}
+
+	doc, ok := s.lookup(strings.TrimPrefix(c.annotation.key.text, "@"), target)
+	if !ok {
+		return list
+	}
+
+	idx, current := c.annotation.valueAt(c.offset)
+	editRange := lineRange(c.line, c.text, current.start, current.end)
+	item := func(label string, kind int, detail string) {
+		list.Items = append(list.Items, CompletionItem{
+			Label:    label,
+			Kind:     kind,
+			Detail:   detail,
+			TextEdit: &TextEdit{Range: editRange, NewText: label},
+		})
+	}
+
+	if idx == 0 {
+		for _, value := range doc.Values {
+			item(value, completionKindValue, doc.ValueDescr)
+		}
+	}
+	if doc.Type == "inline" && (idx > 0 || len(doc.Values) == 0) {
+		used := make(map[string]bool)
+		for i, v := range c.annotation.values {
+			if i != idx {
+				name, _, _ := strings.Cut(v.text, "=")
+				used[name] = true
+			}
+		}
+		for _, param := range doc.Params {
+			if !used[param] {
+				item(param+"=", completionKindProperty, i18n.T("lsp.completion.param", doc.Key))
+			}
+		}
+		for _, option := range doc.Options {
+			if !used[option] {
+				item(option, completionKindField, i18n.T("lsp.completion.option", doc.Key))
+			}
+		}
+	}
+
+	return list
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	c, ok := s.cursorAt(params)
+	if !ok || !c.annotation.key.contains(c.offset) {
+		return nil
+	}
+
+	doc, ok := s.lookup(strings.TrimPrefix(c.annotation.key.text, "@"), c.target())
+	if !ok {
+		return nil
+	}
+
+	keyRange := lineRange(c.line, c.text, c.annotation.key.start, c.annotation.key.end)
+	return &Hover{
+		Contents: MarkupContent{Kind: markupKindMarkdown, Value: formatDoc(doc)},
+		Range:    &keyRange,
+	}
+}
+
+// definition переходит от параметра пути :name в @http-path к параметру метода,
+// имя которого в lowerCamelCase совпадает с name
+func (s *Server) definition(params TextDocumentPositionParams) *Location {
+	c, ok := s.cursorAt(params)
+	if !ok || c.annotation.key.text != "@"+pathAnnotation {
+		return nil
+	}
+
+	name, ok := pathParamAt(c.annotation, c.offset)
+	if !ok {
+		return nil
+	}
+
+	t := c.doc.targetAt(c.line)
+	if t == nil || t.target != gomosaic.TargetMethod || t.method.Params == nil {
+		return nil
+	}
+
+	for _, field := range t.method.Params.List {
+		for _, ident := range field.Names {
+			if strcase.ToLowerCamel(ident.Name) == name {
+				return &Location{URI: c.doc.uri, Range: c.doc.identRange(t.fset, ident)}
+			}
+		}
+	}
+	return nil
+}
+
+// pathParamAt возвращает имя параметра пути :name под смещением offset
+func pathParamAt(a *annotationLine, offset int) (string, bool) {
+	for _, v := range a.values {
+		if !v.contains(offset) {
+			continue
+		}
+		start := v.start
+		for _, part := range strings.Split(v.text, "/") {
+			end := start + len(part)
+			if strings.HasPrefix(part, ":") && start <= offset && offset <= end {
+				return part[1:], true
+			}
+			start = end + 1
+		}
+	}
+	return "", false
+}
+
+// formatDoc возвращает описание аннотации в формате Markdown
+func formatDoc(doc *gomosaic.AnnotationDoc) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "**@%s**", doc.Key)
+	if doc.Title != "" {
+		fmt.Fprintf(&b, " — %s", doc.Title)
+	}
+	b.WriteString("\n\n")
+	if doc.Description != "" {
+		b.WriteString(strings.ReplaceAll(doc.Description, "\n", "  \n"))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(i18n.T("lsp.hover.target", doc.Target, doc.Type))
+	if doc.ValueDescr != "" {
+		b.WriteString("  \n" + i18n.T("lsp.hover.value", doc.ValueDescr))
+	}
+	if len(doc.Values) > 0 {
+		b.WriteString("  \n" + i18n.T("lsp.hover.values", codeList(doc.Values, "")))
+	}
+	if len(doc.Params) > 0 {
+		b.WriteString("  \n" + i18n.T("lsp.hover.params", codeList(doc.Params, "=")))
+	}
+	if len(doc.Options) > 0 {
+		b.WriteString("  \n" + i18n.T("lsp.hover.options", codeList(doc.Options, "")))
+	}
+
+	for _, example := range doc.Examples {
+		fmt.Fprintf(&b, "\n\n```go\n// %s\n```", example)
+	}
+
+	return b.String()
+}
+
+func codeList(values []string, suffix string) string {
+	values = slices.Clone(values)
+	for i, v := range values {
+		values[i] = "`" + v + suffix + "`"
+	}
+	return strings.Join(values, ", ")
+}
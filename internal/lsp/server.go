@@ -0,0 +1,320 @@
+// Package lsp реализует языковой сервер для аннотаций gomosaic в комментариях Go.
+// Сервер работает по протоколу LSP через stdin/stdout и дополняет возможности
+// основного языкового сервера Go: автодополнение ключей и значений аннотаций,
+// документацию при наведении, диагностики загрузчиков аннотаций плагинов
+// и переход от параметров @http-path к параметрам метода.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const defaultDiagnosticsDelay = 300 * time.Millisecond
+
+// LoadFunc загружает пакеты для диагностик, по умолчанию packages.Load
+type LoadFunc func(cfg *packages.Config, patterns ...string) ([]*packages.Package, error)
+
+type Option func(*Server)
+
+// WithLoader заменяет функцию загрузки пакетов
+func WithLoader(load LoadFunc) Option {
+	return func(s *Server) {
+		s.load = load
+	}
+}
+
+// WithDiagnosticsDelay задает задержку перед пересчетом диагностик после изменения файла
+func WithDiagnosticsDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.delay = delay
+	}
+}
+
+// WithVersion задает версию, которую сервер сообщает клиенту
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// Server языковой сервер аннотаций
+type Server struct {
+	pluginManager *gomosaic.PluginManager
+	schemas       []*gomosaic.AnnotationSchema
+	load          LoadFunc
+	delay         time.Duration
+	version       string
+
+	mu        sync.Mutex
+	docs      map[string]*document
+	timers    map[string]*time.Timer
+	published map[string]bool
+
+	wmu sync.Mutex
+	w   io.Writer
+}
+
+// NewServer создает языковой сервер для аннотаций плагинов менеджера pm
+func NewServer(pm *gomosaic.PluginManager, opts ...Option) *Server {
+	s := &Server{
+		pluginManager: pm,
+		schemas:       pm.AnnotationSchemas(),
+		load:          packages.Load,
+		delay:         defaultDiagnosticsDelay,
+		docs:          make(map[string]*document),
+		timers:        make(map[string]*time.Timer),
+		published:     make(map[string]bool),
+	}
+	for _, optApply := range opts {
+		optApply(s)
+	}
+	return s
+}
+
+// Run обрабатывает сообщения клиента до уведомления exit или закрытия r
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer s.stopTimers()
+
+	s.w = w
+	reader := bufio.NewReader(r)
+	for {
+		data, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			if err := s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(ctx, &req)
+		if req.isNotification() {
+			// На уведомления не отвечают, а необработанные уведомления,
+			// например workspace/didChangeConfiguration, игнорируются
+			var rpcErr *responseError
+			if err != nil && !(errors.As(err, &rpcErr) && rpcErr.Code == codeMethodNotFound) {
+				s.logError(err)
+			}
+			continue
+		}
+		if err := s.reply(req.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req *request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.stopTimers()
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.didOpen(ctx, params)
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.didChange(ctx, params)
+		return nil, nil
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.scheduleDiagnostics(ctx, params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.didClose(params)
+		return nil, nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params), nil
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: i18n.T("lsp.method-not-found", req.Method)}
+}
+
+func unmarshalParams(req *request, v any) error {
+	if err := json.Unmarshal(req.Params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) initialize() *InitializeResult {
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      true,
+			},
+			CompletionProvider: CompletionOptions{TriggerCharacters: []string{"@", "-", " "}},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+		},
+		ServerInfo: &ServerInfo{Name: "gomosaic", Version: s.version},
+	}
+}
+
+func (s *Server) didOpen(ctx context.Context, params DidOpenTextDocumentParams) {
+	s.mu.Lock()
+	s.docs[params.TextDocument.URI] = &document{
+		uri:     params.TextDocument.URI,
+		path:    uriToPath(params.TextDocument.URI),
+		version: params.TextDocument.Version,
+		text:    params.TextDocument.Text,
+	}
+	s.mu.Unlock()
+
+	s.scheduleDiagnostics(ctx, params.TextDocument.URI)
+}
+
+func (s *Server) didChange(ctx context.Context, params DidChangeTextDocumentParams) {
+	if len(params.ContentChanges) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	doc, ok := s.docs[params.TextDocument.URI]
+	if ok {
+		// Сервер объявляет полную синхронизацию, поэтому последнее изменение содержит весь текст
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		doc.version = params.TextDocument.Version
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.scheduleDiagnostics(ctx, params.TextDocument.URI)
+	}
+}
+
+func (s *Server) didClose(params DidCloseTextDocumentParams) {
+	uri := params.TextDocument.URI
+
+	s.mu.Lock()
+	delete(s.docs, uri)
+	if timer, ok := s.timers[uri]; ok {
+		timer.Stop()
+		delete(s.timers, uri)
+	}
+	published := s.published[uri]
+	delete(s.published, uri)
+	s.mu.Unlock()
+
+	if published {
+		s.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+	}
+}
+
+// document возвращает копию открытого документа
+func (s *Server) document(uri string) (*document, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.docs[uri]
+	if !ok {
+		return nil, false
+	}
+	docCopy := *doc
+	return &docCopy, true
+}
+
+func (s *Server) reply(id json.RawMessage, result any, err error) error {
+	resp := &response{JSONRPC: jsonrpcVersion, ID: id}
+	if id == nil {
+		resp.ID = json.RawMessage("null")
+	}
+
+	if err != nil {
+		var respErr *responseError
+		if !errors.As(err, &respErr) {
+			respErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Error = respErr
+	} else {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		resp.Result = data
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	return writeMessage(s.w, resp)
+}
+
+func (s *Server) notify(method string, params any) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	// Ошибка записи означает, что клиент отключился, и будет получена при чтении следующего сообщения
+	_ = writeMessage(s.w, &notification{JSONRPC: jsonrpcVersion, Method: method, Params: params})
+}
+
+func (s *Server) logError(err error) {
+	s.notify("window/logMessage", &LogMessageParams{Type: messageTypeError, Message: err.Error()})
+}
+
+func (s *Server) stopTimers() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uri, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, uri)
+	}
+}
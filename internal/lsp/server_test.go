@@ -0,0 +1,378 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	httpplugin "github.com/go-mosaic/gomosaic/internal/plugin/http"
+	"github.com/go-mosaic/gomosaic/internal/plugin/logmiddleware"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+const testSource = `package svc
+
+import "context"
+
+// @gomosaic
+type UserService interface {
+	// @http-method GET
+	// @http-path /users/:userID
+	GetUser(ctx context.Context, userID string) (name string, err error)
+	// @http-metod POST
+	CreateUser(
+		ctx context.Context,
+		// @http-name user_name omitempty
+		name string,
+	) (err error)
+}
+`
+
+// testClient клиент языкового сервера, подключенный через каналы в памяти
+type testClient struct {
+	t        *testing.T
+	w        io.Writer
+	id       int
+	messages chan map[string]json.RawMessage
+}
+
+func startServer(t *testing.T) (*testClient, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/svc\n\ngo 1.24\n")
+	path := filepath.Join(dir, "svc.go")
+	writeFile(t, path, testSource)
+
+	pm := gomosaic.NewPluginManager()
+	pm.RegisterPlugin(new(httpplugin.PluginServerChi))
+	pm.RegisterPlugin(new(logmiddleware.Plugin))
+
+	server := NewServer(pm,
+		WithDiagnosticsDelay(0),
+		WithLoader(func(cfg *packages.Config, patterns ...string) ([]*packages.Package, error) {
+			cfg.Mode |= packages.NeedImports | packages.NeedDeps
+			cfg.Env = append(os.Environ(), "GOFLAGS=", "GOPROXY=off")
+			return packages.Load(cfg, patterns...)
+		}),
+	)
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Run(context.Background(), serverR, serverW)
+		serverW.Close()
+	}()
+
+	c := &testClient{t: t, w: clientW, messages: make(chan map[string]json.RawMessage, 16)}
+	go func() {
+		r := bufio.NewReader(clientR)
+		for {
+			data, err := readMessage(r)
+			if err != nil {
+				close(c.messages)
+				return
+			}
+			var msg map[string]json.RawMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Error(err)
+			}
+			c.messages <- msg
+		}
+	}()
+
+	t.Cleanup(func() {
+		c.notify("exit", nil)
+		if err := <-done; err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+		clientW.Close()
+	})
+
+	return c, path
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	if err := writeMessage(c.w, &notification{JSONRPC: jsonrpcVersion, Method: method, Params: params}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// call отправляет запрос и возвращает результат ответа, пропуская уведомления сервера
+func (c *testClient) call(method string, params, result any) {
+	c.t.Helper()
+
+	c.id++
+	id, _ := json.Marshal(c.id)
+	msg := map[string]any{"jsonrpc": jsonrpcVersion, "id": c.id, "method": method, "params": params}
+	if err := writeMessage(c.w, msg); err != nil {
+		c.t.Fatal(err)
+	}
+
+	for msg := range c.wait("ответ на " + method) {
+		if string(msg["id"]) != string(id) {
+			continue
+		}
+		if msg["error"] != nil {
+			c.t.Fatalf("%s: %s", method, msg["error"])
+		}
+		if err := json.Unmarshal(msg["result"], result); err != nil {
+			c.t.Fatal(err)
+		}
+		return
+	}
+}
+
+// diagnostics ждет диагностики для документа uri
+func (c *testClient) diagnostics(uri string) []Diagnostic {
+	c.t.Helper()
+
+	for msg := range c.wait("диагностики " + uri) {
+		if string(msg["method"]) != `"textDocument/publishDiagnostics"` {
+			continue
+		}
+		var params PublishDiagnosticsParams
+		if err := json.Unmarshal(msg["params"], &params); err != nil {
+			c.t.Fatal(err)
+		}
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+	return nil
+}
+
+func (c *testClient) wait(what string) func(yield func(map[string]json.RawMessage) bool) {
+	return func(yield func(map[string]json.RawMessage) bool) {
+		timeout := time.After(time.Minute)
+		for {
+			select {
+			case msg, ok := <-c.messages:
+				if !ok {
+					c.t.Fatalf("сервер завершил работу, ожидалось: %s", what)
+				}
+				if !yield(msg) {
+					return
+				}
+			case <-timeout:
+				c.t.Fatalf("не дождались: %s", what)
+			}
+		}
+	}
+}
+
+func openDocument(t *testing.T) (*testClient, string) {
+	t.Helper()
+
+	c, path := startServer(t)
+	var result InitializeResult
+	c.call("initialize", map[string]any{}, &result)
+	if !result.Capabilities.HoverProvider || result.Capabilities.TextDocumentSync.Change != textDocumentSyncFull {
+		t.Fatalf("initialize: неожиданные возможности сервера %+v", result.Capabilities)
+	}
+	c.notify("initialized", map[string]any{})
+
+	uri := pathToURI(path)
+	c.notify("textDocument/didOpen", &DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "go", Version: 1, Text: testSource},
+	})
+	return c, uri
+}
+
+func position(uri string, line, character int) *TextDocumentPositionParams {
+	return &TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func TestServerCompletion(t *testing.T) {
+	c, uri := openDocument(t)
+
+	tests := []struct {
+		name      string
+		line      int
+		character int
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "ключи метода",
+			line:      6,
+			character: len("\t// @http-m"),
+			want:      []string{"http-method", "http-path", "log-skip"},
+			notWant:   []string{"gomosaic", "http-client-enable", "http-name"},
+		},
+		{
+			name:      "ключи интерфейса",
+			line:      4,
+			character: len("// @"),
+			want:      []string{"gomosaic", "http-client-enable"},
+			notWant:   []string{"http-method"},
+		},
+		{
+			name:      "значения из valid:in",
+			line:      6,
+			character: len("\t// @http-method G"),
+			want:      []string{"GET", "POST", "PATCH"},
+		},
+		{
+			name:      "параметры и опции inline аннотации",
+			line:      12,
+			character: len("\t\t// @http-name u"),
+			want:      []string{"format="},
+			notWant:   []string{"omitempty"},
+		},
+		{
+			name:      "не аннотация",
+			line:      8,
+			character: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list CompletionList
+			c.call("textDocument/completion", position(uri, tt.line, tt.character), &list)
+
+			labels := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				labels = append(labels, item.Label)
+			}
+			for _, label := range tt.want {
+				if !slices.Contains(labels, label) {
+					t.Errorf("нет %s в %v", label, labels)
+				}
+			}
+			for _, label := range tt.notWant {
+				if slices.Contains(labels, label) {
+					t.Errorf("лишнее %s в %v", label, labels)
+				}
+			}
+			if tt.want == nil && len(labels) > 0 {
+				t.Errorf("ожидался пустой список, получено %v", labels)
+			}
+		})
+	}
+}
+
+func TestServerHover(t *testing.T) {
+	c, uri := openDocument(t)
+
+	var hover Hover
+	c.call("textDocument/hover", position(uri, 6, len("\t// @http-me")), &hover)
+	if !strings.HasPrefix(hover.Contents.Value, "**@http-method** — Метод запроса HTTP") || !strings.Contains(hover.Contents.Value, "`GET`") {
+		t.Errorf("hover = %q", hover.Contents.Value)
+	}
+	if want := (Range{Start: Position{Line: 6, Character: 4}, End: Position{Line: 6, Character: 16}}); hover.Range == nil || *hover.Range != want {
+		t.Errorf("hover range = %v, want %v", hover.Range, want)
+	}
+}
+
+func TestServerDefinition(t *testing.T) {
+	c, uri := openDocument(t)
+
+	var location *Location
+	c.call("textDocument/definition", position(uri, 7, len("\t// @http-path /users/:us")), &location)
+
+	want := &Location{URI: uri, Range: Range{
+		Start: Position{Line: 8, Character: len("\tGetUser(ctx context.Context, ")},
+		End:   Position{Line: 8, Character: len("\tGetUser(ctx context.Context, userID")},
+	}}
+	if location == nil || *location != *want {
+		t.Errorf("definition = %+v, want %+v", location, want)
+	}
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	c, uri := openDocument(t)
+
+	diagnostics := c.diagnostics(uri)
+	if len(diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v", diagnostics)
+	}
+	want := Diagnostic{
+		Range:    Range{Start: Position{Line: 9, Character: 4}, End: Position{Line: 9, Character: 20}},
+		Severity: severityError,
+		Code:     "annotation.unknown-suggestion",
+		Source:   "gomosaic",
+		Message:  "неизвестная аннотация @http-metod для method, возможно, имелась в виду @http-method",
+	}
+	if diagnostics[0] != want {
+		t.Errorf("diagnostic = %+v, want %+v", diagnostics[0], want)
+	}
+
+	fixed := strings.Replace(testSource, "@http-metod", "@http-method", 1)
+	c.notify("textDocument/didChange", &DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: fixed}},
+	})
+	if diagnostics := c.diagnostics(uri); len(diagnostics) != 0 {
+		t.Errorf("после исправления diagnostics = %+v", diagnostics)
+	}
+}
+
+func TestUTF16Offsets(t *testing.T) {
+	line := "\t// @http-path /пользователи/:id"
+	offset := strings.Index(line, ":id")
+	character := utf16Offset(line, offset)
+	if want := len("\t// @http-path /") + len([]rune("пользователи/")); character != want {
+		t.Fatalf("utf16Offset() = %d, want %d", character, want)
+	}
+	if got := byteOffset(line, character); got != offset {
+		t.Errorf("byteOffset() = %d, want %d", got, offset)
+	}
+}
+
+func TestServerUnknownMethods(t *testing.T) {
+	c, _ := startServer(t)
+
+	// Неизвестное уведомление игнорируется без ответа и сообщения в журнал
+	c.notify("workspace/didChangeConfiguration", map[string]any{"settings": map[string]any{}})
+
+	msg := map[string]any{"jsonrpc": jsonrpcVersion, "id": 1, "method": "workspace/symbol", "params": map[string]any{}}
+	if err := writeMessage(c.w, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	for msg := range c.wait("ответ на workspace/symbol") {
+		if method := string(msg["method"]); method != "" {
+			t.Errorf("неожиданное сообщение сервера %s: %s", method, msg["params"])
+			continue
+		}
+
+		var rpcErr responseError
+		if err := json.Unmarshal(msg["error"], &rpcErr); err != nil {
+			t.Fatal(err)
+		}
+		if string(msg["id"]) != "1" || rpcErr.Code != codeMethodNotFound {
+			t.Errorf("ответ id = %s, error = %+v, want methodNotFound", msg["id"], rpcErr)
+		}
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package annotation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// CloneIfaceOpts возвращает глубокую копию модели HTTP API вместе с описаниями типов.
+// Ссылки между элементами модели (BodyParams на Params, Context на параметр метода и т.д.)
+// указывают на элементы копии.
+func CloneIfaceOpts(interfaces []*IfaceOpt) []*IfaceOpt {
+	if interfaces == nil {
+		return nil
+	}
+	result := make([]*IfaceOpt, len(interfaces))
+	for i, iface := range interfaces {
+		result[i] = iface.Clone()
+	}
+	return result
+}
+
+// Clone возвращает глубокую копию IfaceOpt
+func (o *IfaceOpt) Clone() *IfaceOpt {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.NameTypeInfo = o.NameTypeInfo.Clone()
+	c.Trace = maps.Clone(o.Trace)
+
+	funcs := make(map[*gomosaic.MethodInfo]*gomosaic.MethodInfo)
+	vars := make(map[*gomosaic.VarInfo]*gomosaic.VarInfo)
+	if o.NameTypeInfo != nil && o.NameTypeInfo.Type != nil && o.NameTypeInfo.Type.Interface != nil {
+		for i, m := range o.NameTypeInfo.Type.Interface.Methods {
+			cm := c.NameTypeInfo.Type.Interface.Methods[i]
+			funcs[m] = cm
+			for j, v := range m.Params {
+				vars[v] = cm.Params[j]
+			}
+			for j, v := range m.Results {
+				vars[v] = cm.Results[j]
+			}
+		}
+	}
+
+	if o.Methods != nil {
+		c.Methods = make([]*MethodOpt, len(o.Methods))
+		for i, m := range o.Methods {
+			c.Methods[i] = m.clone(&c, funcs, vars)
+		}
+	}
+	return &c
+}
+
+func (o *MethodOpt) clone(iface *IfaceOpt, funcs map[*gomosaic.MethodInfo]*gomosaic.MethodInfo, vars map[*gomosaic.VarInfo]*gomosaic.VarInfo) *MethodOpt {
+	c := *o
+	c.Iface = iface
+	c.Func = funcs[o.Func]
+	c.Context = vars[o.Context]
+	c.Error = vars[o.Error]
+	c.Trace = maps.Clone(o.Trace)
+	c.Openapi.Tags = slices.Clone(o.Openapi.Tags)
+	c.WrapReq.PathParts = slices.Clone(o.WrapReq.PathParts)
+	c.WrapResp.PathParts = slices.Clone(o.WrapResp.PathParts)
+	if o.Query.Values != nil {
+		c.Query.Values = make([]QueryValueOpt, len(o.Query.Values))
+		for i, v := range o.Query.Values {
+			c.Query.Values[i] = QueryValueOpt{Name: v.Name, Values: slices.Clone(v.Values)}
+		}
+	}
+
+	params := make(map[*MethodParamOpt]*MethodParamOpt, len(o.Params))
+	for _, p := range o.Params {
+		pc := *p
+		pc.Var = vars[p.Var]
+		pc.Trace = maps.Clone(p.Trace)
+		params[p] = &pc
+	}
+	mapParams := func(s []*MethodParamOpt) []*MethodParamOpt {
+		if s == nil {
+			return nil
+		}
+		result := make([]*MethodParamOpt, len(s))
+		for i, p := range s {
+			result[i] = params[p]
+		}
+		return result
+	}
+	c.Params = mapParams(o.Params)
+	c.BodyParams = mapParams(o.BodyParams)
+	c.QueryParams = mapParams(o.QueryParams)
+	c.HeaderParams = mapParams(o.HeaderParams)
+	c.CookieParams = mapParams(o.CookieParams)
+	c.PathParams = mapParams(o.PathParams)
+
+	results := make(map[*MethodResultOpt]*MethodResultOpt, len(o.Results))
+	for _, r := range o.Results {
+		rc := *r
+		rc.Var = vars[r.Var]
+		rc.Trace = maps.Clone(r.Trace)
+		results[r] = &rc
+	}
+	mapResults := func(s []*MethodResultOpt) []*MethodResultOpt {
+		if s == nil {
+			return nil
+		}
+		result := make([]*MethodResultOpt, len(s))
+		for i, r := range s {
+			result[i] = results[r]
+		}
+		return result
+	}
+	c.Results = mapResults(o.Results)
+	c.BodyResults = mapResults(o.BodyResults)
+	c.HeaderResults = mapResults(o.HeaderResults)
+	c.CookieResults = mapResults(o.CookieResults)
+
+	return &c
+}
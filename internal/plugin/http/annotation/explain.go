@@ -0,0 +1,105 @@
+package annotation
+
+import (
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+// traceName записывает, откуда получено имя параметра в запросе
+func traceName(trace option.Trace, nameOpt MethodParamNameOpt) {
+	if nameOpt.Value != "" {
+		trace.Set("Name", trace.Annotation("NameOpt.Value"), i18n.T("http.explain.name"))
+		return
+	}
+	format := nameOpt.Format
+	if _, ok := paramNameFormatters[format]; !ok {
+		format = paramNameDefaultFormatter
+	}
+	trace.Set("Name", trace.Annotation("NameOpt.Format"), i18n.T("http.explain.name-format", format))
+}
+
+// Explain возвращает разобранные параметры интерфейса iface или, если method не пустой,
+// параметры метода, его параметров и результатов с источниками значений
+func Explain(interfaces []*IfaceOpt, iface, method string) ([]*gomosaic.Explanation, error) {
+	for _, ifaceOpt := range interfaces {
+		if ifaceOpt.NameTypeInfo.Name != iface {
+			continue
+		}
+		if method == "" {
+			return []*gomosaic.Explanation{{
+				Title:  "IfaceOpt " + iface,
+				Fields: option.Explain(ifaceOpt, ifaceOpt.Trace),
+			}}, nil
+		}
+
+		for _, methodOpt := range ifaceOpt.Methods {
+			if methodOpt.Func.Name == method {
+				return explainMethod(iface+"."+method, methodOpt), nil
+			}
+		}
+		return nil, i18n.Errorf("http.explain.method", method, iface)
+	}
+	return nil, i18n.Errorf("http.explain.interface", iface)
+}
+
+func explainMethod(name string, methodOpt *MethodOpt) []*gomosaic.Explanation {
+	fields := option.Explain(methodOpt, methodOpt.Trace)
+	for _, group := range []struct {
+		field    string
+		httpType string
+		names    []string
+	}{
+		{"BodyParams", BodyHTTPType, paramNames(methodOpt.BodyParams)},
+		{"QueryParams", QueryHTTPType, paramNames(methodOpt.QueryParams)},
+		{"HeaderParams", HeaderHTTPType, paramNames(methodOpt.HeaderParams)},
+		{"CookieParams", CookieHTTPType, paramNames(methodOpt.CookieParams)},
+		{"PathParams", PathHTTPType, paramNames(methodOpt.PathParams)},
+		{"BodyResults", BodyHTTPType, resultNames(methodOpt.BodyResults)},
+		{"HeaderResults", HeaderHTTPType, resultNames(methodOpt.HeaderResults)},
+		{"CookieResults", CookieHTTPType, resultNames(methodOpt.CookieResults)},
+	} {
+		fields = append(fields, &gomosaic.ExplainField{
+			Path:   group.field,
+			Value:  "[" + strings.Join(group.names, " ") + "]",
+			Source: i18n.T("http.explain.http-type", group.httpType),
+		})
+	}
+
+	explanations := []*gomosaic.Explanation{{Title: "MethodOpt " + name, Fields: fields}}
+	for _, param := range methodOpt.Params {
+		if param.Var.IsContext {
+			continue
+		}
+		explanations = append(explanations, &gomosaic.Explanation{
+			Title:  "MethodParamOpt " + name + "(" + param.Var.Name + ")",
+			Fields: option.Explain(param, param.Trace),
+		})
+	}
+	for _, result := range methodOpt.Results {
+		if result.Var.IsError {
+			continue
+		}
+		explanations = append(explanations, &gomosaic.Explanation{
+			Title:  "MethodResultOpt " + name + " -> " + result.Var.Name,
+			Fields: option.Explain(result, result.Trace),
+		})
+	}
+	return explanations
+}
+
+func paramNames(params []*MethodParamOpt) (names []string) {
+	for _, param := range params {
+		names = append(names, param.Var.Name)
+	}
+	return names
+}
+
+func resultNames(results []*MethodResultOpt) (names []string) {
+	for _, result := range results {
+		names = append(names, result.Var.Name)
+	}
+	return names
+}
@@ -50,15 +50,15 @@ const (
 	BodyHTTPType   string = "body"
 )
 
-var paramNameFormatters = map[string]func(string) string{
-	"lowerCamel":     strcase.ToLowerCamel,
-	"kebab":          strcase.ToKebab,
-	"screamingKebab": strcase.ToScreamingKebab,
-	"snake":          strcase.ToSnake,
-	"screamingSnake": strcase.ToScreamingSnake,
+var paramNameFormatters = map[string]func(*strcase.Caser, string) string{
+	"lowerCamel":     (*strcase.Caser).ToLowerCamel,
+	"kebab":          (*strcase.Caser).ToKebab,
+	"screamingKebab": (*strcase.Caser).ToScreamingKebab,
+	"snake":          (*strcase.Caser).ToSnake,
+	"screamingSnake": (*strcase.Caser).ToScreamingSnake,
 }
 
-func formatName(name, defaultName string, format string) string {
+func formatName(caser *strcase.Caser, name, defaultName string, format string) string {
 	if name != "" {
 		return name
 	}
@@ -66,25 +66,25 @@ func formatName(name, defaultName string, format string) string {
 	if format == "" || !isNameParamFormatExists {
 		format = paramNameDefaultFormatter
 	}
-	return paramNameFormatters[format](defaultName)
+	return paramNameFormatters[format](caser, defaultName)
 }
 
-func WrapStruct(names []string, wrappedCode jen.Code) jen.Code {
+func WrapStruct(caser *strcase.Caser, names []string, wrappedCode jen.Code) jen.Code {
 	code := wrappedCode
 
 	for i := len(names) - 1; i >= 0; i-- {
-		code = jen.Id(strcase.ToCamel(names[i])).Struct(code).Tag(map[string]string{"json": names[i]})
+		code = jen.Id(caser.ToCamel(names[i])).Struct(code).Tag(map[string]string{"json": names[i]})
 	}
 
 	return code
 }
 
-func MakeStructFieldsFromParams(params []*MethodParamOpt, qual jenutils.QualFunc) jen.Code {
+func MakeStructFieldsFromParams(caser *strcase.Caser, params []*MethodParamOpt, qual jenutils.QualFunc) jen.Code {
 	structFields := jen.NewFile("")
 
 	for _, param := range params {
 		jsonTag := param.Name
-		fld := structFields.Id(strcase.ToCamel(param.Var.Name))
+		fld := structFields.Id(caser.ToCamel(param.Var.Name))
 		if !param.Required {
 			jsonTag += ",omitempty"
 		}
@@ -94,12 +94,12 @@ func MakeStructFieldsFromParams(params []*MethodParamOpt, qual jenutils.QualFunc
 	return structFields
 }
 
-func MakeStructFieldsFromResults(params []*MethodResultOpt, qual jenutils.QualFunc) jen.Code {
+func MakeStructFieldsFromResults(caser *strcase.Caser, params []*MethodResultOpt, qual jenutils.QualFunc) jen.Code {
 	structFields := jen.NewFile("")
 
 	for _, param := range params {
 		jsonTag := param.Name
-		fld := structFields.Id(strcase.ToCamel(param.Var.Name))
+		fld := structFields.Id(caser.ToCamel(param.Var.Name))
 		fld.Add(jenutils.TypeInfoQual(param.Var.Type, qual)).Tag(map[string]string{"json": jsonTag})
 	}
 
@@ -126,10 +126,10 @@ func MakeEmptyResults(results []*MethodResultOpt, qualFunc jenutils.QualFunc, ad
 	return append(codes, addinCodes...)
 }
 
-func Dot(parts ...string) jen.Code {
+func Dot(caser *strcase.Caser, parts ...string) jen.Code {
 	group := jen.Null()
 	for _, p := range parts {
-		group.Dot(strcase.ToCamel(p))
+		group.Dot(caser.ToCamel(p))
 	}
 
 	return group
@@ -0,0 +1,157 @@
+package annotation
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/lint"
+)
+
+// Идентификаторы правил стиля HTTP API
+const (
+	RulePathKebab   = "http-path-kebab"
+	RulePathPlural  = "http-path-plural"
+	RuleNoBody      = "http-no-body"
+	RulePathParamID = "http-path-param-id"
+	RuleQueryFormat = "http-query-format"
+	RuleOpenapiTags = "http-openapi-tags"
+)
+
+// LintRules возвращает правила стиля HTTP API
+func LintRules() []*lint.Rule {
+	return []*lint.Rule{
+		{ID: RulePathKebab, Description: i18n.T("http.lint.path-kebab"), Severity: lint.SeverityWarning},
+		{ID: RulePathPlural, Description: i18n.T("http.lint.path-plural"), Severity: lint.SeverityWarning},
+		{ID: RuleNoBody, Description: i18n.T("http.lint.no-body"), Severity: lint.SeverityError},
+		{ID: RulePathParamID, Description: i18n.T("http.lint.path-param-id"), Severity: lint.SeverityWarning},
+		{ID: RuleQueryFormat, Description: i18n.T("http.lint.query-format"), Severity: lint.SeverityWarning},
+		{ID: RuleOpenapiTags, Description: i18n.T("http.lint.openapi-tags"), Severity: lint.SeverityWarning},
+	}
+}
+
+var (
+	kebabSegment = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// braceParam параметр пути {name}, который загрузчик не распознает (см. миграцию http-path-params)
+	braceParam = regexp.MustCompile(`^\{[^/{}]*\}$`)
+)
+
+// Lint проверяет стиль HTTP API интерфейсов, prefix используется в именах аннотаций в сообщениях
+func Lint(interfaces []*IfaceOpt, prefix string) (issues []*lint.Issue) {
+	l := &linter{prefix: prefix}
+	for _, ifaceOpt := range interfaces {
+		for _, methodOpt := range ifaceOpt.Methods {
+			l.method(ifaceOpt, methodOpt)
+		}
+	}
+	l.queryFormat()
+	return l.issues
+}
+
+type linter struct {
+	prefix      string
+	issues      []*lint.Issue
+	queryParams []*queryParam
+}
+
+// queryParam query параметр с форматом имени для проверки единого формата
+type queryParam struct {
+	param       *MethodParamOpt
+	format      string
+	annotations []gomosaic.Annotations
+}
+
+func (l *linter) report(rule, message string, pos *gomosaic.PosInfo, annotations ...gomosaic.Annotations) {
+	l.issues = append(l.issues, &lint.Issue{Rule: rule, Message: message, Position: pos, Annotations: annotations})
+}
+
+func (l *linter) method(ifaceOpt *IfaceOpt, methodOpt *MethodOpt) {
+	methodAnnotations := []gomosaic.Annotations{methodOpt.Func.Annotations, ifaceOpt.NameTypeInfo.Annotations}
+
+	pathPos := methodOpt.Func.Pos
+	if a := methodOpt.Trace.Annotation("Path"); a != nil {
+		pathPos = a.Position
+	}
+
+	segments := strings.Split(methodOpt.Path, "/")
+	for i, segment := range segments {
+		if segment == "" || strings.HasPrefix(segment, ":") {
+			continue
+		}
+		if braceParam.MatchString(segment) {
+			l.report(RulePathKebab, i18n.T("http.lint.path-kebab.brace-param", segment), pathPos, methodAnnotations...)
+			continue
+		}
+		if !kebabSegment.MatchString(segment) {
+			l.report(RulePathKebab, i18n.T("http.lint.path-kebab.issue", segment), pathPos, methodAnnotations...)
+		}
+		if i+1 < len(segments) && strings.HasPrefix(segments[i+1], ":") && !strings.HasSuffix(segment, "s") {
+			l.report(RulePathPlural, i18n.T("http.lint.path-plural.issue", segment, segments[i+1]), pathPos, methodAnnotations...)
+		}
+	}
+
+	if slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodDelete}, methodOpt.Method) {
+		for _, param := range methodOpt.BodyParams {
+			l.report(RuleNoBody, i18n.T("http.lint.no-body.issue",
+				param.Var.Name, methodOpt.Method, l.prefix), param.Var.Pos, paramAnnotations(param, methodAnnotations)...)
+		}
+	}
+
+	for _, param := range methodOpt.PathParams {
+		name := param.Var.Name
+		// Только суффикс Id: uuid, paid и valid не являются идентификаторами вида userId
+		if len(name) > 2 && strings.HasSuffix(name, "Id") {
+			l.report(RulePathParamID, i18n.T("http.lint.path-param-id.issue", name, name[:len(name)-2]+"ID"), param.Var.Pos, paramAnnotations(param, methodAnnotations)...)
+		}
+	}
+
+	for _, param := range methodOpt.QueryParams {
+		// Имя, заданное явно, не зависит от формата
+		if param.NameOpt.Value != "" {
+			continue
+		}
+		format := param.NameOpt.Format
+		if _, ok := paramNameFormatters[format]; !ok {
+			format = paramNameDefaultFormatter
+		}
+		l.queryParams = append(l.queryParams, &queryParam{param: param, format: format, annotations: paramAnnotations(param, methodAnnotations)})
+	}
+
+	if len(methodOpt.Openapi.Tags) == 0 {
+		l.report(RuleOpenapiTags, i18n.T("http.lint.openapi-tags.issue", methodOpt.Func.Name, l.prefix), methodOpt.Func.Pos, methodAnnotations...)
+	}
+}
+
+// queryFormat сообщает о query параметрах, формат имени которых отличается от самого частого в API
+func (l *linter) queryFormat() {
+	counts := make(map[string]int)
+	for _, q := range l.queryParams {
+		counts[q.format]++
+	}
+	formats := make([]string, 0, len(counts))
+	for format := range counts {
+		formats = append(formats, format)
+	}
+	slices.Sort(formats)
+
+	// При равенстве предпочитается формат по умолчанию
+	expected := paramNameDefaultFormatter
+	for _, format := range formats {
+		if counts[format] > counts[expected] {
+			expected = format
+		}
+	}
+	for _, q := range l.queryParams {
+		if q.format != expected {
+			l.report(RuleQueryFormat, i18n.T("http.lint.query-format.issue",
+				q.param.Var.Name, q.format, expected), q.param.Var.Pos, q.annotations...)
+		}
+	}
+}
+
+func paramAnnotations(param *MethodParamOpt, parents []gomosaic.Annotations) []gomosaic.Annotations {
+	return append([]gomosaic.Annotations{param.Var.Annotations}, parents...)
+}
@@ -0,0 +1,67 @@
+package annotation
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		pathParams []string
+		want       []string
+	}{
+		{
+			name:       "идентификатор с суффиксом Id",
+			path:       "/users/:userId",
+			pathParams: []string{"userId"},
+			want:       []string{RulePathParamID},
+		},
+		{
+			name:       "слова, оканчивающиеся на id",
+			path:       "/files/:uuid/payments/:paid/checks/:valid",
+			pathParams: []string{"uuid", "paid", "valid"},
+		},
+		{
+			name:       "суффикс ID",
+			path:       "/users/:userID",
+			pathParams: []string{"userID"},
+		},
+		{
+			name: "параметр в фигурных скобках",
+			path: "/users/{id}",
+			want: []string{RulePathKebab},
+		},
+		{
+			name: "сегмент не в kebab-case",
+			path: "/user_profiles",
+			want: []string{RulePathKebab},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			methodOpt := &MethodOpt{
+				Method:  http.MethodGet,
+				Path:    tt.path,
+				Func:    &gomosaic.MethodInfo{Name: "Get"},
+				Openapi: MethodOpenapiOpt{Tags: []string{"users"}},
+			}
+			for _, name := range tt.pathParams {
+				methodOpt.PathParams = append(methodOpt.PathParams, &MethodParamOpt{Var: &gomosaic.VarInfo{Name: name}})
+			}
+			ifaceOpt := &IfaceOpt{NameTypeInfo: &gomosaic.NameTypeInfo{}, Methods: []*MethodOpt{methodOpt}}
+
+			var got []string
+			for _, issue := range Lint([]*IfaceOpt{ifaceOpt}, "http") {
+				got = append(got, issue.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package annotation
 
 import (
+	"context"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 	"github.com/go-mosaic/gomosaic/pkg/option"
 	"github.com/go-mosaic/gomosaic/pkg/strcase"
 )
@@ -38,7 +40,7 @@ type MethodOpt struct {
 	// @docgen-descr "Путь HTTP хендлера для обработки запроса или отправки клиента"
 	// @docgen-option-descr "HTTP путь, можно использовать именованный парамер, должен совпадать с именем параметра метода"
 	// @docgen-example "Базовый пример" "@http-path /user"
-	// @docgen-example "Пример с именованым параметром" "@http-path /user/{id}"
+	// @docgen-example "Пример с именованым параметром" "@http-path /user/:id"
 	Path    string           `option:"path"`
 	Openapi MethodOpenapiOpt `option:"openapi"`
 	// @docgen-title "Максимальный размер тела HTTP запроса"
@@ -66,6 +68,7 @@ type MethodOpt struct {
 	BodyResults   []*MethodResultOpt
 	HeaderResults []*MethodResultOpt
 	CookieResults []*MethodResultOpt
+	Trace         option.Trace // Источники значений полей для gomosaic explain
 }
 
 type SingleOpt struct {
@@ -146,6 +149,7 @@ type MethodParamOpt struct {
 	Name           string
 	PathParamIndex int
 	PathParamName  string
+	Trace          option.Trace // Источники значений полей для gomosaic explain
 }
 
 type MethodResultOpt struct {
@@ -155,6 +159,7 @@ type MethodResultOpt struct {
 	HTTPType string             `option:"type"`
 	Required bool               `option:"required"`
 	Flat     bool               `option:"flat"`
+	Trace    option.Trace       // Источники значений полей для gomosaic explain
 }
 
 type DefaultOpt struct {
@@ -179,9 +184,29 @@ type IfaceOpt struct {
 
 	NameTypeInfo *gomosaic.NameTypeInfo
 	Methods      []*MethodOpt
+	Trace        option.Trace // Источники значений полей для gomosaic explain
 }
 
-func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameTypeInfo) (interfaces []*IfaceOpt, errs error) {
+// ArtifactKey ключ модели HTTP API с префиксом аннотаций prefix в реестре артефактов
+func ArtifactKey(prefix string) string {
+	return gomosaic.ArtifactKey("http-annotation", prefix)
+}
+
+// LoadCached возвращает модель HTTP API, загружая ее один раз за запуск генерации.
+// Модель в реестре строится по собственной копии типов и не ссылается на копию первого плагина,
+// а каждый вызывающий получает глубокую копию модели, которую может изменять.
+// Имена параметров форматируются с аббревиатурами запуска из gomosaic.CaserFromContext.
+func LoadCached(ctx context.Context, module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameTypeInfo) ([]*IfaceOpt, error) {
+	interfaces, err := gomosaic.LoadArtifact(ctx, ArtifactKey(prefix), func() ([]*IfaceOpt, error) {
+		return Load(module, prefix, gomosaic.CloneNameTypesInfo(types), gomosaic.CaserFromContext(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CloneIfaceOpts(interfaces), nil
+}
+
+func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameTypeInfo, caser *strcase.Caser) (interfaces []*IfaceOpt, errs error) {
 	for _, nameTypeInfo := range types {
 		if nameTypeInfo.Type.Interface == nil {
 			continue
@@ -189,7 +214,8 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 
 		ifaceOpt := &IfaceOpt{NameTypeInfo: nameTypeInfo}
 
-		err := option.Unmarshal(prefix, nameTypeInfo.Annotations, ifaceOpt)
+		var err error
+		ifaceOpt.Trace, err = option.UnmarshalTrace(prefix, nameTypeInfo.Annotations, ifaceOpt)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
@@ -198,39 +224,44 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 		for _, m := range nameTypeInfo.Type.Interface.Methods {
 			methodOpt := &MethodOpt{Iface: ifaceOpt, Func: m}
 
-			err := option.Unmarshal(prefix, m.Annotations, methodOpt)
+			methodOpt.Trace, err = option.UnmarshalTrace(prefix, m.Annotations, methodOpt)
 			if err != nil {
 				errs = multierror.Append(errs, err)
 			}
 
 			if methodOpt.FormMaxMemory == 0 {
 				methodOpt.FormMaxMemory = defaultMemory
+				methodOpt.Trace.Set("FormMaxMemory", nil, i18n.T("http.explain.default", "32 MB"))
 			}
 
-			if methodOpt.Default.Accept == "" {
+			if methodOpt.Default.Accept == "" && ifaceOpt.Default.Accept != "" {
 				methodOpt.Default.Accept = ifaceOpt.Default.Accept
+				methodOpt.Trace.Set("Default.Accept", ifaceOpt.Trace.Annotation("Default.Accept"), i18n.T("http.explain.inherited"))
 			}
 
-			if methodOpt.Default.ContentType == "" {
+			if methodOpt.Default.ContentType == "" && ifaceOpt.Default.ContentType != "" {
 				methodOpt.Default.ContentType = ifaceOpt.Default.ContentType
+				methodOpt.Trace.Set("Default.ContentType", ifaceOpt.Trace.Annotation("Default.ContentType"), i18n.T("http.explain.inherited"))
 			}
 
 			if methodOpt.WrapReq.Path != "" {
 				methodOpt.WrapReq.PathParts = strings.Split(methodOpt.WrapReq.Path, ".")
+				methodOpt.Trace.Set("WrapReq.PathParts", methodOpt.Trace.Annotation("WrapReq.Path"), i18n.T("http.explain.path-parts"))
 			}
 
 			if methodOpt.WrapResp.Path != "" {
 				methodOpt.WrapResp.PathParts = strings.Split(methodOpt.WrapResp.Path, ".")
+				methodOpt.Trace.Set("WrapResp.PathParts", methodOpt.Trace.Annotation("WrapResp.Path"), i18n.T("http.explain.path-parts"))
 			}
 
 			if len(m.Params) == 0 || !m.Params[0].IsContext {
-				errs = multierror.Append(errs, gomosaic.Error("Не верная сигнатура метода, первым параметром обязателен тип context.Context", m.Pos))
+				errs = multierror.Append(errs, gomosaic.SignatureError(gomosaic.ErrMissingContext, m.Pos))
 				continue
 			}
 
 			for _, param := range m.Params {
 				methodParamOpt := &MethodParamOpt{Var: param}
-				err := option.Unmarshal(prefix, param.Annotations, methodParamOpt)
+				methodParamOpt.Trace, err = option.UnmarshalTrace(prefix, param.Annotations, methodParamOpt)
 				if err != nil {
 					errs = multierror.Append(errs, err)
 				}
@@ -241,9 +272,11 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 
 				if methodParamOpt.HTTPType == "" {
 					methodParamOpt.HTTPType = BodyHTTPType
+					methodParamOpt.Trace.Set("HTTPType", nil, i18n.T("http.explain.default", BodyHTTPType))
 				}
 
-				methodParamOpt.Name = formatName(methodParamOpt.NameOpt.Value, methodParamOpt.Var.Name, methodParamOpt.NameOpt.Format)
+				methodParamOpt.Name = formatName(caser, methodParamOpt.NameOpt.Value, methodParamOpt.Var.Name, methodParamOpt.NameOpt.Format)
+				traceName(methodParamOpt.Trace, methodParamOpt.NameOpt)
 
 				methodOpt.Params = append(methodOpt.Params, methodParamOpt)
 			}
@@ -253,11 +286,16 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 				if strings.HasPrefix(part, ":") {
 					pathParamName := part[1:]
 					for i, p := range methodOpt.Params {
-						if pathParamName == strcase.ToLowerCamel(p.Var.Name) {
+						if pathParamName == caser.ToLowerCamel(p.Var.Name) {
 							methodOpt.Params[i].HTTPType = PathHTTPType
 							methodOpt.Params[i].Required = true
 							methodOpt.Params[i].PathParamIndex = idx
 							methodOpt.Params[i].PathParamName = pathParamName
+
+							pathAnnotation, rule := methodOpt.Trace.Annotation("Path"), i18n.T("http.explain.path-param", part)
+							for _, field := range []string{"HTTPType", "Required", "PathParamIndex", "PathParamName"} {
+								methodOpt.Params[i].Trace.Set(field, pathAnnotation, rule)
+							}
 						}
 					}
 				}
@@ -282,13 +320,13 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 			}
 
 			if len(m.Results) == 0 || !m.Results[len(m.Results)-1].IsError {
-				errs = multierror.Append(errs, gomosaic.Error("Не верная сигнатура метода, последим параметром результата обязателен тип error", m.Pos))
+				errs = multierror.Append(errs, gomosaic.SignatureError(gomosaic.ErrMissingError, m.Pos))
 				continue
 			}
 
 			for _, result := range m.Results {
 				MethodResultOpt := &MethodResultOpt{Var: result}
-				err := option.Unmarshal(prefix, result.Annotations, MethodResultOpt)
+				MethodResultOpt.Trace, err = option.UnmarshalTrace(prefix, result.Annotations, MethodResultOpt)
 				if err != nil {
 					errs = multierror.Append(errs, err)
 				}
@@ -306,7 +344,8 @@ func Load(module *gomosaic.ModuleInfo, prefix string, types []*gomosaic.NameType
 					}
 				}
 
-				MethodResultOpt.Name = formatName(MethodResultOpt.NameOpt.Value, MethodResultOpt.Var.Name, MethodResultOpt.NameOpt.Format)
+				MethodResultOpt.Name = formatName(caser, MethodResultOpt.NameOpt.Value, MethodResultOpt.Var.Name, MethodResultOpt.NameOpt.Format)
+				traceName(MethodResultOpt.Trace, MethodResultOpt.NameOpt)
 
 				methodOpt.Results = append(methodOpt.Results, MethodResultOpt)
 			}
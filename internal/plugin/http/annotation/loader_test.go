@@ -0,0 +1,89 @@
+package annotation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+func testAnnotations(t *testing.T, values ...string) gomosaic.Annotations {
+	t.Helper()
+
+	comments := make([]*gomosaic.CommentInfo, 0, len(values))
+	for _, value := range values {
+		comments = append(comments, &gomosaic.CommentInfo{Value: value, IsAnnotation: true})
+	}
+	annotations, err := gomosaic.ParseAnnotations(comments)
+	if err != nil {
+		t.Fatalf("ParseAnnotations() error = %v", err)
+	}
+	return annotations
+}
+
+func testUserService(t *testing.T) []*gomosaic.NameTypeInfo {
+	t.Helper()
+
+	method := &gomosaic.MethodInfo{
+		Name: "GetUser",
+		Params: []*gomosaic.VarInfo{
+			{Name: "ctx", IsContext: true},
+			{Name: "id"},
+		},
+		Results: []*gomosaic.VarInfo{
+			{Name: "user"},
+			{Name: "err", IsError: true},
+		},
+		Annotations: testAnnotations(t, "@http-method GET", "@http-path /users/:id"),
+	}
+
+	return []*gomosaic.NameTypeInfo{{
+		Name: "UserService",
+		Type: &gomosaic.TypeInfo{Interface: &gomosaic.InterfaceInfo{Methods: []*gomosaic.MethodInfo{method}}},
+	}}
+}
+
+func TestLoadCachedReturnsIndependentCopies(t *testing.T) {
+	types := testUserService(t)
+	module := &gomosaic.ModuleInfo{}
+	ctx := gomosaic.ContextWithArtifacts(context.Background(), gomosaic.NewArtifacts())
+
+	// первый плагин меняет и свою копию типов, и полученную модель
+	first := gomosaic.CloneNameTypesInfo(types)
+	got, err := LoadCached(ctx, module, "http", first)
+	if err != nil {
+		t.Fatalf("LoadCached() error = %v", err)
+	}
+	first[0].Type.Interface.Methods[0].Name = "Changed"
+	first[0].Type.Interface.Methods[0].Params[1].Name = "changed"
+	got[0].Methods[0].Path = "/changed"
+	got[0].Methods[0].PathParams[0].Name = "changed"
+
+	got, err = LoadCached(ctx, module, "http", gomosaic.CloneNameTypesInfo(types))
+	if err != nil {
+		t.Fatalf("LoadCached() error = %v", err)
+	}
+
+	iface := got[0]
+	m := iface.Methods[0]
+	if m.Path != "/users/:id" || m.Func.Name != "GetUser" {
+		t.Errorf("LoadCached() метод = %s %s, want GetUser /users/:id", m.Func.Name, m.Path)
+	}
+	if len(m.PathParams) != 1 || m.PathParams[0].Name != "id" || m.PathParams[0].Var.Name != "id" {
+		t.Fatalf("LoadCached() параметры пути изменены другим плагином: %+v", m.PathParams)
+	}
+
+	// ссылки внутри копии указывают на элементы той же копии
+	if m.Iface != iface {
+		t.Error("MethodOpt.Iface указывает не на интерфейс копии")
+	}
+	if m.Func != iface.NameTypeInfo.Type.Interface.Methods[0] {
+		t.Error("MethodOpt.Func указывает не на метод копии типов")
+	}
+	if m.PathParams[0] != m.Params[1] || m.Params[1].Var != m.Func.Params[1] {
+		t.Error("PathParams не совпадают с Params копии")
+	}
+	if m.Context != m.Func.Params[0] || m.Error != m.Func.Results[1] {
+		t.Error("Context и Error указывают не на переменные копии типов")
+	}
+}
@@ -0,0 +1,34 @@
+package annotation
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+//go:embed loader.go
+var loaderSource []byte
+
+var loadDocs = sync.OnceValue(func() option.Docs {
+	docs, err := option.ParseDocs(loaderSource)
+	if err != nil {
+		panic(err)
+	}
+	return docs
+})
+
+// Schema возвращает описание аннотаций HTTP API с префиксом prefix
+// вместе с документацией из комментариев @docgen структур аннотаций
+func Schema(prefix string) *gomosaic.AnnotationSchema {
+	docs := loadDocs()
+
+	var annotations []*gomosaic.AnnotationDoc
+	annotations = append(annotations, option.DescribeAnnotations(prefix, gomosaic.TargetInterface, IfaceOpt{}, docs)...)
+	annotations = append(annotations, option.DescribeAnnotations(prefix, gomosaic.TargetMethod, MethodOpt{}, docs)...)
+	annotations = append(annotations, option.DescribeAnnotations(prefix, gomosaic.TargetParam, MethodParamOpt{}, docs)...)
+	annotations = append(annotations, option.DescribeAnnotations(prefix, gomosaic.TargetResult, MethodResultOpt{}, docs)...)
+
+	return &gomosaic.AnnotationSchema{Prefix: prefix, Annotations: annotations}
+}
@@ -0,0 +1,75 @@
+package annotation
+
+import (
+	"sort"
+
+	"github.com/go-mosaic/gomosaic/pkg/apisnapshot"
+)
+
+// Snapshot возвращает описание HTTP API интерфейсов для снимка API
+func Snapshot(interfaces []*IfaceOpt) *apisnapshot.API {
+	api := &apisnapshot.API{Name: "http", Routes: []*apisnapshot.Route{}}
+	for _, ifaceOpt := range interfaces {
+		for _, methodOpt := range ifaceOpt.Methods {
+			route := &apisnapshot.Route{
+				Method:       methodOpt.Method,
+				Path:         methodOpt.Path,
+				Operation:    ifaceOpt.NameTypeInfo.Name + "." + methodOpt.Func.Name,
+				RequestWrap:  methodOpt.WrapReq.Path,
+				ResponseWrap: methodOpt.WrapResp.Path,
+			}
+
+			// Параметры пути сравниваются по позиции в пути
+			pathParams := append([]*MethodParamOpt(nil), methodOpt.PathParams...)
+			sort.SliceStable(pathParams, func(i, j int) bool {
+				return pathParams[i].PathParamIndex < pathParams[j].PathParamIndex
+			})
+			for _, param := range pathParams {
+				route.Params = append(route.Params, snapshotParam(param.PathParamName, apisnapshot.InPath, true, param))
+			}
+			for _, group := range []struct {
+				in     string
+				params []*MethodParamOpt
+			}{
+				{apisnapshot.InQuery, methodOpt.QueryParams},
+				{apisnapshot.InHeader, methodOpt.HeaderParams},
+				{apisnapshot.InCookie, methodOpt.CookieParams},
+				{apisnapshot.InBody, methodOpt.BodyParams},
+			} {
+				for _, param := range group.params {
+					route.Params = append(route.Params, snapshotParam(param.Name, group.in, param.Required, param))
+				}
+			}
+
+			for _, group := range []struct {
+				in      string
+				results []*MethodResultOpt
+			}{
+				{apisnapshot.InHeader, methodOpt.HeaderResults},
+				{apisnapshot.InCookie, methodOpt.CookieResults},
+				{apisnapshot.InBody, methodOpt.BodyResults},
+			} {
+				for _, result := range group.results {
+					route.Results = append(route.Results, &apisnapshot.Param{
+						Name:     result.Name,
+						In:       group.in,
+						Required: result.Required,
+						Schema:   apisnapshot.SchemaOf(result.Var.Type),
+					})
+				}
+			}
+
+			api.Routes = append(api.Routes, route)
+		}
+	}
+	return api
+}
+
+func snapshotParam(name, in string, required bool, param *MethodParamOpt) *apisnapshot.Param {
+	return &apisnapshot.Param{
+		Name:     name,
+		In:       in,
+		Required: required,
+		Schema:   apisnapshot.SchemaOf(param.Var.Type),
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+
+	"github.com/go-mosaic/gomosaic/internal/plugin/http/annotation"
+	"github.com/go-mosaic/gomosaic/pkg/apisnapshot"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/lint"
+)
+
+// annotationPrefix префикс аннотаций HTTP плагинов
+const annotationPrefix = "http"
+
+// httpAnnotations общая для HTTP плагинов реализация описания, проверки и объяснения аннотаций @http-*
+// и описания HTTP API для снимка и проверки стиля
+type httpAnnotations struct{}
+
+func (httpAnnotations) AnnotationSchema() *gomosaic.AnnotationSchema {
+	return annotation.Schema(annotationPrefix)
+}
+
+func (httpAnnotations) Validate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) error {
+	_, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
+	return err
+}
+
+func (httpAnnotations) Explain(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo, iface, method string) ([]*gomosaic.Explanation, error) {
+	interfaces, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
+	if err != nil {
+		return nil, err
+	}
+	return annotation.Explain(interfaces, iface, method)
+}
+
+func (httpAnnotations) DescribeAPI(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (*apisnapshot.API, error) {
+	interfaces, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
+	if err != nil {
+		return nil, err
+	}
+	return annotation.Snapshot(interfaces), nil
+}
+
+func (httpAnnotations) LintRules() []*lint.Rule {
+	return annotation.LintRules()
+}
+
+func (httpAnnotations) Lint(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) ([]*lint.Issue, error) {
+	interfaces, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
+	if err != nil {
+		return nil, err
+	}
+	return annotation.Lint(interfaces, annotationPrefix), nil
+}
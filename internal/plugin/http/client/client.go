@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/dave/jennifer/jen"
@@ -22,18 +23,25 @@ const (
 
 type Qualifier interface {
 	Qual(pkgPath, name string) func(s *jen.Statement)
+	gomosaic.DeclSourceRecorder
 }
 
 type ClientGenerator struct {
 	qualifier    Qualifier
 	modulePath   string
+	trace        bool
+	caser        *strcase.Caser
 	rewriteTypes map[string]bool
 }
 
-func NewClientGenerator(qualifier Qualifier, modulePath string) *ClientGenerator {
+// NewClientGenerator создает генератор клиента, trace включает генерацию трассировки OpenTelemetry.
+// Имена в сгенерированном коде строятся через caser с аббревиатурами запуска.
+func NewClientGenerator(qualifier Qualifier, modulePath string, trace bool, caser *strcase.Caser) *ClientGenerator {
 	return &ClientGenerator{
 		qualifier:    qualifier,
 		modulePath:   modulePath,
+		trace:        trace,
+		caser:        caser,
 		rewriteTypes: map[string]bool{},
 	}
 }
@@ -49,7 +57,7 @@ func (g *ClientGenerator) qual(pkgPath, name string) func(s *jen.Statement) {
 	}
 }
 
-func (g *ClientGenerator) Generate(services []*annotation.IfaceOpt) (jen.Code, error) {
+func (g *ClientGenerator) Generate(ctx context.Context, services []*annotation.IfaceOpt) (jen.Code, error) {
 	group := jen.NewFile("")
 
 	group.Add(g.genTypes())
@@ -75,11 +83,19 @@ func (g *ClientGenerator) Generate(services []*annotation.IfaceOpt) (jen.Code, e
 
 		g.rewriteTypes = structGen.Processed()
 
-		for _, s := range clientServices {
-
+		clients, err := gomosaic.ParallelMap(ctx, clientServices, func(_ context.Context, s *annotation.IfaceOpt) (jen.Code, error) {
+			group := jen.NewFile("")
 			group.Add(g.genClientStruct(s))
 			group.Add(g.genClientConstruct(s))
 			group.Add(g.genClientEndpoints(s))
+			return group, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, code := range clients {
+			group.Add(code)
 		}
 	}
 
@@ -90,27 +106,27 @@ func (g *ClientGenerator) genReqBodyStruct(methodOpt *annotation.MethodOpt) jen.
 	group := jen.NewFile("").Null()
 
 	if len(methodOpt.BodyParams) == 1 && methodOpt.Single.Req {
-		fldName := strcase.ToLowerCamel(methodOpt.BodyParams[0].Var.Name)
+		fldName := g.caser.ToLowerCamel(methodOpt.BodyParams[0].Var.Name)
 		fldParam := jen.Id(recvName).Dot("params").Dot(fldName)
 
 		group.Return(fldParam)
 	} else {
 		if len(methodOpt.WrapReq.PathParts) > 0 {
-			group.Var().Id("body").Struct(annotation.WrapStruct(methodOpt.WrapReq.PathParts, annotation.MakeStructFieldsFromParams(methodOpt.BodyParams, g.qual))).Line()
+			group.Var().Id("body").Struct(annotation.WrapStruct(g.caser, methodOpt.WrapReq.PathParts, annotation.MakeStructFieldsFromParams(g.caser, methodOpt.BodyParams, g.qual))).Line()
 		} else {
-			group.Var().Id("body").Struct(annotation.MakeStructFieldsFromParams(methodOpt.BodyParams, g.qual)).Line()
+			group.Var().Id("body").Struct(annotation.MakeStructFieldsFromParams(g.caser, methodOpt.BodyParams, g.qual)).Line()
 		}
 
 		for _, param := range methodOpt.BodyParams {
 			if param.Var.IsContext {
 				continue
 			}
-			fldName := strcase.ToLowerCamel(param.Var.Name)
+			fldName := g.caser.ToLowerCamel(param.Var.Name)
 
 			fldAssign := jen.Do(func(s *jen.Statement) {
 				s.Id("body")
 				for _, name := range methodOpt.WrapReq.PathParts {
-					s.Dot(strcase.ToCamel(name))
+					s.Dot(g.caser.ToCamel(name))
 				}
 
 				fldParam := jen.Id(recvName).Dot("params").Dot(fldName)
@@ -118,7 +134,7 @@ func (g *ClientGenerator) genReqBodyStruct(methodOpt *annotation.MethodOpt) jen.
 					fldParam = jen.Op("*").Add(fldParam)
 				}
 
-				s.Dot(strcase.ToCamel(param.Var.Name)).Op("=").Add(fldParam)
+				s.Dot(g.caser.ToCamel(param.Var.Name)).Op("=").Add(fldParam)
 			}).Line()
 
 			if !param.Required {
@@ -160,7 +176,7 @@ func (g *ClientGenerator) genJSONReqContent(methodOpt *annotation.MethodOpt) jen
 func (g *ClientGenerator) genMakeBodyRequetsMethod(methodOpt *annotation.MethodOpt) jen.Code {
 	group := jen.NewFile("").Null()
 
-	methodRequestName := methodRequestName(methodOpt)
+	methodRequestName := g.methodRequestName(methodOpt)
 
 	group.Func().Params(jen.Id(recvName).Op("*").Id(methodRequestName)).Id("makeBodyRequest").Params().Any().Block(
 		g.genReqBodyStruct(methodOpt),
@@ -247,7 +263,7 @@ func (g *ClientGenerator) genQueryParams(methodOpt *annotation.MethodOpt) jen.Co
 	group.Id("q").Op(":=").Id("req").Dot("URL").Dot("Query").Call()
 
 	for _, p := range methodOpt.QueryParams {
-		fldName := strcase.ToLowerCamel(p.Name)
+		fldName := g.caser.ToLowerCamel(p.Name)
 
 		var blockCode jen.Code
 
@@ -327,7 +343,7 @@ func (g *ClientGenerator) genCookieParams(methodOpt *annotation.MethodOpt) jen.C
 func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.Code {
 	group := jen.NewFile("")
 
-	methodRequestName := methodRequestName(methodOpt)
+	methodRequestName := g.methodRequestName(methodOpt)
 
 	group.Func().Params(jen.Id(recvName).Op("*").Id(methodRequestName)).Id("Execute").
 		Params(
@@ -363,19 +379,19 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 			group.Id("r").Dot("opts").Dot("ctx").Op("=").Qual(annotation.CTXPkg, "WithValue").Call(
 				jen.Id("r").Dot("opts").Dot("ctx"),
 				jen.Id("methodContextKey"),
-				jen.Id(constFullName(methodOpt)),
+				jen.Id(g.constFullName(methodOpt)),
 			)
 
 			group.Id("r").Dot("opts").Dot("ctx").Op("=").Qual(annotation.CTXPkg, "WithValue").Call(
 				jen.Id("r").Dot("opts").Dot("ctx"),
 				jen.Id("shortMethodContextKey"),
-				jen.Id(constShortName(methodOpt)),
+				jen.Id(g.constShortName(methodOpt)),
 			)
 
 			group.Id("r").Dot("opts").Dot("ctx").Op("=").Qual(annotation.CTXPkg, "WithValue").Call(
 				jen.Id("r").Dot("opts").Dot("ctx"),
 				jen.Id("scopeNameContextKey"),
-				jen.Id(strcase.ToLowerCamel(methodOpt.Iface.NameTypeInfo.Name)+"ScopeName"),
+				jen.Id(g.caser.ToLowerCamel(methodOpt.Iface.NameTypeInfo.Name)+"ScopeName"),
 			)
 
 			group.List(jen.Id("req"), jen.Err()).Op(":=").Qual(annotation.HTTPPkg, "NewRequestWithContext").Call(
@@ -395,12 +411,14 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 
 			group.Id("req").Dot("Header").Dot("Set").Call(jen.Lit("Accept"), jen.Lit("application/json"))
 
-			group.If(jen.Id("r").Dot("opts").Dot("propagator").Op("!=").Nil()).Block(
-				jen.Id("r").Dot("opts").Dot("propagator").Dot("Inject").Call(
-					jen.Id("ctx"),
-					jen.Qual(annotation.OtelPropagationPkg, "HeaderCarrier").Call(jen.Id("req").Dot("Header")),
-				),
-			)
+			if g.trace {
+				group.If(jen.Id("r").Dot("opts").Dot("propagator").Op("!=").Nil()).Block(
+					jen.Id("r").Dot("opts").Dot("propagator").Dot("Inject").Call(
+						jen.Id("ctx"),
+						jen.Qual(annotation.OtelPropagationPkg, "HeaderCarrier").Call(jen.Id("req").Dot("Header")),
+					),
+				)
+			}
 
 			if len(methodOpt.BodyParams) > 0 {
 				group.Switch(jen.Id("r").Dot("opts").Dot("content")).BlockFunc(func(group *jen.Group) {
@@ -419,7 +437,7 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 									if p.Var.IsContext || !p.Var.Type.IsBasic {
 										continue
 									}
-									paramID := jen.Id("r").Dot("params").Dot(strcase.ToLowerCamel(p.Var.Name))
+									paramID := jen.Id("r").Dot("params").Dot(g.caser.ToLowerCamel(p.Var.Name))
 
 									valueID := jen.Add(paramID)
 									if !p.Required {
@@ -456,7 +474,7 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 										continue
 									}
 
-									paramID := jen.Id("r").Dot("params").Dot(strcase.ToLowerCamel(p.Var.Name))
+									paramID := jen.Id("r").Dot("params").Dot(g.caser.ToLowerCamel(p.Var.Name))
 									valueID := paramID.Clone()
 
 									if !p.Required {
@@ -633,9 +651,9 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 					group.Var().Id("respBody").Add(jenutils.TypeInfoQual(methodOpt.BodyResults[0].Var.Type, g.qual))
 				} else {
 					if len(methodOpt.WrapResp.PathParts) > 0 {
-						group.Var().Id("respBody").Struct(annotation.WrapStruct(methodOpt.WrapResp.PathParts, annotation.MakeStructFieldsFromResults(methodOpt.BodyResults, g.qual)))
+						group.Var().Id("respBody").Struct(annotation.WrapStruct(g.caser, methodOpt.WrapResp.PathParts, annotation.MakeStructFieldsFromResults(g.caser, methodOpt.BodyResults, g.qual)))
 					} else {
-						group.Var().Id("respBody").Struct(annotation.MakeStructFieldsFromResults(methodOpt.BodyResults, g.qual)).Line()
+						group.Var().Id("respBody").Struct(annotation.MakeStructFieldsFromResults(g.caser, methodOpt.BodyResults, g.qual)).Line()
 					}
 				}
 
@@ -667,22 +685,22 @@ func (g *ClientGenerator) genExecuteMethod(methodOpt *annotation.MethodOpt) jen.
 					),
 				)
 
-				group.ReturnFunc(func(g *jen.Group) {
+				group.ReturnFunc(func(group *jen.Group) {
 					if len(methodOpt.BodyResults) > 0 {
 						if len(methodOpt.BodyResults) == 1 && methodOpt.Single.Resp {
-							g.Id("respBody")
+							group.Id("respBody")
 						} else {
 							var ids []jen.Code
 
 							for _, name := range methodOpt.WrapResp.PathParts {
-								ids = append(ids, jen.Dot(strcase.ToCamel(name)))
+								ids = append(ids, jen.Dot(g.caser.ToCamel(name)))
 							}
 							for _, result := range methodOpt.BodyResults {
-								g.Id("respBody").Add(ids...).Dot(strcase.ToCamel(result.Name))
+								group.Id("respBody").Add(ids...).Dot(g.caser.ToCamel(result.Name))
 							}
 						}
 					}
-					g.Nil()
+					group.Nil()
 				})
 			} else {
 				group.Return(jen.Nil())
@@ -698,8 +716,8 @@ func (g *ClientGenerator) genParamSetters(params []*annotation.MethodParamOpt) j
 		if param.Required {
 			continue
 		}
-		methodSetName := strcase.ToCamel(param.Var.Name)
-		fldName := jen.Id(strcase.ToLowerCamel(param.Var.Name))
+		methodSetName := g.caser.ToCamel(param.Var.Name)
+		fldName := jen.Id(g.caser.ToLowerCamel(param.Var.Name))
 
 		group.Dot("Set" + methodSetName).Call(fldName)
 	}
@@ -712,13 +730,13 @@ func (g *ClientGenerator) genClientMethod(methodOpt *annotation.MethodOpt) jen.C
 
 	clientName := clientStructName(methodOpt.Iface)
 	methodMakeRequestName := methodMakeRequestName(methodOpt)
-	methodName := methodRequestName(methodOpt)
+	methodName := g.methodRequestName(methodOpt)
 
 	group.Func().Params(jen.Id("c").Op("*").Id(clientName)).Id(methodMakeRequestName).
 		ParamsFunc(func(group *jen.Group) {
 			for _, param := range methodOpt.Params {
 				if param.Required {
-					group.Id(strcase.ToLowerCamel(param.Var.Name)).Add(jenutils.TypeInfoQual(param.Var.Type, g.qual))
+					group.Id(g.caser.ToLowerCamel(param.Var.Name)).Add(jenutils.TypeInfoQual(param.Var.Type, g.qual))
 				}
 			}
 		}).
@@ -732,7 +750,7 @@ func (g *ClientGenerator) genClientMethod(methodOpt *annotation.MethodOpt) jen.C
 				continue
 			}
 			if param.Required {
-				group.Id("m").Dot("params").Dot(strcase.ToLowerCamel(param.Var.Name)).Op("=").Id(strcase.ToLowerCamel(param.Var.Name))
+				group.Id("m").Dot("params").Dot(g.caser.ToLowerCamel(param.Var.Name)).Op("=").Id(g.caser.ToLowerCamel(param.Var.Name))
 			}
 		}
 		group.Return(jen.Id("m"))
@@ -746,18 +764,18 @@ func (g *ClientGenerator) genClientMethod(methodOpt *annotation.MethodOpt) jen.C
 		}).
 		ParamsFunc(func(group *jen.Group) {
 			for _, result := range methodOpt.Results {
-				group.Id(strcase.ToLowerCamel(result.Var.Name)).Add(jenutils.TypeInfoQual(result.Var.Type, g.qual))
+				group.Id(g.caser.ToLowerCamel(result.Var.Name)).Add(jenutils.TypeInfoQual(result.Var.Type, g.qual))
 			}
 		}).
 		BlockFunc(func(group *jen.Group) {
 			group.ListFunc(func(group *jen.Group) {
 				for _, param := range methodOpt.Results {
-					group.Id(strcase.ToLowerCamel(param.Var.Name))
+					group.Id(g.caser.ToLowerCamel(param.Var.Name))
 				}
 			}).Op("=").Id("c").Dot(methodMakeRequestName).CallFunc(func(group *jen.Group) {
 				for _, param := range methodOpt.Params {
 					if param.Required {
-						group.Id(strcase.ToLowerCamel(param.Var.Name))
+						group.Id(g.caser.ToLowerCamel(param.Var.Name))
 					}
 				}
 			}).CustomFunc(jen.Options{}, func(group *jen.Group) {
@@ -783,10 +801,10 @@ func (g *ClientGenerator) genReqStructSetters(methodOpt *annotation.MethodOpt) j
 		if param.Var.IsContext {
 			continue
 		}
-		methodRequestName := methodRequestName(methodOpt)
+		methodRequestName := g.methodRequestName(methodOpt)
 
-		fldName := strcase.ToLowerCamel(param.Var.Name)
-		fnName := strcase.ToCamel(param.Var.Name)
+		fldName := g.caser.ToLowerCamel(param.Var.Name)
+		fnName := g.caser.ToCamel(param.Var.Name)
 
 		group.Func().Params(
 			jen.Id(recvName).Op("*").Id(methodRequestName),
@@ -807,7 +825,7 @@ func (g *ClientGenerator) genReqStructSetters(methodOpt *annotation.MethodOpt) j
 }
 
 func (g *ClientGenerator) genRequestStructParam(p *annotation.MethodParamOpt) jen.Code {
-	name := strcase.ToLowerCamel(p.Var.Name)
+	name := g.caser.ToLowerCamel(p.Var.Name)
 
 	paramNameID := jen.Id(name)
 	if !p.Required && !p.Var.Type.IsPtr {
@@ -820,7 +838,7 @@ func (g *ClientGenerator) genRequestStructParam(p *annotation.MethodParamOpt) je
 func (g *ClientGenerator) genReqStruct(methodOpt *annotation.MethodOpt) jen.Code {
 	group := jen.NewFile("")
 
-	methodRequestName := methodRequestName(methodOpt)
+	methodRequestName := g.methodRequestName(methodOpt)
 	clientName := clientStructName(methodOpt.Iface)
 
 	group.Type().Id(methodRequestName).StructFunc(func(group *jen.Group) {
@@ -842,6 +860,16 @@ func (g *ClientGenerator) genReqStruct(methodOpt *annotation.MethodOpt) jen.Code
 func (g *ClientGenerator) genClientEndpoint(methodOpt *annotation.MethodOpt) jen.Code {
 	group := jen.NewFile("")
 
+	// тип запроса отмечается вместе с его методами Set*, makeBodyRequest и Execute
+	clientName := clientStructName(methodOpt.Iface)
+	for _, decl := range []string{
+		g.methodRequestName(methodOpt),
+		clientName + "." + methodMakeRequestName(methodOpt),
+		clientName + "." + methodOpt.Func.Name,
+	} {
+		g.qualifier.SetDeclSource(decl, methodOpt.Iface.NameTypeInfo, methodOpt.Func)
+	}
+
 	group.Add(g.genClientMethod(methodOpt))
 
 	group.Add(g.genReqStruct(methodOpt))
@@ -871,6 +899,7 @@ func (g *ClientGenerator) genClientConstruct(ifaceOpt *annotation.IfaceOpt) jen.
 
 	group := jen.NewFile("")
 
+	g.qualifier.SetDeclSource("New"+ifaceOpt.NameTypeInfo.Name+"Client", ifaceOpt.NameTypeInfo, nil)
 	group.Func().Id("New"+ifaceOpt.NameTypeInfo.Name+"Client").
 		Params(
 			jen.Id("target").String(),
@@ -927,13 +956,18 @@ func (g *ClientGenerator) genClientStruct(ifaceOpt *annotation.IfaceOpt) jen.Cod
 	group := jen.NewFile("")
 
 	for _, m := range ifaceOpt.Methods {
-		group.Const().Id(constShortName(m)).Op("=").Lit(m.Func.ShortName)
-		group.Const().Id(constFullName(m)).Op("=").Lit(m.Func.FullName)
+		g.qualifier.SetDeclSource(g.constShortName(m), ifaceOpt.NameTypeInfo, m.Func)
+		g.qualifier.SetDeclSource(g.constFullName(m), ifaceOpt.NameTypeInfo, m.Func)
+		group.Const().Id(g.constShortName(m)).Op("=").Lit(m.Func.ShortName)
+		group.Const().Id(g.constFullName(m)).Op("=").Lit(m.Func.FullName)
 	}
 
 	clientName := clientStructName(ifaceOpt)
+	scopeName := g.caser.ToLowerCamel(ifaceOpt.NameTypeInfo.Name) + "ScopeName"
 
-	group.Const().Id(strcase.ToLowerCamel(ifaceOpt.NameTypeInfo.Name) + "ScopeName").Op("=").Lit(filepath.Base(ifaceOpt.NameTypeInfo.Package.Path))
+	g.qualifier.SetDeclSource(clientName, ifaceOpt.NameTypeInfo, nil)
+	g.qualifier.SetDeclSource(scopeName, ifaceOpt.NameTypeInfo, nil)
+	group.Const().Id(scopeName).Op("=").Lit(filepath.Base(ifaceOpt.NameTypeInfo.Package.Path))
 
 	group.Type().Id(clientName).StructFunc(func(g *jen.Group) {
 		g.Id("target").String()
@@ -1068,30 +1102,34 @@ func (g *ClientGenerator) genTypes() jen.Code {
 
 	group.Type().Id("ErrorDecoder").Func().Params(jen.Qual("io", "ReadCloser"), jen.Int()).Error()
 
-	group.Type().Id(clientOptionName).Struct(
-		jen.Id("ctx").Qual("context", "Context"),
-		jen.Id("content").String(),
-		jen.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer"),
-		jen.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator"),
-		jen.Id("before").Index().Id("ClientBeforeFunc"),
-		jen.Id("after").Index().Id("ClientAfterFunc"),
-		jen.Id("errorDecode").Id("ErrorDecoder"),
-		jen.Id("client").Op("*").Qual(annotation.HTTPPkg, "Client"),
-	)
+	group.Type().Id(clientOptionName).StructFunc(func(group *jen.Group) {
+		group.Id("ctx").Qual("context", "Context")
+		group.Id("content").String()
+		if g.trace {
+			group.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer")
+			group.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator")
+		}
+		group.Id("before").Index().Id("ClientBeforeFunc")
+		group.Id("after").Index().Id("ClientAfterFunc")
+		group.Id("errorDecode").Id("ErrorDecoder")
+		group.Id("client").Op("*").Qual(annotation.HTTPPkg, "Client")
+	})
 
 	group.Type().Id("ClientOption").Func().Params(jen.Op("*").Id(clientOptionName))
 
-	group.Func().Id("WithTracer").Params(jen.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer")).Id("ClientOption").Block(
-		jen.Return(jen.Func().Params(jen.Id("o").Op("*").Id(clientOptionName)).Block(
-			jen.Id("o").Dot("tracer").Op("=").Id("tracer"),
-		)),
-	)
+	if g.trace {
+		group.Func().Id("WithTracer").Params(jen.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer")).Id("ClientOption").Block(
+			jen.Return(jen.Func().Params(jen.Id("o").Op("*").Id(clientOptionName)).Block(
+				jen.Id("o").Dot("tracer").Op("=").Id("tracer"),
+			)),
+		)
 
-	group.Func().Id("WithPropagator").Params(jen.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator")).Id("ClientOption").Block(
-		jen.Return(jen.Func().Params(jen.Id("o").Op("*").Id(clientOptionName)).Block(
-			jen.Id("o").Dot("propagator").Op("=").Id("propagator"),
-		)),
-	)
+		group.Func().Id("WithPropagator").Params(jen.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator")).Id("ClientOption").Block(
+			jen.Return(jen.Func().Params(jen.Id("o").Op("*").Id(clientOptionName)).Block(
+				jen.Id("o").Dot("propagator").Op("=").Id("propagator"),
+			)),
+		)
+	}
 
 	group.Func().Id("WithContent").Params(jen.Id("content").String()).Id("ClientOption").Block(
 		jen.Return(jen.Func().Params(jen.Id("o").Op("*").Id(clientOptionName)).Block(
@@ -1232,12 +1270,18 @@ func (g *ClientGenerator) genTypes() jen.Code {
 
 func (g *ClientGenerator) genTrace(codes ...jen.Code) jen.Code {
 	group := jen.NewFile("").Null()
+	if !g.trace {
+		return group
+	}
 	tracerID := jen.Id("r").Dot("opts").Dot("tracer")
 	group.If(jen.Add(tracerID).Op("!=").Nil()).Block(codes...)
 	return group
 }
 
 func (g *ClientGenerator) genStartTrace(methodOpt *annotation.MethodOpt) jen.Code {
+	if !g.trace {
+		return jen.Null()
+	}
 	group := jen.NewFile("")
 
 	tracerID := jen.Id("r").Dot("opts").Dot("tracer")
@@ -1246,7 +1290,7 @@ func (g *ClientGenerator) genStartTrace(methodOpt *annotation.MethodOpt) jen.Cod
 
 	group.Add(
 		g.genTrace(
-			jen.List(jen.Id("ctx"), jen.Id("span")).Op("=").Add(tracerID).Dot("Start").Call(jen.Id("ctx"), jen.Id(constShortName(methodOpt)), jen.Qual(annotation.OtelTracePkg, "WithSpanKind").Call(jen.Qual(annotation.OtelTracePkg, "SpanKindServer"))),
+			jen.List(jen.Id("ctx"), jen.Id("span")).Op("=").Add(tracerID).Dot("Start").Call(jen.Id("ctx"), jen.Id(g.constShortName(methodOpt)), jen.Qual(annotation.OtelTracePkg, "WithSpanKind").Call(jen.Qual(annotation.OtelTracePkg, "SpanKindServer"))),
 			jen.Defer().Id("span").Dot("End").Call(),
 		),
 	)
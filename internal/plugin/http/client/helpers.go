@@ -7,31 +7,30 @@ import (
 
 	"github.com/go-mosaic/gomosaic/internal/plugin/http/annotation"
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
-	"github.com/go-mosaic/gomosaic/pkg/strcase"
 )
 
-func fullPrefixLowerCamel(methodOpt *annotation.MethodOpt) string {
-	return strcase.ToLowerCamel(methodOpt.Iface.NameTypeInfo.Name) + methodOpt.Func.Name
+func (g *ClientGenerator) fullPrefixLowerCamel(methodOpt *annotation.MethodOpt) string {
+	return g.caser.ToLowerCamel(methodOpt.Iface.NameTypeInfo.Name) + methodOpt.Func.Name
 }
 
-func fullPrefixCamel(methodOpt *annotation.MethodOpt) string {
-	return strcase.ToCamel(methodOpt.Iface.NameTypeInfo.Name) + methodOpt.Func.Name
+func (g *ClientGenerator) fullPrefixCamel(methodOpt *annotation.MethodOpt) string {
+	return g.caser.ToCamel(methodOpt.Iface.NameTypeInfo.Name) + methodOpt.Func.Name
 }
 
-func constShortName(methodOpt *annotation.MethodOpt) string {
-	return fullPrefixLowerCamel(methodOpt) + "ShortName"
+func (g *ClientGenerator) constShortName(methodOpt *annotation.MethodOpt) string {
+	return g.fullPrefixLowerCamel(methodOpt) + "ShortName"
 }
 
-func constFullName(methodOpt *annotation.MethodOpt) string {
-	return fullPrefixLowerCamel(methodOpt) + "FullName"
+func (g *ClientGenerator) constFullName(methodOpt *annotation.MethodOpt) string {
+	return g.fullPrefixLowerCamel(methodOpt) + "FullName"
 }
 
 func clientStructName(ifaceOpt *annotation.IfaceOpt) string {
 	return ifaceOpt.NameTypeInfo.Name + "Client"
 }
 
-func methodRequestName(methodOpt *annotation.MethodOpt) string {
-	return fullPrefixCamel(methodOpt) + "Request"
+func (g *ClientGenerator) methodRequestName(methodOpt *annotation.MethodOpt) string {
+	return g.fullPrefixCamel(methodOpt) + "Request"
 }
 
 func methodMakeRequestName(methodOpt *annotation.MethodOpt) string {
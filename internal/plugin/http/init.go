@@ -1,10 +1,15 @@
 package http
 
-import "github.com/go-mosaic/gomosaic/pkg/gomosaic"
+import (
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/migrate"
+)
 
 func init() {
 	gomosaic.RegisterPlugin(new(PluginServerChi))
 	gomosaic.RegisterPlugin(new(PluginServerEcho))
 	gomosaic.RegisterPlugin(new(PluginClient))
 	gomosaic.RegisterPlugin(new(PluginClientTesting))
+
+	migrate.Register(pathParamsRule)
 }
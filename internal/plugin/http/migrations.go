@@ -0,0 +1,20 @@
+package http
+
+import (
+	"regexp"
+
+	"github.com/go-mosaic/gomosaic/pkg/migrate"
+)
+
+// pathParamBraces параметр пути в виде {name}
+var pathParamBraces = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// pathParamsRule заменяет параметры пути {name} на :name, которые распознает загрузчик HTTP аннотаций
+var pathParamsRule = migrate.RewriteValue(
+	"http-path-params",
+	"http.migrate.path-params",
+	annotationPrefix+"-path",
+	func(value string) string {
+		return pathParamBraces.ReplaceAllString(value, ":$1")
+	},
+)
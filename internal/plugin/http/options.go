@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+// PluginOpt параметры HTTP плагинов, передаваемые через --opt plugin.key=value
+type PluginOpt struct {
+	Filename string `option:"filename" usage:"plugin.opt.filename"`
+	Trace    bool   `option:"trace" default:"true" usage:"http.opt.trace"`
+}
+
+// TestPluginOpt параметры плагина тестов HTTP клиента, который не генерирует трассировку
+type TestPluginOpt struct {
+	Filename string `option:"filename" usage:"plugin.opt.filename"`
+}
+
+// loadPluginOpt декодирует параметры плагина из контекста поверх значений по умолчанию
+func loadPluginOpt(ctx context.Context, filename string) (*PluginOpt, error) {
+	opts := &PluginOpt{Filename: filename}
+	if err := option.UnmarshalPluginOptions(gomosaic.PluginOptionsFromContext(ctx), opts); err != nil {
+		return nil, err
+	}
+	if err := checkFilename(ctx, opts.Filename); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// loadTestPluginOpt декодирует параметры плагина тестов HTTP клиента
+func loadTestPluginOpt(ctx context.Context, filename string) (*TestPluginOpt, error) {
+	opts := &TestPluginOpt{Filename: filename}
+	if err := option.UnmarshalPluginOptions(gomosaic.PluginOptionsFromContext(ctx), opts); err != nil {
+		return nil, err
+	}
+	if err := checkFilename(ctx, opts.Filename); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+func checkFilename(ctx context.Context, filename string) error {
+	if !strings.HasSuffix(filename, ".go") {
+		return i18n.Errorf("plugin.filename-ext", gomosaic.PluginOptionsFromContext(ctx).Plugin, filename)
+	}
+	return nil
+}
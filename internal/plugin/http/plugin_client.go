@@ -11,27 +11,38 @@ import (
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
 )
 
-type PluginClient struct{}
+const clientFilename = "client_gen.go"
+
+type PluginClient struct {
+	httpAnnotations
+}
 
 func (p *PluginClient) Name() string { return "http-client" }
 
+func (p *PluginClient) Options() any { return &PluginOpt{Filename: clientFilename} }
+
 func (p *PluginClient) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
 	outputDir := gomosaic.OutputDirFromContext(ctx)
 
-	a, err := annotation.Load(module, "http", types)
+	opts, err := loadPluginOpt(ctx, clientFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
 	if err != nil {
 		return nil, err
 	}
 
 	f := gomosaic.NewGoFile(module, outputDir)
 
-	clientGen := client.NewClientGenerator(f, module.Path)
-	code, err := clientGen.Generate(a)
+	clientGen := client.NewClientGenerator(f, module.Path, opts.Trace, gomosaic.CaserFromContext(ctx))
+	code, err := clientGen.Generate(ctx, a)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	} else {
 		f.Add(code)
 	}
 
-	return map[string]gomosaic.File{"client_gen.go": f}, errs
+	return map[string]gomosaic.File{opts.Filename: f}, errs
 }
@@ -11,14 +11,25 @@ import (
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
 )
 
-type PluginClientTesting struct{}
+const clientTestFilename = "client_gen_test.go"
+
+type PluginClientTesting struct {
+	httpAnnotations
+}
 
 func (p *PluginClientTesting) Name() string { return "http-client-test" }
 
+func (p *PluginClientTesting) Options() any { return &TestPluginOpt{Filename: clientTestFilename} }
+
 func (p *PluginClientTesting) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
 	outputDir := gomosaic.OutputDirFromContext(ctx)
 
-	annotations, err := annotation.Load(module, "http", types)
+	opts, err := loadTestPluginOpt(ctx, clientTestFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
 	if err != nil {
 		return nil, err
 	}
@@ -27,11 +38,11 @@ func (p *PluginClientTesting) Generate(ctx context.Context, module *gomosaic.Mod
 
 	fake := faker.New()
 
-	clientTestGen := testclient.NewClientTest(fake, f.Qual)
+	clientTestGen := testclient.NewClientTest(fake, f.Qual, f, gomosaic.CaserFromContext(ctx))
 	f.Add(clientTestGen.Generate(annotations, []testclient.Config{
 		{StatusCode: 200},                   //nolint: mnd
 		{StatusCode: 400, CheckError: true}, //nolint: mnd
 	}))
 
-	return map[string]gomosaic.File{"client_gen_test.go": f}, nil
+	return map[string]gomosaic.File{opts.Filename: f}, nil
 }
@@ -11,27 +11,45 @@ import (
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
 )
 
-type PluginServerChi struct{}
+const serverChiFilename = "server_chi_gen.go"
+
+// ArtifactRoutes имя артефакта HTTP серверов с таблицей маршрутов ([]*annotation.IfaceOpt).
+// Плагин, зависящий от сервера, получает ее через
+// gomosaic.LookupArtifact[[]*annotation.IfaceOpt](ctx, gomosaic.ArtifactKey("http-server-chi", ArtifactRoutes)).
+const ArtifactRoutes = "routes"
+
+type PluginServerChi struct {
+	httpAnnotations
+}
 
 func (p *PluginServerChi) Name() string { return "http-server-chi" }
 
+func (p *PluginServerChi) Options() any { return &PluginOpt{Filename: serverChiFilename} }
+
 func (p *PluginServerChi) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
 	outputDir := gomosaic.OutputDirFromContext(ctx)
 
-	annotations, err := annotation.Load(module, "http", types)
+	opts, err := loadPluginOpt(ctx, serverChiFilename)
 	if err != nil {
 		return nil, err
 	}
 
+	annotations, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
+	if err != nil {
+		return nil, err
+	}
+
+	gomosaic.PublishArtifact(ctx, gomosaic.ArtifactKey(p.Name(), ArtifactRoutes), annotations)
+
 	f := gomosaic.NewGoFile(module, outputDir)
 
-	serverGen := server.NewServer(new(server.StrategyChi), module, f)
-	code, err := serverGen.Generate(annotations)
+	serverGen := server.NewServer(new(server.StrategyChi), module, f, opts.Trace, gomosaic.CaserFromContext(ctx))
+	code, err := serverGen.Generate(ctx, annotations)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	} else {
 		f.Add(code)
 	}
 
-	return map[string]gomosaic.File{"server_chi_gen.go": f}, errs
+	return map[string]gomosaic.File{opts.Filename: f}, errs
 }
@@ -11,27 +11,40 @@ import (
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
 )
 
-type PluginServerEcho struct{}
+const serverEchoFilename = "server_echo_gen.go"
+
+type PluginServerEcho struct {
+	httpAnnotations
+}
 
 func (p *PluginServerEcho) Name() string { return "http-server-echo" }
 
+func (p *PluginServerEcho) Options() any { return &PluginOpt{Filename: serverEchoFilename} }
+
 func (p *PluginServerEcho) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
 	outputDir := gomosaic.OutputDirFromContext(ctx)
 
-	annotations, err := annotation.Load(module, "http", types)
+	opts, err := loadPluginOpt(ctx, serverEchoFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := annotation.LoadCached(ctx, module, annotationPrefix, types)
 	if err != nil {
 		return nil, err
 	}
 
+	gomosaic.PublishArtifact(ctx, gomosaic.ArtifactKey(p.Name(), ArtifactRoutes), annotations)
+
 	f := gomosaic.NewGoFile(module, outputDir)
 
-	serverGen := server.NewServer(new(server.StrategyEcho), module, f)
-	code, err := serverGen.Generate(annotations)
+	serverGen := server.NewServer(new(server.StrategyEcho), module, f, opts.Trace, gomosaic.CaserFromContext(ctx))
+	code, err := serverGen.Generate(ctx, annotations)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	} else {
 		f.Add(code)
 	}
 
-	return map[string]gomosaic.File{"server_echo_gen.go": f}, errs
+	return map[string]gomosaic.File{opts.Filename: f}, errs
 }
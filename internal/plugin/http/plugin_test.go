@@ -0,0 +1,51 @@
+package http
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaictest"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+func TestPlugins(t *testing.T) {
+	tests := []struct {
+		name   string
+		plugin gomosaic.Generator
+	}{
+		{name: "сервер chi", plugin: new(PluginServerChi)},
+		{name: "сервер echo", plugin: new(PluginServerEcho)},
+		{name: "клиент", plugin: new(PluginClient)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gomosaictest.Run(t, gomosaictest.TestData(), tt.plugin, "basic/...", "multi/...", "badsig/...")
+		})
+	}
+}
+
+func TestPluginsWithoutTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		plugin   gomosaic.Generator
+		filename string
+	}{
+		{name: "сервер echo", plugin: new(PluginServerEcho), filename: "server_echo_notrace_gen.go"},
+		{name: "клиент", plugin: new(PluginClient), filename: "client_notrace_gen.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := map[string]string{"trace": "false", "filename": tt.filename}
+			gomosaictest.RunWithOptions(t, gomosaictest.TestData(), tt.plugin, options, "basic/...")
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
@@ -14,12 +15,15 @@ import (
 
 type Qualifier interface {
 	Qual(pkgPath, name string) func(s *jen.Statement)
+	gomosaic.DeclSourceRecorder
 }
 
 type ServerGenerator struct {
 	module    *gomosaic.ModuleInfo
 	strategy  Strategy
 	qualifier Qualifier
+	trace     bool
+	caser     *strcase.Caser
 }
 
 func (g *ServerGenerator) genServiceOptions(services []*annotation.IfaceOpt) jen.Code {
@@ -29,14 +33,17 @@ func (g *ServerGenerator) genServiceOptions(services []*annotation.IfaceOpt) jen
 		middlewareType := jen.Qual(gomosaic.TransportPkg, "Middleware")
 		optionsName := s.NameTypeInfo.Name + "Options"
 
-		group.Add(g.genTypeOptions(optionsName, middlewareType, s.Methods))
+		group.Add(g.genTypeOptions(s, optionsName, middlewareType))
 	}
 
 	return group
 }
 
-func (g *ServerGenerator) genTypeOptions(optionsName string, middlewareType jen.Code, methods []*annotation.MethodOpt) jen.Code {
+func (g *ServerGenerator) genTypeOptions(s *annotation.IfaceOpt, optionsName string, middlewareType jen.Code) jen.Code {
 	group := jen.NewFile("")
+	methods := s.Methods
+
+	g.qualifier.SetDeclSource(optionsName, s.NameTypeInfo, nil)
 
 	transportOptions := jen.Do(g.qualifier.Qual(gomosaic.TransportPkg, "TransportOption"))
 
@@ -46,6 +53,10 @@ func (g *ServerGenerator) genTypeOptions(optionsName string, middlewareType jen.
 		for _, m := range methods {
 			group.Id("middleware" + m.Func.Name).Index().Add(middlewareType)
 		}
+		if g.trace {
+			group.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer")
+			group.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator")
+		}
 	})
 
 	group.Func().Params(jen.Id("o").Op("*").Id(optionsName)).Id("TransportOptions").Params(jen.Id("opts").Op("...").Add(transportOptions)).Op("*").Id(optionsName).Block(
@@ -53,12 +64,25 @@ func (g *ServerGenerator) genTypeOptions(optionsName string, middlewareType jen.
 		jen.Return(jen.Id("o")),
 	).Line()
 
+	if g.trace {
+		group.Func().Params(jen.Id("o").Op("*").Id(optionsName)).Id("Tracer").Params(jen.Id("tracer").Qual(annotation.OtelTracePkg, "Tracer")).Op("*").Id(optionsName).Block(
+			jen.Id("o").Dot("tracer").Op("=").Id("tracer"),
+			jen.Return(jen.Id("o")),
+		).Line()
+
+		group.Func().Params(jen.Id("o").Op("*").Id(optionsName)).Id("Propagator").Params(jen.Id("propagator").Qual(annotation.OtelPropagationPkg, "TextMapPropagator")).Op("*").Id(optionsName).Block(
+			jen.Id("o").Dot("propagator").Op("=").Id("propagator"),
+			jen.Return(jen.Id("o")),
+		).Line()
+	}
+
 	group.Func().Params(jen.Id("o").Op("*").Id(optionsName)).Id("Middleware").Params(jen.Id("middleware").Op("...").Add(middlewareType)).Op("*").Id(optionsName).Block(
 		jen.Id("o").Dot("middleware").Op("=").Append(jen.Id("o").Dot("middleware"), jen.Id("middleware").Op("...")),
 		jen.Return(jen.Id("o")),
 	).Line()
 
 	for _, m := range methods {
+		g.qualifier.SetDeclSource(optionsName+".Middleware"+m.Func.Name, s.NameTypeInfo, m.Func)
 		group.Func().Params(jen.Id("o").Op("*").Id(optionsName)).Id("Middleware"+m.Func.Name).Params(jen.Id("middleware").Op("...").Add(middlewareType)).Op("*").Id(optionsName).Block(
 			jen.Id("o").Dot("middleware"+m.Func.Name).Op("=").Append(jen.Id("o").Dot("middleware"+m.Func.Name), jen.Id("middleware").Op("...")),
 			jen.Return(jen.Id("o")),
@@ -85,7 +109,7 @@ func (g *ServerGenerator) genQueryParamsFunc(
 
 	for _, p := range params {
 		if p.Var.Type.IsNamed && p.Var.Type.ElemType.Struct != nil {
-			name := "param" + strcase.ToCamel(p.Name)
+			name := "param" + g.caser.ToCamel(p.Name)
 
 			group.Var().Id(name).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
 
@@ -129,7 +153,7 @@ func (g *ServerGenerator) genQueryParamsFunc(
 				}
 			}
 		} else {
-			name := "param" + strcase.ToCamel(p.Name)
+			name := "param" + g.caser.ToCamel(p.Name)
 
 			group.Var().Id(name).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
 
@@ -168,7 +192,7 @@ func (g *ServerGenerator) genNonBodyParamsFunc(
 	for _, p := range params {
 
 		if p.Var.Type.IsNamed && p.Var.Type.ElemType.Struct != nil {
-			name := "param" + strcase.ToCamel(p.Name)
+			name := "param" + g.caser.ToCamel(p.Name)
 
 			group.Var().Id(name).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
 
@@ -181,7 +205,7 @@ func (g *ServerGenerator) genNonBodyParamsFunc(
 				if err == nil {
 					transformCodes = append(transformCodes, typetransform.For(f.Type).
 						SetAssignID(jen.Id(name).Dot(f.Name)).
-						SetValueID(valueFn(strcase.ToLowerCamel(tag.Name))).
+						SetValueID(valueFn(g.caser.ToLowerCamel(tag.Name))).
 						SetErrStatements(
 							jen.Return(jen.Err()),
 						).Parse(),
@@ -189,11 +213,11 @@ func (g *ServerGenerator) genNonBodyParamsFunc(
 				}
 			}
 		} else {
-			name := "param" + strcase.ToCamel(p.Name)
+			name := "param" + g.caser.ToCamel(p.Name)
 
 			group.Var().Id(name).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
 
-			valueName := strcase.ToLowerCamel(p.Name)
+			valueName := g.caser.ToLowerCamel(p.Name)
 			if p.NameOpt.Value != "" {
 				valueName = p.NameOpt.Value
 			}
@@ -222,7 +246,7 @@ func (g *ServerGenerator) genHandlerDecodeBodyParams(
 	group := jen.NewFile("")
 
 	for _, p := range params {
-		group.Var().Id(strcase.ToLowerCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
+		group.Var().Id(g.caser.ToLowerCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualifier.Qual))
 	}
 
 	httpMethod := strings.ToUpper(opt.Method)
@@ -248,10 +272,10 @@ func (g *ServerGenerator) genHandlerDecodeBodyParams(
 				if len(params) == 1 && opt.Single.Req {
 					group.Var().Id(reqName).Add(jenutils.TypeInfoQual(params[0].Var.Type, g.qualifier.Qual))
 				} else {
-					structFields := annotation.MakeStructFieldsFromParams(params, g.qualifier.Qual)
+					structFields := annotation.MakeStructFieldsFromParams(g.caser, params, g.qualifier.Qual)
 
 					if len(opt.WrapReq.PathParts) > 0 {
-						structFields = annotation.WrapStruct(opt.WrapReq.PathParts, structFields)
+						structFields = annotation.WrapStruct(g.caser, opt.WrapReq.PathParts, structFields)
 					}
 
 					group.Var().Id(reqName).Struct(structFields)
@@ -262,10 +286,10 @@ func (g *ServerGenerator) genHandlerDecodeBodyParams(
 				)
 
 				if len(params) == 1 && opt.Single.Req {
-					group.Id(strcase.ToLowerCamel(params[0].Var.Name)).Op("=").Id(reqName)
+					group.Id(g.caser.ToLowerCamel(params[0].Var.Name)).Op("=").Id(reqName)
 				} else {
 					for _, p := range params {
-						group.Id(strcase.ToLowerCamel(p.Var.Name)).Op("=").Id(reqName).Add(annotation.Dot(append(opt.WrapReq.PathParts, strcase.ToCamel(p.Var.Name))...))
+						group.Id(g.caser.ToLowerCamel(p.Var.Name)).Op("=").Id(reqName).Add(annotation.Dot(g.caser, append(opt.WrapReq.PathParts, g.caser.ToCamel(p.Var.Name))...))
 					}
 				}
 			})
@@ -278,7 +302,7 @@ func (g *ServerGenerator) genHandlerDecodeBodyParams(
 					)
 
 					for _, p := range params {
-						stFldName := strcase.ToLowerCamel(p.Var.Name)
+						stFldName := g.caser.ToLowerCamel(p.Var.Name)
 						valueID := jen.Id("form").Dot("Get").Call(jen.Lit(p.Name))
 
 						assignID := jen.Id(stFldName)
@@ -303,7 +327,7 @@ func (g *ServerGenerator) genHandlerDecodeBodyParams(
 					)
 
 					for _, p := range params {
-						stFldName := strcase.ToLowerCamel(p.Var.Name)
+						stFldName := g.caser.ToLowerCamel(p.Var.Name)
 						valueID := jen.Id("form").Dot("FormValue").Call(jen.Lit(p.Name))
 						code := typetransform.For(p.Var.Type).
 							SetAssignID(jen.Id(stFldName)).
@@ -328,7 +352,7 @@ func (g *ServerGenerator) genCallServiceMethod(m *annotation.MethodOpt) jen.Code
 	svcCall := jen.Do(func(s *jen.Statement) {
 		s.ListFunc(func(group *jen.Group) {
 			for _, r := range m.Results {
-				group.Id(strcase.ToLowerCamel(r.Var.Name))
+				group.Id(g.caser.ToLowerCamel(r.Var.Name))
 			}
 		})
 		if len(m.Results) > 0 {
@@ -337,25 +361,61 @@ func (g *ServerGenerator) genCallServiceMethod(m *annotation.MethodOpt) jen.Code
 			s.Op("=")
 		}
 	}).Id("svc").Dot(m.Func.Name).CallFunc(func(group *jen.Group) {
-		group.Id("req").Dot("Context").Call()
+		if g.trace {
+			group.Id("ctx")
+		} else {
+			group.Id("req").Dot("Context").Call()
+		}
 		for _, p := range m.Params {
 			if p.Var.IsContext {
 				continue
 			}
 			switch p.HTTPType {
 			default:
-				group.Id(strcase.ToLowerCamel(p.Var.Name))
+				group.Id(g.caser.ToLowerCamel(p.Var.Name))
 			case annotation.PathHTTPType, annotation.CookieHTTPType, annotation.QueryHTTPType:
-				group.Id("param" + strcase.ToCamel(p.Var.Name))
+				group.Id("param" + g.caser.ToCamel(p.Var.Name))
 			}
 		}
 	})
 
 	group.Add(svcCall)
 
-	group.If(jen.Err().Op("!=").Nil()).Block(
+	group.If(jen.Err().Op("!=").Nil()).BlockFunc(func(group *jen.Group) {
 		// g.genErrorEncoderCall(m),
-		jen.Return(jen.Err()),
+		if g.trace {
+			group.If(jen.Id("span").Op("!=").Nil()).Block(
+				jen.Id("span").Dot("RecordError").Call(jen.Err()),
+				jen.Id("span").Dot("SetStatus").Call(jen.Qual(annotation.OtelCodesPkg, "Error"), jen.Err().Dot("Error").Call()),
+			)
+		}
+		group.Return(jen.Err())
+	})
+
+	return group
+}
+
+// genStartTrace извлекает контекст трассировки из заголовков запроса и открывает span обработчика
+func (g *ServerGenerator) genStartTrace(m *annotation.MethodOpt) jen.Code {
+	group := jen.NewFile("")
+
+	group.Id("ctx").Op(":=").Id("req").Dot("Context").Call()
+	group.If(jen.Id("opt").Dot("propagator").Op("!=").Nil()).Block(
+		jen.Id("carrier").Op(":=").Qual(annotation.OtelPropagationPkg, "MapCarrier").Values(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("key")).Op(":=").Range().Id("opt").Dot("propagator").Dot("Fields").Call()).Block(
+			jen.Id("carrier").Dot("Set").Call(jen.Id("key"), jen.Id("req").Dot("Header").Call(jen.Id("key"))),
+		),
+		jen.Id("ctx").Op("=").Id("opt").Dot("propagator").Dot("Extract").Call(jen.Id("ctx"), jen.Id("carrier")),
+	)
+
+	group.Var().Id("span").Qual(annotation.OtelTracePkg, "Span")
+	group.If(jen.Id("opt").Dot("tracer").Op("!=").Nil()).Block(
+		jen.List(jen.Id("ctx"), jen.Id("span")).Op("=").Id("opt").Dot("tracer").Dot("Start").Call(
+			jen.Id("ctx"),
+			jen.Lit(m.Func.ShortName),
+			jen.Qual(annotation.OtelTracePkg, "WithSpanKind").Call(jen.Qual(annotation.OtelTracePkg, "SpanKindServer")),
+		),
+		jen.Defer().Id("span").Dot("End").Call(),
 	)
 
 	return group
@@ -364,6 +424,7 @@ func (g *ServerGenerator) genCallServiceMethod(m *annotation.MethodOpt) jen.Code
 func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 	group := jen.NewFile("")
 
+	g.qualifier.SetDeclSource(s.NameTypeInfo.Name+"RegisterHandlers", s.NameTypeInfo, nil)
 	group.Func().Id(s.NameTypeInfo.Name+"RegisterHandlers").Params(
 		jen.Id("router").Do(func(s *jen.Statement) {
 			if g.strategy.UsePtrType() {
@@ -376,6 +437,8 @@ func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 		jen.Id("opt").Op("*").Id(s.NameTypeInfo.Name+"Options"),
 	).BlockFunc(func(group *jen.Group) {
 		group.Add(g.genOptionLoader(s.NameTypeInfo.Name))
+		// пользовательская настройка опций сервера, например дополнительные middleware
+		group.Add(gomosaic.KeepRegion(s.NameTypeInfo.Name + ".options"))
 
 		group.Id("transportFactory").Op(":=").Qual(gomosaic.TransportFactoryPkg, "NewFactory").Call(
 			jen.Id("opt").Dot("transportOptions").Op("..."),
@@ -397,9 +460,13 @@ func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 				jen.Lit(m.Method),
 				jen.Lit(strings.Join(pathParts, "/")),
 				jen.Func().Params(
-					jen.Id("req").Id("transport").Dot("Request"),
-					jen.Id("resp").Id("transport").Dot("Response"),
+					jen.Id("req").Do(g.qualifier.Qual(gomosaic.TransportPkg, "Request")),
+					jen.Id("resp").Do(g.qualifier.Qual(gomosaic.TransportPkg, "Response")),
 				).Error().BlockFunc(func(group *jen.Group) {
+					if g.trace {
+						group.Add(g.genStartTrace(m))
+					}
+
 					if len(m.Params) > 0 {
 						if len(m.BodyParams) > 0 {
 							group.Add(g.genHandlerDecodeBodyParams(m, m.BodyParams))
@@ -431,10 +498,10 @@ func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 					if len(m.BodyResults) == 1 && m.Single.Resp {
 						respName = m.BodyResults[0].Var.Name
 					} else {
-						structFields := annotation.MakeStructFieldsFromResults(m.BodyResults, g.qualifier.Qual)
+						structFields := annotation.MakeStructFieldsFromResults(g.caser, m.BodyResults, g.qualifier.Qual)
 
 						if len(m.WrapResp.PathParts) > 0 {
-							structFields = annotation.WrapStruct(m.WrapResp.PathParts, structFields)
+							structFields = annotation.WrapStruct(g.caser, m.WrapResp.PathParts, structFields)
 						}
 
 						group.Var().Id(respName).Struct(structFields)
@@ -442,9 +509,9 @@ func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 						for _, result := range m.BodyResults {
 							group.Id(respName).Do(func(s *jen.Statement) {
 								for _, name := range m.WrapResp.PathParts {
-									s.Dot(strcase.ToCamel(name))
+									s.Dot(g.caser.ToCamel(name))
 								}
-							}).Dot(strcase.ToCamel(result.Var.Name)).Op("=").Id(result.Var.Name)
+							}).Dot(g.caser.ToCamel(result.Var.Name)).Op("=").Id(result.Var.Name)
 						}
 					}
 
@@ -463,24 +530,40 @@ func (g *ServerGenerator) genRegisterHandlers(s *annotation.IfaceOpt) jen.Code {
 	return group
 }
 
-func (g *ServerGenerator) Generate(services []*annotation.IfaceOpt) (jen.Code, error) {
+func (g *ServerGenerator) Generate(ctx context.Context, services []*annotation.IfaceOpt) (jen.Code, error) {
 	group := jen.NewFile("")
+	// пользовательские импорты для кода защищенных областей
+	group.Add(gomosaic.KeepRegion("imports"))
 	group.Add(g.genServiceOptions(services))
-	for _, s := range services {
-		group.Add(g.genRegisterHandlers(s))
+
+	handlers, err := gomosaic.ParallelMap(ctx, services, func(_ context.Context, s *annotation.IfaceOpt) (jen.Code, error) {
+		return g.genRegisterHandlers(s), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range handlers {
+		group.Add(code)
 	}
 
 	return group, nil
 }
 
+// NewServer создает генератор сервера, trace включает генерацию трассировки OpenTelemetry.
+// Имена в сгенерированном коде строятся через caser с аббревиатурами запуска.
 func NewServer(
 	strategy Strategy,
 	module *gomosaic.ModuleInfo,
 	qualifier Qualifier,
+	trace bool,
+	caser *strcase.Caser,
 ) *ServerGenerator {
 	return &ServerGenerator{
 		strategy:  strategy,
 		module:    module,
 		qualifier: qualifier,
+		trace:     trace,
+		caser:     caser,
 	}
 }
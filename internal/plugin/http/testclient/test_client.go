@@ -21,8 +21,10 @@ type Config struct {
 }
 
 type ClientTestGenerator struct {
-	fake   faker.Faker
-	qualFn jenutils.QualFunc
+	fake    faker.Faker
+	qualFn  jenutils.QualFunc
+	sources gomosaic.DeclSourceRecorder
+	caser   *strcase.Caser
 }
 
 func (g *ClientTestGenerator) basicTypeToValue(typeInfo *gomosaic.TypeInfo) jen.Code {
@@ -128,7 +130,7 @@ func (g *ClientTestGenerator) genBodyParamsGenerate(methodOpt *annotation.Method
 	if len(methodOpt.BodyParams) > 0 {
 		group.Var().Id("serverRequest").StructFunc(func(group *jen.Group) {
 			for _, p := range methodOpt.BodyParams {
-				group.Id(strcase.ToCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn))
+				group.Id(g.caser.ToCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn))
 			}
 		})
 
@@ -146,11 +148,11 @@ func (g *ClientTestGenerator) genBodyParamsGenerate(methodOpt *annotation.Method
 						if v.IsArray {
 							continue
 						}
-						group.Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Op(".").Add(v.Path).Op("=").Add(g.typeToValue(v.Var.Type)).Line()
+						group.Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Op(".").Add(v.Path).Op("=").Add(g.typeToValue(v.Var.Type)).Line()
 					}
 				}
 			} else {
-				group.Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Op("=").Add(g.typeToValue(p.Var.Type)).Line()
+				group.Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Op("=").Add(g.typeToValue(p.Var.Type)).Line()
 			}
 		}
 	}
@@ -168,8 +170,8 @@ func (g *ClientTestGenerator) genParamsGenerate(params []*annotation.MethodParam
 		if p.HTTPType == annotation.BodyHTTPType {
 			continue
 		}
-		postfix := strcase.ToCamel(p.HTTPType)
-		group.Var().Id(strcase.ToLowerCamel(p.Var.Name) + postfix).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn)).Op("=").Add(g.typeToValue(p.Var.Type)).Line()
+		postfix := g.caser.ToCamel(p.HTTPType)
+		group.Var().Id(g.caser.ToLowerCamel(p.Var.Name) + postfix).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn)).Op("=").Add(g.typeToValue(p.Var.Type)).Line()
 	}
 
 	return group
@@ -196,7 +198,7 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 				if len(methodOpt.BodyParams) > 0 {
 					group.Var().Id("body").StructFunc(func(group *jen.Group) {
 						for _, p := range methodOpt.BodyParams {
-							group.Id(strcase.ToCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn)).Tag(map[string]string{
+							group.Id(g.caser.ToCamel(p.Var.Name)).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn)).Tag(map[string]string{
 								"json": p.Name,
 							})
 						}
@@ -204,7 +206,7 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 					var bodyVar jen.Code
 					if methodOpt.Single.Req && len(methodOpt.BodyParams) == 1 {
-						bodyVar = jen.Op("&").Id("body").Dot(strcase.ToCamel(methodOpt.BodyParams[0].Var.Name))
+						bodyVar = jen.Op("&").Id("body").Dot(g.caser.ToCamel(methodOpt.BodyParams[0].Var.Name))
 					} else {
 						bodyVar = jen.Op("&").Id("body")
 					}
@@ -219,7 +221,7 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 						switch {
 						default:
-							group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Op("!=").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).BlockFunc(func(g *jen.Group) {
+							group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Op("!=").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).BlockFunc(func(g *jen.Group) {
 								g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + p.Name))
 							}))
 						case typeInfo.Struct != nil:
@@ -231,11 +233,11 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 									fieldPath := v.Paths.String()
 
 									if v.Var.Type.IsMap {
-										group.If(jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)), jen.Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name))).BlockFunc(func(g *jen.Group) {
+										group.If(jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)), jen.Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name))).BlockFunc(func(g *jen.Group) {
 											g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + p.Name))
 										}))
 									} else {
-										group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
+										group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
 											g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath))
 										})
 									}
@@ -252,31 +254,31 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 										switch {
 										default:
-											group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
+											group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
 												g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath))
 											})
 										case v.Var.Type.IsBasic:
 											if v.Var.Type.IsPtr {
-												group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("==").Nil()).BlockFunc(func(g *jen.Group) {
+												group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("==").Nil()).BlockFunc(func(g *jen.Group) {
 													g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath + " is nil"))
 												})
-												group.If(jen.Op("*").Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Op("*").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
+												group.If(jen.Op("*").Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Op("*").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
 													g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath))
 												})
 											} else {
-												group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
+												group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path).Op("!=").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path)).BlockFunc(func(g *jen.Group) {
 													g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath))
 												})
 											}
 										case gomosaic.IsTime(v.Var.Type) || gomosaic.IsDuration(v.Var.Type):
-											group.If(jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path), jen.Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path))).BlockFunc(func(g *jen.Group) {
+											group.If(jen.Op("!").Qual("reflect", "DeepEqual").Call(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path), jen.Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op(".").Add(v.Path))).BlockFunc(func(g *jen.Group) {
 												g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + fieldPath))
 											})
 										}
 									}
 								}
 							} else if typeInfo.ElemType.IsBasic {
-								group.If(jen.Id("body").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op("!=").Id("serverRequest").Dot(strcase.ToCamel(p.Var.Name)).Index(jen.Lit(0)).BlockFunc(func(g *jen.Group) {
+								group.If(jen.Id("body").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).Op("!=").Id("serverRequest").Dot(g.caser.ToCamel(p.Var.Name)).Index(jen.Lit(0)).BlockFunc(func(g *jen.Group) {
 									g.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + p.Name))
 								}))
 							}
@@ -294,7 +296,7 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 					}
 					switch p.HTTPType {
 					case annotation.PathHTTPType:
-						paramID := jen.Id(strcase.ToLowerCamel(p.Var.Name) + "PathReq")
+						paramID := jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "PathReq")
 						group.Var().Add(paramID).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn))
 
 						code := typetransform.For(p.Var.Type).
@@ -307,11 +309,11 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 						group.Add(code)
 
-						group.If(jen.Id(strcase.ToLowerCamel(p.Var.Name) + "Path").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
-							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + strcase.ToLowerCamel(p.Var.Name)))
+						group.If(jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "Path").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
+							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + g.caser.ToLowerCamel(p.Var.Name)))
 						}))
 					case annotation.QueryHTTPType:
-						paramID := jen.Id(strcase.ToLowerCamel(p.Var.Name) + "QueryReq")
+						paramID := jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "QueryReq")
 						group.Var().Add(paramID).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn))
 
 						code := typetransform.For(p.Var.Type).
@@ -324,11 +326,11 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 						group.Add(code)
 
-						group.If(jen.Id(strcase.ToLowerCamel(p.Var.Name) + "Query").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
-							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + strcase.ToLowerCamel(p.Var.Name)))
+						group.If(jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "Query").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
+							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + g.caser.ToLowerCamel(p.Var.Name)))
 						}))
 					case annotation.HeaderHTTPType:
-						paramID := jen.Id(strcase.ToLowerCamel(p.Var.Name) + "HeaderReq")
+						paramID := jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "HeaderReq")
 						group.Var().Add(paramID).Add(jenutils.TypeInfoQual(p.Var.Type, g.qualFn))
 
 						code := typetransform.For(p.Var.Type).
@@ -341,8 +343,8 @@ func (g ClientTestGenerator) genMockServerGenerate(methodOpt *annotation.MethodO
 
 						group.Add(code)
 
-						group.If(jen.Id(strcase.ToLowerCamel(p.Var.Name) + "Header").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
-							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + strcase.ToLowerCamel(p.Var.Name)))
+						group.If(jen.Id(g.caser.ToLowerCamel(p.Var.Name) + "Header").Op("!=").Add(paramID).BlockFunc(func(group *jen.Group) {
+							group.Id("t").Dot("Fatal").Call(jen.Lit("failed equal method " + methodOpt.Func.ShortName + " " + g.caser.ToLowerCamel(p.Var.Name)))
 						}))
 					}
 				}
@@ -450,6 +452,7 @@ func (g *ClientTestGenerator) Generate(ifaceOpts []*annotation.IfaceOpt, configs
 				testMethod := fmt.Sprintf("%s_%d", methodOpt.Func.Name, cfg.StatusCode)
 				testName := "Test" + methodOpt.Iface.NameTypeInfo.Name + "_" + testMethod
 
+				g.sources.SetDeclSource(testName, methodOpt.Iface.NameTypeInfo, methodOpt.Func)
 				group.Func().Id(testName).Params(jen.Id("t").Op("*").Qual("testing", "T")).BlockFunc(func(group *jen.Group) {
 					group.Add(g.genServerResponseGenerate(cfg, methodOpt))
 					group.Add(g.genBodyParamsGenerate(methodOpt))
@@ -497,7 +500,7 @@ func (g *ClientTestGenerator) Generate(ifaceOpts []*annotation.IfaceOpt, configs
 							if p.Var.IsContext {
 								continue
 							}
-							name := strcase.ToLowerCamel(p.Var.Name)
+							name := g.caser.ToLowerCamel(p.Var.Name)
 							switch p.HTTPType {
 							case annotation.HeaderHTTPType:
 								group.Id(name + "Header")
@@ -506,7 +509,7 @@ func (g *ClientTestGenerator) Generate(ifaceOpts []*annotation.IfaceOpt, configs
 							case annotation.QueryHTTPType:
 								group.Id(name + "Query")
 							case annotation.BodyHTTPType:
-								group.Id("serverRequest").Dot(strcase.ToCamel(name))
+								group.Id("serverRequest").Dot(g.caser.ToCamel(name))
 							case annotation.PathHTTPType:
 								group.Id(name + "Path")
 							}
@@ -526,9 +529,13 @@ func (g *ClientTestGenerator) Generate(ifaceOpts []*annotation.IfaceOpt, configs
 func NewClientTest(
 	fake faker.Faker,
 	qualFn jenutils.QualFunc,
+	sources gomosaic.DeclSourceRecorder,
+	caser *strcase.Caser,
 ) *ClientTestGenerator {
 	return &ClientTestGenerator{
-		fake:   fake,
-		qualFn: qualFn,
+		fake:    fake,
+		qualFn:  qualFn,
+		sources: sources,
+		caser:   caser,
 	}
 }
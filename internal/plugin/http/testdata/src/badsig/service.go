@@ -0,0 +1,15 @@
+package badsig
+
+import "context"
+
+// @gomosaic
+// @http-client-enable
+type BadService interface {
+	// @http-method GET
+	// @http-path /no-context
+	NoContext(id int) (err error) // want "первым параметром обязателен тип context.Context"
+	// @http-method FETCH
+	// want -1 "http-method valid only params"
+	// @http-path /bad-method
+	BadMethod(ctx context.Context) (err error)
+}
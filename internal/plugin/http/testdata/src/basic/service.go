@@ -0,0 +1,23 @@
+package basic
+
+import "context"
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// @gomosaic
+// @http-client-enable
+type UserService interface {
+	// @http-method GET
+	// @http-path /users/:id
+	GetUser(ctx context.Context, id int) (user *User, err error)
+	// @http-method POST
+	// @http-path /users
+	CreateUser(ctx context.Context, user *User) (err error)
+	// @http-method GET
+	// @http-path /users
+	// @http-query limit
+	ListUsers(ctx context.Context, limit int) (users []*User, total int, err error)
+}
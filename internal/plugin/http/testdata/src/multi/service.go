@@ -0,0 +1,44 @@
+package multi
+
+import "context"
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Order struct {
+	ID     int `json:"id"`
+	UserID int `json:"userID"`
+}
+
+// @gomosaic
+// @http-client-enable
+type UserService interface {
+	// @http-method GET
+	// @http-path /users/:id
+	GetUser(ctx context.Context, id int) (user *User, err error)
+	// @http-method POST
+	// @http-path /users
+	CreateUser(ctx context.Context, user *User) (err error)
+}
+
+// @gomosaic
+// @http-client-enable
+type OrderService interface {
+	// @http-method GET
+	// @http-path /orders/:id
+	GetOrder(ctx context.Context, id int) (order *Order, err error)
+	// @http-method GET
+	// @http-path /orders
+	// @http-query userID
+	ListOrders(ctx context.Context, userID int) (orders []*Order, err error)
+}
+
+// @gomosaic
+// @http-client-enable
+type HealthService interface {
+	// @http-method GET
+	// @http-path /health
+	Check(ctx context.Context) (err error)
+}
@@ -0,0 +1,196 @@
+package implstub
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// implFile существующий файл реализации
+type implFile struct {
+	path       string
+	src        []byte
+	imports    map[string]string // путь пакета -> имя в файле
+	decls      map[string]bool   // объявления верхнего уровня
+	methods    map[string]bool   // методы типа реализации
+	recvName   string            // имя получателя в существующих методах
+	importsEnd int               // смещение, после которого можно добавить блок импортов
+}
+
+// parseImplFile разбирает существующий файл реализации. Возвращает nil, если файла нет.
+func parseImplFile(path, typeName string) (*implFile, error) {
+	src, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("implstub.read", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, i18n.Errorf("implstub.parse", err)
+	}
+
+	f := &implFile{
+		path:       path,
+		src:        src,
+		imports:    make(map[string]string, len(file.Imports)),
+		decls:      make(map[string]bool),
+		methods:    make(map[string]bool),
+		importsEnd: fset.Position(file.Name.End()).Offset,
+	}
+
+	for _, spec := range file.Imports {
+		pkgPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Name != nil {
+			f.imports[pkgPath] = spec.Name.Name
+		} else {
+			f.imports[pkgPath] = gomosaic.PackageAlias(pkgPath)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				f.importsEnd = fset.Position(decl.End()).Offset
+				continue
+			}
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					f.decls[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						f.decls[name.Name] = true
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				f.decls[decl.Name.Name] = true
+				continue
+			}
+			recv := decl.Recv.List[0]
+			if recvTypeName(recv.Type) != typeName {
+				continue
+			}
+			f.methods[decl.Name.Name] = true
+			if f.recvName == "" && len(recv.Names) > 0 && recv.Names[0].Name != "_" {
+				f.recvName = recv.Names[0].Name
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// recvTypeName возвращает имя типа получателя метода (T, *T, T[P])
+func recvTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// position возвращает позицию начала файла для предупреждений о файле реализации
+func (f *implFile) position() token.Position {
+	return token.Position{Filename: f.path, Line: 1, Column: 1}
+}
+
+func (f *implFile) hasDecl(name string) bool {
+	return f != nil && f.decls[name]
+}
+
+func (f *implFile) hasMethod(name string) bool {
+	return f != nil && f.methods[name]
+}
+
+// reserve передает файлу заготовок имена существующего файла:
+// импорты используются под теми же псевдонимами, а объявления не перекрываются импортами.
+func (f *implFile) reserve(out *gomosaic.GoFile) {
+	for pkgPath, name := range f.imports {
+		out.ImportAlias(pkgPath, name)
+	}
+	names := make([]string, 0, len(f.decls))
+	for name := range f.decls {
+		names = append(names, name)
+	}
+	out.Imports().Reserve(names...)
+}
+
+// appendStubs возвращает содержимое существующего файла, в конец которого дописаны заготовки.
+// Существующий код не изменяется: недостающие импорты добавляются отдельным блоком после имеющихся.
+func (f *implFile) appendStubs(stubs *gomosaic.GoFile) (gomosaic.File, error) {
+	var rendered bytes.Buffer
+	if err := stubs.Render(&rendered, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+
+	src := rendered.Bytes()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+
+	var (
+		newImports [][]byte
+		declsStart = len(src)
+	)
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		declsStart = fset.Position(decl.Pos()).Offset
+		break
+	}
+	for _, spec := range file.Imports {
+		pkgPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := f.imports[pkgPath]; ok {
+			continue
+		}
+		newImports = append(newImports, src[fset.Position(spec.Pos()).Offset:fset.Position(spec.End()).Offset])
+	}
+
+	out := gomosaic.NewTxtFile()
+	out.WriteBytes(f.src[:f.importsEnd])
+	if len(newImports) > 0 {
+		out.WriteText("\n\nimport (\n")
+		for _, spec := range newImports {
+			out.WriteText("\t%s\n", spec)
+		}
+		out.WriteText(")")
+	}
+	out.WriteBytes(f.src[f.importsEnd:])
+	if !bytes.HasSuffix(f.src, []byte("\n")) {
+		out.Line()
+	}
+	out.Line()
+	out.WriteBytes(src[declsStart:])
+
+	return out, nil
+}
@@ -0,0 +1,7 @@
+package implstub
+
+import "github.com/go-mosaic/gomosaic/pkg/gomosaic"
+
+func init() {
+	gomosaic.RegisterPlugin(new(Plugin))
+}
@@ -0,0 +1,31 @@
+package implstub
+
+import (
+	"context"
+	"go/token"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+const defaultSuffix = "Impl"
+
+// PluginOpt параметры плагина, передаваемые через --opt plugin.key=value
+type PluginOpt struct {
+	Suffix string `option:"suffix" usage:"implstub.opt.suffix"`
+}
+
+// loadPluginOpt декодирует параметры плагина из контекста поверх значений по умолчанию
+func loadPluginOpt(ctx context.Context) (*PluginOpt, error) {
+	opts := &PluginOpt{Suffix: defaultSuffix}
+	if err := option.UnmarshalPluginOptions(gomosaic.PluginOptionsFromContext(ctx), opts); err != nil {
+		return nil, err
+	}
+
+	if !token.IsIdentifier("X" + opts.Suffix) {
+		return nil, i18n.Errorf("implstub.suffix", gomosaic.PluginOptionsFromContext(ctx).Plugin, opts.Suffix)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,159 @@
+package implstub
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/jenutils"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+// Plugin создает заготовки реализаций интерфейсов: тип, конструктор
+// и методы с panic("not implemented"). Файл реализации принадлежит пользователю:
+// он создается только при отсутствии, а при следующих запусках в конец файла
+// дописываются только заготовки новых методов интерфейса. Удаленные пользователем
+// тип или конструктор не создаются заново, о них выводится предупреждение.
+type Plugin struct{}
+
+func (p *Plugin) Name() string { return "impl-stub" }
+
+func (p *Plugin) Options() any { return &PluginOpt{Suffix: defaultSuffix} }
+
+// CacheInputs возвращает существующие файлы реализаций в директории вывода,
+// чтобы удаление метода из файла приводило к повторному добавлению заготовки
+func (p *Plugin) CacheInputs(ctx context.Context, _ *gomosaic.ModuleInfo) ([]string, error) {
+	opts, err := loadPluginOpt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := "*.go"
+	if opts.Suffix != "" {
+		pattern = "*_" + gomosaic.CaserFromContext(ctx).ToSnake(opts.Suffix) + ".go"
+	}
+
+	return filepath.Glob(filepath.Join(gomosaic.OutputDirFromContext(ctx), pattern))
+}
+
+func (p *Plugin) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
+	outputDir := gomosaic.OutputDirFromContext(ctx)
+
+	opts, err := loadPluginOpt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	caser := gomosaic.CaserFromContext(ctx)
+	files = make(map[string]gomosaic.File)
+	for _, nameTypeInfo := range types {
+		if nameTypeInfo.Type == nil || nameTypeInfo.Type.Interface == nil {
+			continue
+		}
+
+		typeName := caser.ToCamel(nameTypeInfo.Name) + opts.Suffix
+		filename := caser.ToSnake(typeName) + ".go"
+
+		existing, err := parseImplFile(filepath.Join(outputDir, filename), typeName)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		f := gomosaic.NewGoFile(module, outputDir, gomosaic.Editable())
+		if existing != nil {
+			if !existing.hasDecl(typeName) {
+				errs = multierror.Append(errs, gomosaic.Warnf("implstub.missing-type", existing.position(), typeName))
+				continue
+			}
+			if constructName := "New" + typeName; !existing.hasDecl(constructName) {
+				errs = multierror.Append(errs, gomosaic.Warnf("implstub.missing-constructor", existing.position(), constructName))
+			}
+			existing.reserve(f)
+		}
+
+		if !genStubs(f, caser, nameTypeInfo, typeName, existing) {
+			continue
+		}
+
+		if existing == nil {
+			files[filename] = f
+			continue
+		}
+
+		file, err := existing.appendStubs(f)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		files[filename] = file
+	}
+
+	return files, errs
+}
+
+// genStubs добавляет в файл заготовки методов, которых нет в существующем файле реализации,
+// а для нового файла еще и тип с конструктором. Возвращает false, если добавлять нечего.
+func genStubs(f *gomosaic.GoFile, caser *strcase.Caser, nameTypeInfo *gomosaic.NameTypeInfo, typeName string, existing *implFile) (added bool) {
+	if existing == nil {
+		ifaceType := jen.Do(f.Qual(nameTypeInfo.Package.Path, nameTypeInfo.Name))
+		f.SetDeclSource(typeName, nameTypeInfo, nil)
+		f.SetDeclSource("New"+typeName, nameTypeInfo, nil)
+		f.Type().Id(typeName).Struct()
+		f.Var().Id("_").Add(ifaceType).Op("=").Parens(jen.Op("*").Id(typeName)).Call(jen.Nil())
+		f.Func().Id("New" + typeName).Params().Op("*").Id(typeName).Block(
+			jen.Return(jen.Op("&").Id(typeName).Values()),
+		)
+		added = true
+	}
+
+	recvName := caser.ToLowerCamel(typeName)[:1]
+	if existing != nil && existing.recvName != "" {
+		recvName = existing.recvName
+	}
+
+	for _, m := range nameTypeInfo.Type.Interface.Methods {
+		if existing.hasMethod(m.Name) {
+			continue
+		}
+		f.SetDeclSource(typeName+"."+m.Name, nameTypeInfo, m)
+		genMethod(f, recvName, typeName, m)
+		added = true
+	}
+
+	return added
+}
+
+func genMethod(f *gomosaic.GoFile, recvName, typeName string, m *gomosaic.MethodInfo) {
+	for _, v := range append(append([]*gomosaic.VarInfo{}, m.Params...), m.Results...) {
+		if v.Name == recvName {
+			recvName = ""
+		}
+	}
+
+	recv := jen.Op("*").Id(typeName)
+	if recvName != "" {
+		recv = jen.Id(recvName).Add(recv)
+	}
+
+	f.Line()
+	f.Func().Params(recv).Id(m.Name).
+		ParamsFunc(func(group *jen.Group) {
+			for i, p := range m.Params {
+				if m.IsVariadic && i == len(m.Params)-1 {
+					group.Id(p.Name).Op("...").Add(jenutils.TypeInfoQual(p.Type.ElemType, f.Qual))
+					continue
+				}
+				group.Id(p.Name).Add(jenutils.TypeInfoQual(p.Type, f.Qual))
+			}
+		}).
+		ParamsFunc(func(group *jen.Group) {
+			for _, r := range m.Results {
+				group.Add(jenutils.TypeInfoQual(r.Type, f.Qual))
+			}
+		}).
+		Block(jen.Panic(jen.Lit("not implemented")))
+}
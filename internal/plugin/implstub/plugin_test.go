@@ -0,0 +1,19 @@
+package implstub
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaictest"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+func TestPlugin(t *testing.T) {
+	gomosaictest.RunWithTypeCheck(t, gomosaictest.TestData(), new(Plugin), "basic/...", "existing/...", "complete/...", "deleted/...")
+}
@@ -0,0 +1,19 @@
+package basic
+
+import (
+	"context"
+	"time"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// @gomosaic
+type UserService interface {
+	GetUser(ctx context.Context, id int) (user *User, err error)
+	ListUsers(ctx context.Context, since time.Time, limit int) (users []*User, total int, err error)
+	Ping(ctx context.Context)
+	Log(ctx context.Context, format string, args ...any)
+}
@@ -0,0 +1,9 @@
+package complete
+
+import "context"
+
+type PingServiceImpl struct{}
+
+func NewPingServiceImpl() *PingServiceImpl { return &PingServiceImpl{} }
+
+func (PingServiceImpl) Ping(context.Context) error { return nil }
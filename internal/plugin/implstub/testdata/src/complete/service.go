@@ -0,0 +1,8 @@
+package complete
+
+import "context"
+
+// @gomosaic
+type PingService interface {
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,10 @@
+package deleted // want "конструктор NewOrderServiceImpl удален из файла реализации"
+
+import "context"
+
+// OrderServiceImpl создается без конструктора
+type OrderServiceImpl struct{}
+
+func (s *OrderServiceImpl) Get(ctx context.Context, id int) error {
+	return nil
+}
@@ -0,0 +1,3 @@
+package deleted // want "тип PaymentServiceImpl удален из файла реализации"
+
+// Реализация PaymentService перенесена в отдельный пакет
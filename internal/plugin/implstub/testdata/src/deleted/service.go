@@ -0,0 +1,14 @@
+package deleted
+
+import "context"
+
+// @gomosaic
+type OrderService interface {
+	Get(ctx context.Context, id int) error
+	Cancel(ctx context.Context, id int) error
+}
+
+// @gomosaic
+type PaymentService interface {
+	Pay(ctx context.Context, amount int) error
+}
@@ -0,0 +1,16 @@
+package existing
+
+import "context"
+
+// UserServiceImpl хранит пользователей в памяти
+type UserServiceImpl struct {
+	users map[int]*User
+}
+
+func NewUserServiceImpl() *UserServiceImpl {
+	return &UserServiceImpl{users: make(map[int]*User)}
+}
+
+func (s *UserServiceImpl) GetUser(ctx context.Context, id int) (*User, error) {
+	return s.users[id], nil
+}
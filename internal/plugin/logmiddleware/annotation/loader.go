@@ -11,7 +11,7 @@ type MethodOpt struct {
 	Iface *IfaceOpt
 	Func  *gomosaic.MethodInfo
 
-	// @godoc-title "Пропустить генерацию логирования для метода"
+	// @docgen-title "Пропустить генерацию логирования для метода"
 	Skip bool `option:"skip,asFlag"`
 }
 
@@ -0,0 +1,28 @@
+package annotation
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+//go:embed loader.go
+var loaderSource []byte
+
+var loadDocs = sync.OnceValue(func() option.Docs {
+	docs, err := option.ParseDocs(loaderSource)
+	if err != nil {
+		panic(err)
+	}
+	return docs
+})
+
+// Schema возвращает описание аннотаций middleware логирования с префиксом prefix
+func Schema(prefix string) *gomosaic.AnnotationSchema {
+	return &gomosaic.AnnotationSchema{
+		Prefix:      prefix,
+		Annotations: option.DescribeAnnotations(prefix, gomosaic.TargetMethod, MethodOpt{}, loadDocs()),
+	}
+}
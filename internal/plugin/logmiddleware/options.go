@@ -0,0 +1,29 @@
+package logmiddleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+// PluginOpt параметры плагина, передаваемые через --opt plugin.key=value
+type PluginOpt struct {
+	Filename string `option:"filename" usage:"plugin.opt.filename"`
+}
+
+// loadPluginOpt декодирует параметры плагина из контекста поверх значений по умолчанию
+func loadPluginOpt(ctx context.Context, filename string) (*PluginOpt, error) {
+	opts := &PluginOpt{Filename: filename}
+	if err := option.UnmarshalPluginOptions(gomosaic.PluginOptionsFromContext(ctx), opts); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(opts.Filename, ".go") {
+		return nil, i18n.Errorf("plugin.filename-ext", gomosaic.PluginOptionsFromContext(ctx).Plugin, opts.Filename)
+	}
+
+	return opts, nil
+}
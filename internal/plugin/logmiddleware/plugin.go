@@ -10,25 +10,48 @@ import (
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
 )
 
+// annotationPrefix префикс аннотаций плагина
+const annotationPrefix = "log"
+
+const defaultFilename = "log_middleware_gen.go"
+
 type Plugin struct{}
 
 func (p *Plugin) Name() string { return "log-middleware" }
 
+func (p *Plugin) Options() any { return &PluginOpt{Filename: defaultFilename} }
+
+func (p *Plugin) AnnotationSchema() *gomosaic.AnnotationSchema {
+	return annotation.Schema(annotationPrefix)
+}
+
+func (p *Plugin) Validate(_ context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) error {
+	_, err := annotation.Load(module, annotationPrefix, types)
+	return err
+}
+
 func (p *Plugin) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
 	outputDir := gomosaic.OutputDirFromContext(ctx)
 
+	opts, err := loadPluginOpt(ctx, defaultFilename)
+	if err != nil {
+		return nil, err
+	}
+
 	f := gomosaic.NewGoFile(module, outputDir)
 
-	annotations, err := annotation.Load(module, "log", types)
+	annotations, err := annotation.Load(module, annotationPrefix, types)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, service := range annotations {
+	codes, err := gomosaic.ParallelMap(ctx, annotations, func(_ context.Context, service *annotation.IfaceOpt) (jen.Code, error) {
 		g := middleware.NewGenerator(
 			service.NameTypeInfo,
 			"Log",
 			f.Qual,
+			f,
+			gomosaic.CaserFromContext(ctx),
 			[]jen.Code{
 				jen.Id("logger"), jen.Qual(gomosaic.SpanPkg, "Logger"),
 			},
@@ -56,13 +79,15 @@ func (p *Plugin) Generate(ctx context.Context, module *gomosaic.ModuleInfo, type
 				})
 		}
 
-		code, err := g.Generate()
-		if err != nil {
-			return nil, err
-		}
+		return g.Generate()
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	for _, code := range codes {
 		f.Add(code)
 	}
 
-	return map[string]gomosaic.File{"log_middleware_gen.go": f}, errs
+	return map[string]gomosaic.File{opts.Filename: f}, errs
 }
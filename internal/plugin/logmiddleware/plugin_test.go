@@ -0,0 +1,11 @@
+package logmiddleware
+
+import (
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaictest"
+)
+
+func TestPlugin(t *testing.T) {
+	gomosaictest.Run(t, gomosaictest.TestData(), new(Plugin), "basic/...")
+}
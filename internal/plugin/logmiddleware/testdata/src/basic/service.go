@@ -0,0 +1,16 @@
+package basic
+
+import "context"
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// @gomosaic
+type UserService interface {
+	GetUser(ctx context.Context, id int) (user *User, err error)
+	// @log-skip
+	Ping(ctx context.Context)
+	Count(ctx context.Context) (total int)
+}
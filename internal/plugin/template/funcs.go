@@ -0,0 +1,256 @@
+package template
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+// funcMap возвращает функции, доступные шаблонам.
+// Строковые функции принимают обрабатываемую строку последним аргументом,
+// чтобы их можно было использовать в конвейерах: {{ .Name | trimSuffix "Service" | snake }}.
+// Псевдонимы пакетов планируются один раз для всех пакетов из сигнатур методов,
+// поэтому typeName, zero и pkgAlias согласованы между собой и с imports.
+func funcMap(data *Data, caser *strcase.Caser) texttemplate.FuncMap {
+	imports := typeImports(data.Types, data.PkgPath)
+	aliases := gomosaic.NewImportPlanner().Plan(imports)
+
+	qualifier := func(pkgPath string) string {
+		if pkgPath == data.PkgPath {
+			return ""
+		}
+		if alias, ok := aliases[pkgPath]; ok {
+			return alias
+		}
+		return gomosaic.PackageAlias(pkgPath)
+	}
+
+	return texttemplate.FuncMap{
+		"camel":          caser.ToCamel,
+		"lowerCamel":     caser.ToLowerCamel,
+		"snake":          caser.ToSnake,
+		"screamingSnake": caser.ToScreamingSnake,
+		"kebab":          caser.ToKebab,
+		"lower":          strings.ToLower,
+		"upper":          strings.ToUpper,
+		"join":           func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"split":          func(sep, s string) []string { return strings.Split(s, sep) },
+		"replace":        func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"trimPrefix":     func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix":     func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"hasPrefix":      func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":      func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"contains":       func(substr, s string) bool { return strings.Contains(s, substr) },
+		"quote":          strconv.Quote,
+		"default": func(def, s string) string {
+			if s == "" {
+				return def
+			}
+			return s
+		},
+
+		"typeName": func(t *gomosaic.TypeInfo) string {
+			return typeString(t, qualifier)
+		},
+		"typePath": func(t *gomosaic.TypeInfo) string {
+			return typeString(t, func(pkgPath string) string { return pkgPath })
+		},
+		"pkgAlias": qualifier,
+		"imports": func(types []*gomosaic.NameTypeInfo) []string {
+			return typeImports(types, data.PkgPath)
+		},
+		"zero": func(t *gomosaic.TypeInfo) string {
+			return zeroValue(t, qualifier)
+		},
+
+		"interfaces": func(types []*gomosaic.NameTypeInfo) (result []*gomosaic.NameTypeInfo) {
+			for _, t := range types {
+				if t.Type != nil && t.Type.Interface != nil {
+					result = append(result, t)
+				}
+			}
+			return result
+		},
+		"structs": func(types []*gomosaic.NameTypeInfo) (result []*gomosaic.NameTypeInfo) {
+			for _, t := range types {
+				if t.Type != nil && t.Type.Struct != nil {
+					result = append(result, t)
+				}
+			}
+			return result
+		},
+		"params": func(m *gomosaic.MethodInfo) (result []*gomosaic.VarInfo) {
+			for _, v := range m.Params {
+				if !v.IsContext {
+					result = append(result, v)
+				}
+			}
+			return result
+		},
+		"results": func(m *gomosaic.MethodInfo) (result []*gomosaic.VarInfo) {
+			for _, v := range m.Results {
+				if !v.IsError {
+					result = append(result, v)
+				}
+			}
+			return result
+		},
+		"get":        getAnnotation,
+		"annotation": getAnnotation,
+	}
+}
+
+// getAnnotation заменяет в шаблонах метод Annotations.Get, который возвращает два значения
+// и поэтому не вызывается из шаблона. Для отсутствующей аннотации возвращается nil,
+// что позволяет проверять ее через {{ with get "key" .Annotations }}.
+func getAnnotation(key string, annotations gomosaic.Annotations) *gomosaic.AnnotationInfo {
+	a, _ := annotations.Get(key)
+	return a
+}
+
+// typeString возвращает тип в синтаксисе Go, qualifier возвращает префикс пакета (пустой для текущего пакета)
+func typeString(t *gomosaic.TypeInfo, qualifier func(pkgPath string) string) string {
+	if t == nil {
+		return ""
+	}
+
+	switch {
+	case t.IsPtr:
+		return "*" + typeString(t.ElemType, qualifier)
+	case t.IsSlice:
+		return "[]" + typeString(t.ElemType, qualifier)
+	case t.IsArray:
+		return "[" + strconv.Itoa(t.ArrayLen) + "]" + typeString(t.ElemType, qualifier)
+	case t.IsMap:
+		return "map[" + typeString(t.KeyType, qualifier) + "]" + typeString(t.ElemType, qualifier)
+	case t.IsChan:
+		return t.Name + " " + typeString(t.ElemType, qualifier)
+	case t.IsNamed:
+		name := t.Name
+		if t.Package != "" {
+			if q := qualifier(t.Package); q != "" {
+				name = q + "." + name
+			}
+		}
+		if t.IsInstantiated && len(t.TypeParams) > 0 {
+			args := make([]string, 0, len(t.TypeParams))
+			for _, arg := range t.TypeParams {
+				args = append(args, typeString(arg, qualifier))
+			}
+			name += "[" + strings.Join(args, ", ") + "]"
+		}
+		return name
+	case t.Signature != nil:
+		return "func" + signatureString(t.Signature, qualifier)
+	case t.Struct != nil:
+		fields := make([]string, 0, len(t.Struct.Fields))
+		for _, f := range t.Struct.Fields {
+			fields = append(fields, f.Name+" "+typeString(f.Type, qualifier))
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	case t.Interface != nil:
+		methods := make([]string, 0, len(t.Interface.Methods))
+		for _, m := range t.Interface.Methods {
+			methods = append(methods, m.Name+signatureString(&gomosaic.SignatureInfo{Params: m.Params, Results: m.Results}, qualifier))
+		}
+		return "interface{" + strings.Join(methods, "; ") + "}"
+	}
+
+	return t.Name
+}
+
+// typeImports возвращает отсортированные пути пакетов, используемых в сигнатурах методов интерфейсов
+func typeImports(types []*gomosaic.NameTypeInfo, currentPkgPath string) []string {
+	seen := make(map[string]bool)
+
+	var visit func(t *gomosaic.TypeInfo)
+	visit = func(t *gomosaic.TypeInfo) {
+		if t == nil {
+			return
+		}
+		if t.IsNamed {
+			if t.Package != "" && t.Package != currentPkgPath {
+				seen[t.Package] = true
+			}
+			for _, arg := range t.TypeParams {
+				visit(arg)
+			}
+			return
+		}
+		visit(t.KeyType)
+		visit(t.ElemType)
+	}
+
+	for _, t := range types {
+		if t.Type == nil || t.Type.Interface == nil {
+			continue
+		}
+		for _, m := range t.Type.Interface.Methods {
+			for _, v := range m.Params {
+				visit(v.Type)
+			}
+			for _, v := range m.Results {
+				visit(v.Type)
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// zeroValue возвращает нулевое значение типа в синтаксисе Go
+func zeroValue(t *gomosaic.TypeInfo, qualifier func(pkgPath string) string) string {
+	if t == nil {
+		return "nil"
+	}
+
+	underlying := t
+	if t.IsNamed && t.ElemType != nil {
+		underlying = t.ElemType
+	}
+
+	switch {
+	case underlying.IsPtr, underlying.IsSlice, underlying.IsMap, underlying.IsChan,
+		underlying.Signature != nil, underlying.Interface != nil:
+		return "nil"
+	case underlying.Struct != nil, underlying.IsArray:
+		return typeString(t, qualifier) + "{}"
+	case underlying.IsBasic && underlying.BasicInfo&gomosaic.IsString != 0:
+		return `""`
+	case underlying.IsBasic && underlying.BasicInfo&gomosaic.IsBoolean != 0:
+		return "false"
+	case underlying.IsBasic:
+		return "0"
+	}
+
+	return "nil"
+}
+
+func signatureString(sig *gomosaic.SignatureInfo, qualifier func(pkgPath string) string) string {
+	vars := func(vars []*gomosaic.VarInfo) string {
+		s := make([]string, 0, len(vars))
+		for _, v := range vars {
+			s = append(s, typeString(v.Type, qualifier))
+		}
+		return strings.Join(s, ", ")
+	}
+
+	s := "(" + vars(sig.Params) + ")"
+	switch len(sig.Results) {
+	case 0:
+	case 1:
+		s += " " + vars(sig.Results)
+	default:
+		s += " (" + vars(sig.Results) + ")"
+	}
+	return s
+}
@@ -0,0 +1,29 @@
+package template
+
+import (
+	"context"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/option"
+)
+
+// PluginOpt параметры плагина, передаваемые через --opt plugin.key=value
+type PluginOpt struct {
+	Dir   string   `option:"dir" usage:"template.opt.dir"`
+	Files []string `option:"files" usage:"template.opt.files"`
+}
+
+// loadPluginOpt декодирует параметры плагина из контекста
+func loadPluginOpt(ctx context.Context) (*PluginOpt, error) {
+	opts := &PluginOpt{}
+	if err := option.UnmarshalPluginOptions(gomosaic.PluginOptionsFromContext(ctx), opts); err != nil {
+		return nil, err
+	}
+
+	if opts.Dir == "" && len(opts.Files) == 0 {
+		return nil, i18n.Errorf("template.no-templates", gomosaic.PluginOptionsFromContext(ctx).Plugin)
+	}
+
+	return opts, nil
+}
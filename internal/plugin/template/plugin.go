@@ -0,0 +1,184 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+const (
+	templateExt   = ".tmpl"
+	partialPrefix = "_"
+)
+
+// Data данные, которые получает шаблон
+type Data struct {
+	Module    *gomosaic.ModuleInfo     // Информация о модуле
+	Types     []*gomosaic.NameTypeInfo // Типы, найденные во входных пакетах
+	Package   string                   // Имя пакета директории вывода
+	PkgPath   string                   // Путь пакета директории вывода
+	OutputDir string                   // Директория вывода
+}
+
+// Plugin выполняет пользовательские шаблоны text/template над моделью.
+// Имя сгенерированного файла получается из имени шаблона без расширения .tmpl,
+// файлы .go форматируются gofmt и получают заголовок сгенерированного файла.
+// Шаблоны с именем, начинающимся с "_", не создают файлов и доступны остальным через {{ template }}.
+type Plugin struct{}
+
+func (p *Plugin) Name() string { return "template" }
+
+func (p *Plugin) Options() any { return &PluginOpt{} }
+
+// CacheInputs возвращает файлы шаблонов, чтобы их изменение сбрасывало кеш генерации
+func (p *Plugin) CacheInputs(ctx context.Context, module *gomosaic.ModuleInfo) ([]string, error) {
+	opts, err := loadPluginOpt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return templateFiles(module, opts)
+}
+
+func (p *Plugin) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (files map[string]gomosaic.File, errs error) {
+	opts, err := loadPluginOpt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := templateFiles(module, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	outputDir := gomosaic.OutputDirFromContext(ctx)
+	pkgPath, pkgName := gomosaic.OutputPackage(module, outputDir)
+
+	data := &Data{
+		Module:    module,
+		Types:     types,
+		Package:   pkgName,
+		PkgPath:   pkgPath,
+		OutputDir: outputDir,
+	}
+
+	var partials, templates []string
+	for _, path := range paths {
+		if strings.HasPrefix(filepath.Base(path), partialPrefix) {
+			partials = append(partials, path)
+		} else {
+			templates = append(templates, path)
+		}
+	}
+
+	files = make(map[string]gomosaic.File, len(templates))
+	for _, path := range templates {
+		filename := strings.TrimSuffix(filepath.Base(path), templateExt)
+		if _, ok := files[filename]; ok {
+			errs = multierror.Append(errs, i18n.Errorf("template.conflict", path, filename))
+			continue
+		}
+
+		f, err := execute(path, partials, filename, data, gomosaic.CaserFromContext(ctx))
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		files[filename] = f
+	}
+
+	return files, errs
+}
+
+// templateFiles возвращает отсортированные пути шаблонов из директории и списка файлов
+func templateFiles(module *gomosaic.ModuleInfo, opts *PluginOpt) (paths []string, err error) {
+	if opts.Dir != "" {
+		dir := resolvePath(module, opts.Dir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, i18n.Errorf("template.read-dir", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), templateExt) {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	for _, file := range opts.Files {
+		if !strings.HasSuffix(file, templateExt) {
+			return nil, i18n.Errorf("template.ext", templateExt, file)
+		}
+		paths = append(paths, resolvePath(module, file))
+	}
+
+	if len(paths) == 0 {
+		return nil, i18n.Errorf("template.not-found", templateExt, opts.Dir)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func resolvePath(module *gomosaic.ModuleInfo, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(module.Dir, path)
+}
+
+// execute выполняет шаблон вместе с общими шаблонами и форматирует результат для файлов .go
+func execute(path string, partials []string, filename string, data *Data, caser *strcase.Caser) (gomosaic.File, error) {
+	name := filepath.Base(path)
+
+	t, err := texttemplate.New(name).
+		Funcs(funcMap(data, caser)).
+		Option("missingkey=error").
+		ParseFiles(append([]string{path}, partials...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+
+	if filepath.Ext(filename) != ".go" {
+		f := gomosaic.NewTxtFile()
+		f.WriteBytes(buf.Bytes())
+		return f, nil
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, i18n.Errorf("template.format", path, err)
+	}
+
+	return &goSourceFile{src: src}, nil
+}
+
+// goSourceFile отформатированный исходный код, к которому при сохранении добавляется заголовок
+type goSourceFile struct {
+	src []byte
+}
+
+func (f *goSourceFile) Render(w io.Writer, version string) error {
+	if _, err := io.WriteString(w, gomosaic.GeneratedHeader(version)+"\n"); err != nil {
+		return err
+	}
+	_, err := w.Write(f.src)
+	return err
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaictest"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+func TestPlugin(t *testing.T) {
+	gomosaictest.RunWithOptions(t, gomosaictest.TestData(), new(Plugin), map[string]string{"dir": "basic/templates"}, "basic")
+}
+
+func TestPluginErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		wantErr string
+	}{
+		{
+			name:    "шаблоны не указаны",
+			options: map[string]string{},
+			wantErr: "не указаны шаблоны",
+		},
+		{
+			name:    "файл без расширения .tmpl",
+			options: map[string]string{"files": "templates/plain.txt"},
+			wantErr: "должен иметь расширение .tmpl",
+		},
+		{
+			name:    "неизвестная функция",
+			options: map[string]string{"files": "templates/undefined.txt.tmpl"},
+			wantErr: `function "nope" not defined`,
+		},
+		{
+			name:    "go код не форматируется",
+			options: map[string]string{"files": "templates/broken_gen.go.tmpl"},
+			wantErr: "не удалось отформатировать",
+		},
+		{
+			name:    "неизвестный параметр",
+			options: map[string]string{"directory": "templates"},
+			wantErr: "неизвестный параметр directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := gomosaic.ContextWithOutputDir(context.Background(), t.TempDir())
+			ctx = gomosaic.ContextWithPluginOptions(ctx, &gomosaic.PluginOptions{Plugin: "template", Values: tt.options})
+
+			module := &gomosaic.ModuleInfo{Dir: gomosaictest.TestData(), Path: "example.com"}
+			_, err := new(Plugin).Generate(ctx, module, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package basic
+
+import "context"
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// @gomosaic
+// @permission-group users
+type UserService interface {
+	// @http-method GET
+	// @http-path /users/:id
+	// @permission read
+	GetUser(ctx context.Context, id int) (user *User, err error)
+	// @http-method POST
+	// @http-path /users
+	// @permission write
+	CreateUser(ctx context.Context, user User) (err error)
+	// @http-method GET
+	// @http-path /users
+	FindUsers(ctx context.Context, filter map[string]string, limit int) (users []*User, total int, err error)
+}
@@ -0,0 +1,88 @@
+// Package rewrite переписывает части исходных файлов пакетов, сохраняя остальной код:
+// общая основа gomosaic migrate и gomosaic fmt.
+package rewrite
+
+import (
+	"bytes"
+	"os"
+	"sort"
+
+	"github.com/go-mosaic/gomosaic/internal/textdiff"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// Result результат переписывания файла
+type Result struct {
+	Filename  string // Путь к файлу
+	Original  []byte // Исходное содержимое
+	Rewritten []byte // Содержимое после изменений
+}
+
+// Changed сообщает, изменился ли файл
+func (r *Result) Changed() bool {
+	return !bytes.Equal(r.Original, r.Rewritten)
+}
+
+// Write перезаписывает файл измененным содержимым с сохранением прав доступа
+func (r *Result) Write() error {
+	info, err := os.Stat(r.Filename)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.Filename, r.Rewritten, info.Mode().Perm())
+}
+
+// Diff возвращает изменения файла в формате unified diff
+func (r *Result) Diff() string {
+	return textdiff.Unified(r.Filename, r.Original, r.Rewritten)
+}
+
+// Edit замена содержимого файла между смещениями Start и End
+type Edit struct {
+	Start, End int
+	Text       string
+}
+
+// Apply возвращает src с примененными правками. Правки должны идти по возрастанию смещений и не пересекаться.
+func Apply(src []byte, edits []Edit) []byte {
+	if len(edits) == 0 {
+		return src
+	}
+
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		out.Write(src[last:e.Start])
+		out.WriteString(e.Text)
+		last = e.End
+	}
+	out.Write(src[last:])
+	return out.Bytes()
+}
+
+// Files применяет fn к файлам пакетов, подходящих под шаблоны paths, в порядке имен
+// и возвращает результаты только для измененных файлов. Файлы не перезаписываются.
+func Files[R interface{ Changed() bool }](dir string, paths []string, fn func(filename string, src []byte) (R, error)) (results []R, err error) {
+	filenames, err := gomosaic.PackageFiles(dir, paths)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := fn(filename, src)
+		if err != nil {
+			return nil, err
+		}
+		if result.Changed() {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
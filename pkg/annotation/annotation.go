@@ -2,6 +2,7 @@ package annotation
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -20,6 +21,44 @@ func (a *Annotation) Value() string {
 	return ""
 }
 
+// String возвращает аннотацию в каноническом виде: @key, опции и параметры key=value,
+// отсортированные по имени. Значения с пробелами, кавычками и обратной косой чертой
+// заключаются в двойные кавычки с экранированием, так что Parse(a.String()) возвращает ту же аннотацию.
+// Исключение: опции со знаком =, которые Parse разбирает как параметры.
+func (a *Annotation) String() string {
+	var b strings.Builder
+	b.WriteString("@" + a.Key)
+
+	for _, option := range a.Options {
+		b.WriteString(" " + quote(option))
+	}
+
+	keys := make([]string, 0, len(a.Params))
+	for key := range a.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteString(" " + key + "=" + quote(a.Params[key]))
+	}
+
+	return b.String()
+}
+
+// quote заключает значение в кавычки, если без них оно будет разобрано иначе
+func quote(value string) string {
+	needQuote := value == "" || strings.ContainsFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '\'' || r == '\\'
+	})
+	if !needQuote {
+		return value
+	}
+
+	// Экранируются обе кавычки: splitAnnotation не различает их внутри значения
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `'`, `\'`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
 // parseAnnotation парсит аннотацию.
 func parseAnnotation(s string) (*Annotation, error) {
 	s = strings.TrimSpace(s)
@@ -62,3 +62,53 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotationString(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "ключ без значений",
+			s:    `@gomosaic`,
+			want: `@gomosaic`,
+		},
+		{
+			name: "лишние пробелы и одинарные кавычки",
+			s:    `@http-path   '/user/:id'`,
+			want: `@http-path /user/:id`,
+		},
+		{
+			name: "параметры сортируются по имени после опций",
+			s:    `@http-error type=int "Result" code=404`,
+			want: `@http-error Result code=404 type=int`,
+		},
+		{
+			name: "экранирование кавычек и пробелов",
+			s:    `@http-error "result Result" description='Some \"error\" it\'s' path="C:\\tmp" empty=""`,
+			want: `@http-error "result Result" description="Some \"error\" it\'s" empty="" path="C:\\tmp"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.s)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got := a.String()
+			if got != tt.want {
+				t.Fatalf("String() = %s, want %s", got, tt.want)
+			}
+
+			parsed, err := Parse(got)
+			if err != nil {
+				t.Fatalf("Parse(String()) error = %v", err)
+			}
+			if parsed.Key != a.Key || !reflect.DeepEqual(parsed.Options, a.Options) || !reflect.DeepEqual(parsed.Params, a.Params) {
+				t.Errorf("Parse(String()) = %+v, want %+v", parsed, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Package annotationfmt приводит аннотации gomosaic в комментариях к каноническому виду:
+// каждая аннотация разбирается annotation.Parse и записывается через Annotation.String,
+// а аннотации одного комментария упорядочиваются по заданному порядку ключей.
+// Заголовок и описание в комментариях не изменяются.
+package annotationfmt
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-mosaic/gomosaic/internal/rewrite"
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+)
+
+// DefaultOrder порядок ключей по умолчанию: маркер @gomosaic первым, остальные аннотации в исходном порядке
+var DefaultOrder = []string{"gomosaic"}
+
+// Option параметр форматирования
+type Option func(*formatter)
+
+// WithOrder задает порядок аннотаций в комментарии. Элемент порядка совпадает с ключом
+// целиком или с его префиксом плагина: "http" соответствует @http-method и @http-path.
+// Для ключа выбирается самое длинное совпадение, аннотации без совпадений ставятся
+// после остальных в исходном порядке.
+func WithOrder(keys ...string) Option {
+	return func(f *formatter) {
+		f.order = keys
+	}
+}
+
+type formatter struct {
+	order []string
+}
+
+func newFormatter(opts ...Option) *formatter {
+	f := &formatter{order: DefaultOrder}
+	for _, optApply := range opts {
+		optApply(f)
+	}
+	return f
+}
+
+// Result результат форматирования файла
+type Result = rewrite.Result
+
+// Format форматирует аннотации в файлах пакетов, подходящих под шаблоны paths,
+// и возвращает результаты только для измененных файлов. Файлы не перезаписываются.
+func Format(dir string, paths []string, opts ...Option) (results []*Result, err error) {
+	return rewrite.Files(dir, paths, func(filename string, src []byte) (*Result, error) {
+		return Source(filename, src, opts...)
+	})
+}
+
+// annotationComment комментарий // с аннотацией
+type annotationComment struct {
+	comment    *ast.Comment
+	annotation *annotation.Annotation
+	text       string // канонический текст комментария
+}
+
+// Source форматирует аннотации в комментариях файла. Изменяются только комментарии
+// с аннотациями, остальной код и текст комментариев сохраняются.
+func Source(filename string, src []byte, opts ...Option) (*Result, error) {
+	result := &Result{Filename: filename, Original: src, Rewritten: src}
+	if !bytes.Contains(src, []byte("@")) {
+		return result, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	tokFile := fset.File(file.Pos())
+
+	f := newFormatter(opts...)
+
+	var edits []rewrite.Edit
+	for _, group := range file.Comments {
+		comments := annotationComments(group)
+		if len(comments) == 0 {
+			continue
+		}
+
+		// Аннотации переставляются между строками с аннотациями, строки заголовка и описания остаются на месте
+		sorted := make([]*annotationComment, len(comments))
+		copy(sorted, comments)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return f.rank(sorted[i].annotation.Key) < f.rank(sorted[j].annotation.Key)
+		})
+
+		for i, c := range comments {
+			if text := sorted[i].text; text != c.comment.Text {
+				edits = append(edits, rewrite.Edit{
+					Start: tokFile.Offset(c.comment.Pos()),
+					End:   tokFile.Offset(c.comment.End()),
+					Text:  text,
+				})
+			}
+		}
+	}
+
+	result.Rewritten = rewrite.Apply(src, edits)
+
+	return result, nil
+}
+
+// annotationComments возвращает комментарии группы с аннотациями и их каноническим текстом
+func annotationComments(group *ast.CommentGroup) (comments []*annotationComment) {
+	for _, comment := range group.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+
+		a, err := annotation.Parse(text)
+		if err != nil {
+			continue
+		}
+		c := &annotationComment{comment: comment, annotation: a, text: "// " + text}
+		if canonical := a.String(); canonicalizable(a, canonical) {
+			c.text = "// " + canonical
+		}
+		comments = append(comments, c)
+	}
+	return comments
+}
+
+// canonicalizable проверяет, что канонический текст разбирается в ту же аннотацию.
+// Если нет (например, опция со знаком =), аннотация сохраняется как есть.
+func canonicalizable(a *annotation.Annotation, canonical string) bool {
+	for key := range a.Params {
+		if !isParamName(key) {
+			return false
+		}
+	}
+
+	parsed, err := annotation.Parse(canonical)
+	if err != nil {
+		return false
+	}
+	return parsed.Key == a.Key &&
+		reflect.DeepEqual(parsed.Options, a.Options) &&
+		reflect.DeepEqual(parsed.Params, a.Params)
+}
+
+// rank возвращает позицию ключа в порядке аннотаций
+func (f *formatter) rank(key string) int {
+	rank, matched := len(f.order), 0
+	for i, entry := range f.order {
+		if (key == entry || strings.HasPrefix(key, entry+"-")) && len(entry) > matched {
+			rank, matched = i, len(entry)
+		}
+	}
+	return rank
+}
+
+// isParamName проверяет, что ключ параметра не содержит кавычек и других символов, которые изменит каноническая запись
+func isParamName(key string) bool {
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return key != ""
+}
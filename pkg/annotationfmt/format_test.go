@@ -0,0 +1,84 @@
+package annotationfmt
+
+import (
+	"strings"
+	"testing"
+)
+
+const source = `package svc
+
+// UserService сервис пользователей
+// @gomosaic
+type UserService interface {
+	// GetUser возвращает пользователя
+	// @http-path   '/users/:id'
+	//@http-method GET
+	// @log-skip
+	GetUser(ctx context.Context, id string) (string, error)
+	// List возвращает список пользователей
+	// @http-error type=int "Result"  code=404
+	// Описание после аннотации
+	// @gomosaic-ignore "a=b"
+	List(ctx context.Context) error /* @http-method  GET */
+}
+`
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts []Option
+		want string
+	}{
+		{
+			name: "канонический вид аннотаций без упорядочивания",
+			src:  source,
+			opts: []Option{WithOrder()},
+			want: strings.NewReplacer(
+				"// @http-path   '/users/:id'", "// @http-path /users/:id",
+				"//@http-method GET", "// @http-method GET",
+				`// @http-error type=int "Result"  code=404`, "// @http-error Result code=404 type=int",
+			).Replace(source),
+		},
+		{
+			name: "порядок ключей с префиксами плагинов",
+			src:  source,
+			opts: []Option{WithOrder("log", "http-method", "http")},
+			want: strings.NewReplacer(
+				"\t// @http-path   '/users/:id'\n\t//@http-method GET\n\t// @log-skip\n",
+				"\t// @log-skip\n\t// @http-method GET\n\t// @http-path /users/:id\n",
+				`// @http-error type=int "Result"  code=404`, "// @http-error Result code=404 type=int",
+			).Replace(source),
+		},
+		{
+			name: "описание между аннотациями остается на месте",
+			src:  source,
+			opts: []Option{WithOrder("gomosaic", "http")},
+			want: strings.NewReplacer(
+				"// @http-path   '/users/:id'", "// @http-path /users/:id",
+				"//@http-method GET", "// @http-method GET",
+				"\t// @http-error type=int \"Result\"  code=404\n\t// Описание после аннотации\n\t// @gomosaic-ignore \"a=b\"\n",
+				"\t// @gomosaic-ignore \"a=b\"\n\t// Описание после аннотации\n\t// @http-error Result code=404 type=int\n",
+			).Replace(source),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Source("svc.go", []byte(tt.src), tt.opts...)
+			if err != nil {
+				t.Fatalf("Source() error = %v", err)
+			}
+			if got := string(result.Rewritten); got != tt.want {
+				t.Errorf("Source() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			again, err := Source("svc.go", result.Rewritten, tt.opts...)
+			if err != nil {
+				t.Fatalf("Source() error = %v", err)
+			}
+			if again.Changed() {
+				t.Errorf("повторное форматирование изменило файл:\n%s", again.Diff())
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package apisnapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// Change изменение API между снимками
+type Change struct {
+	API      string // Вид API
+	Route    string // Маршрут, например GET /user/:id
+	Breaking bool   // Изменение несовместимо с существующими клиентами
+	ID       string // Идентификатор сообщения, не зависит от языка
+	Message  string // Описание изменения
+}
+
+func (c *Change) String() string {
+	return c.Route + ": " + c.Message
+}
+
+// HasBreaking сообщает, есть ли среди изменений несовместимые
+func HasBreaking(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff сравнивает снимок API old с текущим снимком current.
+// Несовместимые изменения: удаление маршрута, новый обязательный параметр,
+// перенос параметра, изменение типа, удаление значения ответа.
+func Diff(old, current *Snapshot) (changes []*Change) {
+	for _, oldAPI := range old.APIs {
+		d := &differ{api: oldAPI.Name}
+		d.routes(oldAPI.Routes, findAPI(current, oldAPI.Name).Routes)
+		changes = append(changes, d.changes...)
+	}
+	for _, api := range current.APIs {
+		if findAPI(old, api.Name).Name == "" {
+			d := &differ{api: api.Name}
+			d.routes(nil, api.Routes)
+			changes = append(changes, d.changes...)
+		}
+	}
+	return changes
+}
+
+func findAPI(s *Snapshot, name string) *API {
+	for _, api := range s.APIs {
+		if api.Name == name {
+			return api
+		}
+	}
+	return &API{}
+}
+
+type differ struct {
+	api     string
+	route   string
+	changes []*Change
+}
+
+func (d *differ) add(breaking bool, id string, args ...any) {
+	d.changes = append(d.changes, &Change{API: d.api, Route: d.route, Breaking: breaking, ID: id, Message: i18n.T(id, args...)})
+}
+
+// routeKey ключ маршрута: имена параметров пути не влияют на API, важна только их позиция
+func routeKey(r *Route) string {
+	parts := strings.Split(r.Path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = ":"
+		}
+	}
+	return r.Method + " " + strings.Join(parts, "/")
+}
+
+func (d *differ) routes(old, current []*Route) {
+	currentByKey := make(map[string]*Route, len(current))
+	for _, r := range current {
+		currentByKey[routeKey(r)] = r
+	}
+	oldByKey := make(map[string]*Route, len(old))
+	for _, r := range old {
+		oldByKey[routeKey(r)] = r
+	}
+
+	for _, o := range old {
+		d.route = o.Method + " " + o.Path
+		c, ok := currentByKey[routeKey(o)]
+		if !ok {
+			d.add(true, "apisnapshot.route-removed", o.Operation)
+			continue
+		}
+		d.routeChanges(o, c)
+	}
+	for _, c := range current {
+		if _, ok := oldByKey[routeKey(c)]; !ok {
+			d.route = c.Method + " " + c.Path
+			d.add(false, "apisnapshot.route-added", c.Operation)
+		}
+	}
+}
+
+func (d *differ) routeChanges(old, current *Route) {
+	if old.RequestWrap != current.RequestWrap {
+		d.add(true, "apisnapshot.request-wrap", old.RequestWrap, current.RequestWrap)
+	}
+	if old.ResponseWrap != current.ResponseWrap {
+		d.add(true, "apisnapshot.response-wrap", old.ResponseWrap, current.ResponseWrap)
+	}
+	d.params(old.Params, current.Params)
+	d.results(old.Results, current.Results)
+}
+
+// paramKeys ключи параметров: параметры пути сравниваются по позиции, остальные по имени
+func paramKeys(params []*Param) (keys []string, byKey map[string]*Param) {
+	byKey = make(map[string]*Param, len(params))
+	pathIndex := 0
+	for _, p := range params {
+		key := p.Name
+		if p.In == InPath {
+			key = fmt.Sprintf(":%d", pathIndex)
+			pathIndex++
+		}
+		keys = append(keys, key)
+		byKey[key] = p
+	}
+	return keys, byKey
+}
+
+func (d *differ) params(old, current []*Param) {
+	oldKeys, oldByKey := paramKeys(old)
+	currentKeys, currentByKey := paramKeys(current)
+
+	for _, key := range oldKeys {
+		o := oldByKey[key]
+		c, ok := currentByKey[key]
+		switch {
+		case !ok:
+			d.add(false, "apisnapshot.param-removed", o.Name, o.In)
+		case o.In != c.In:
+			d.add(true, "apisnapshot.param-moved", o.Name, o.In, c.In)
+		default:
+			if !o.Required && c.Required {
+				d.add(true, "apisnapshot.param-required", c.Name, c.In)
+			}
+			if o.Required && !c.Required {
+				d.add(false, "apisnapshot.param-optional", c.Name, c.In)
+			}
+			d.schema(i18n.T("apisnapshot.param", c.Name), o.Schema, c.Schema, true)
+		}
+	}
+	for _, key := range currentKeys {
+		if _, ok := oldByKey[key]; ok {
+			continue
+		}
+		c := currentByKey[key]
+		if c.Required || c.In == InPath {
+			d.add(true, "apisnapshot.required-param-added", c.Name, c.In)
+		} else {
+			d.add(false, "apisnapshot.param-added", c.Name, c.In)
+		}
+	}
+}
+
+func (d *differ) results(old, current []*Param) {
+	oldKeys, oldByKey := paramKeys(old)
+	currentKeys, currentByKey := paramKeys(current)
+
+	for _, key := range oldKeys {
+		o := oldByKey[key]
+		c, ok := currentByKey[key]
+		switch {
+		case !ok:
+			d.add(true, "apisnapshot.result-removed", o.Name, o.In)
+		case o.In != c.In:
+			d.add(true, "apisnapshot.result-moved", o.Name, o.In, c.In)
+		default:
+			d.schema(i18n.T("apisnapshot.result", c.Name), o.Schema, c.Schema, false)
+		}
+	}
+	for _, key := range currentKeys {
+		if _, ok := oldByKey[key]; !ok {
+			c := currentByKey[key]
+			d.add(false, "apisnapshot.result-added", c.Name, c.In)
+		}
+	}
+}
+
+// schema сравнивает схемы значения. Удаление поля несовместимо для ответа,
+// а для запроса совместимо: сервер игнорирует лишние поля.
+func (d *differ) schema(path string, old, current *Schema, request bool) {
+	if old == nil || current == nil {
+		return
+	}
+	if old.Type != current.Type {
+		d.add(true, "apisnapshot.type-changed", path, describe(old), describe(current))
+		return
+	}
+	d.schema(path+"[]", old.Items, current.Items, request)
+
+	currentFields := make(map[string]*Field, len(current.Fields))
+	for _, f := range current.Fields {
+		currentFields[f.Name] = f
+	}
+	oldFields := make(map[string]bool, len(old.Fields))
+	for _, o := range old.Fields {
+		oldFields[o.Name] = true
+		c, ok := currentFields[o.Name]
+		if !ok {
+			d.add(!request, "apisnapshot.field-removed", path, o.Name)
+			continue
+		}
+		d.schema(path+"."+o.Name, o.Schema, c.Schema, request)
+	}
+	for _, c := range current.Fields {
+		if !oldFields[c.Name] {
+			d.add(false, "apisnapshot.field-added", path, c.Name)
+		}
+	}
+}
+
+func describe(s *Schema) string {
+	if s.GoType != "" {
+		return s.Type + " (" + s.GoType + ")"
+	}
+	return s.Type
+}
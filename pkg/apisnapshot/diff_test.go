@@ -0,0 +1,136 @@
+package apisnapshot
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+func baseRoute() *Route {
+	return &Route{
+		Method:    "GET",
+		Path:      "/user/:id",
+		Operation: "UserService.GetUser",
+		Params: []*Param{
+			{Name: "id", In: InPath, Required: true, Schema: &Schema{Type: TypeInteger}},
+			{Name: "fields", In: InQuery, Schema: &Schema{Type: TypeArray, Items: &Schema{Type: TypeString}}},
+			{Name: "filter", In: InBody, Schema: &Schema{Type: TypeObject, Fields: []*Field{
+				{Name: "name", Schema: &Schema{Type: TypeString}},
+			}}},
+		},
+		Results: []*Param{
+			{Name: "user", In: InBody, Schema: &Schema{Type: TypeObject, GoType: "svc.User", Fields: []*Field{
+				{Name: "id", Schema: &Schema{Type: TypeInteger}},
+				{Name: "name", Schema: &Schema{Type: TypeString}},
+			}}},
+		},
+	}
+}
+
+func snapshotOf(routes ...*Route) *Snapshot {
+	return &Snapshot{Version: version, APIs: []*API{{Name: "http", Routes: routes}}}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(r *Route) []*Route
+		want     []string
+		breaking bool
+	}{
+		{
+			name: "без изменений, переименование типа Go и параметра пути",
+			change: func(r *Route) []*Route {
+				r.Path = "/user/:userId"
+				r.Params[0].Name = "userId"
+				r.Results[0].Schema.GoType = "v2.User"
+				return []*Route{r}
+			},
+		},
+		{
+			name:     "удаление маршрута",
+			change:   func(r *Route) []*Route { return nil },
+			want:     []string{"GET /user/:id: маршрут удален (UserService.GetUser)"},
+			breaking: true,
+		},
+		{
+			name: "новый маршрут и необязательный параметр",
+			change: func(r *Route) []*Route {
+				r.Params = append(r.Params, &Param{Name: "page", In: InQuery, Schema: &Schema{Type: TypeInteger}})
+				return []*Route{r, {Method: "DELETE", Path: "/user/:id", Operation: "UserService.DeleteUser"}}
+			},
+			want: []string{
+				"GET /user/:id: добавлен параметр page (query)",
+				"DELETE /user/:id: маршрут добавлен (UserService.DeleteUser)",
+			},
+		},
+		{
+			name: "новый обязательный параметр и перенос параметра",
+			change: func(r *Route) []*Route {
+				r.Params[1].In = InHeader
+				r.Params = append(r.Params, &Param{Name: "X-Tenant", In: InHeader, Required: true, Schema: &Schema{Type: TypeString}})
+				return []*Route{r}
+			},
+			want: []string{
+				"GET /user/:id: параметр fields перенесен из query в header",
+				"GET /user/:id: добавлен обязательный параметр X-Tenant (header)",
+			},
+			breaking: true,
+		},
+		{
+			name:     "изменение типа элемента",
+			change:   func(r *Route) []*Route { r.Params[1].Schema.Items.Type = TypeInteger; return []*Route{r} },
+			want:     []string{"GET /user/:id: параметр fields[]: тип изменен с string на integer"},
+			breaking: true,
+		},
+		{
+			name: "удаление поля запроса совместимо, поля ответа нет",
+			change: func(r *Route) []*Route {
+				r.Params[2].Schema.Fields = nil
+				r.Results[0].Schema.Fields = r.Results[0].Schema.Fields[:1]
+				return []*Route{r}
+			},
+			want: []string{
+				"GET /user/:id: параметр filter: поле name удалено",
+				"GET /user/:id: значение ответа user: поле name удалено",
+			},
+			breaking: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(snapshotOf(baseRoute()), snapshotOf(tt.change(baseRoute())...))
+
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Diff() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Diff()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+			if HasBreaking(changes) != tt.breaking {
+				t.Errorf("HasBreaking() = %v, want %v", HasBreaking(changes), tt.breaking)
+			}
+		})
+	}
+
+	t.Run("удаление поля запроса не считается несовместимым", func(t *testing.T) {
+		r := baseRoute()
+		r.Params[2].Schema.Fields = nil
+		if changes := Diff(snapshotOf(baseRoute()), snapshotOf(r)); HasBreaking(changes) {
+			t.Errorf("HasBreaking() = true, want false: %v", changes)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package apisnapshot
+
+import (
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// Типы схемы значения
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeMap     = "map"
+	TypeAny     = "any"
+)
+
+// Schema схема значения в запросе или ответе. Сравниваются только Type, Items и Fields,
+// GoType выводится для справки: переименование типа Go не меняет API.
+type Schema struct {
+	Type   string   `json:"type"`             // Тип значения
+	GoType string   `json:"goType,omitempty"` // Именованный тип Go
+	Items  *Schema  `json:"items,omitempty"`  // Схема элементов array и значений map
+	Fields []*Field `json:"fields,omitempty"` // Поля object
+}
+
+// Field поле объекта
+type Field struct {
+	Name   string  `json:"name"`   // Имя поля в JSON
+	Schema *Schema `json:"schema"` // Схема значения поля
+}
+
+// SchemaOf возвращает схему значения типа Go в JSON. Поля структур называются
+// по тегу json, поля встроенных структур поднимаются на уровень объекта,
+// рекурсивные типы описываются один раз.
+func SchemaOf(t *gomosaic.TypeInfo) *Schema {
+	return schemaOf(t, make(map[string]bool))
+}
+
+func schemaOf(t *gomosaic.TypeInfo, seen map[string]bool) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{Type: TypeAny}
+	case t.IsPtr:
+		return schemaOf(t.ElemType, seen)
+	case t.IsSlice || t.IsArray:
+		if elem := t.ElemType; elem != nil && elem.IsBasic && elem.BasicKind == gomosaic.Uint8 {
+			// []byte кодируется в base64 строку
+			return &Schema{Type: TypeString}
+		}
+		return &Schema{Type: TypeArray, Items: schemaOf(t.ElemType, seen)}
+	case t.IsMap:
+		return &Schema{Type: TypeMap, Items: schemaOf(t.ElemType, seen)}
+	case t.IsNamed:
+		goType := t.Name
+		if t.Package != "" {
+			goType = t.Package + "." + t.Name
+		}
+		if goType == "time.Time" {
+			return &Schema{Type: TypeString, GoType: goType}
+		}
+		if seen[goType] {
+			return &Schema{Type: TypeObject, GoType: goType}
+		}
+		seen[goType] = true
+		defer delete(seen, goType)
+
+		s := schemaOf(t.ElemType, seen)
+		s.GoType = goType
+		return s
+	case t.IsBasic:
+		switch {
+		case t.BasicInfo&gomosaic.IsBoolean != 0:
+			return &Schema{Type: TypeBoolean}
+		case t.BasicInfo&gomosaic.IsInteger != 0:
+			return &Schema{Type: TypeInteger}
+		case t.BasicInfo&gomosaic.IsFloat != 0:
+			return &Schema{Type: TypeNumber}
+		case t.BasicInfo&gomosaic.IsString != 0:
+			return &Schema{Type: TypeString}
+		}
+	case t.Struct != nil:
+		return &Schema{Type: TypeObject, Fields: structFields(t, seen)}
+	}
+	return &Schema{Type: TypeAny}
+}
+
+// jsonField поле структуры с глубиной вложения через встроенные структуры
+type jsonField struct {
+	field  *Field
+	depth  int
+	tagged bool
+}
+
+// structFields возвращает поля объекта так же, как их кодирует encoding/json: поля встроенных
+// структур без имени в теге json поднимаются на уровень объекта, из одноименных полей
+// остается менее вложенное, а при равной вложенности единственное поле с тегом
+func structFields(t *gomosaic.TypeInfo, seen map[string]bool) []*Field {
+	var fields []jsonField
+	collectFields(t, 0, seen, &fields)
+
+	byName := make(map[string][]jsonField, len(fields))
+	for _, f := range fields {
+		byName[f.field.Name] = append(byName[f.field.Name], f)
+	}
+
+	var result []*Field
+	for _, f := range fields {
+		if dominantField(byName[f.field.Name]) == f.field {
+			result = append(result, f.field)
+		}
+	}
+	return result
+}
+
+func collectFields(t *gomosaic.TypeInfo, depth int, seen map[string]bool, fields *[]jsonField) {
+	for _, field := range t.Struct.Fields {
+		name, tagged := field.Name, false
+		if field.Tags != nil {
+			if tag, err := field.Tags.Get("json"); err == nil {
+				if tag.Name == "-" {
+					continue
+				}
+				if tag.Name != "" {
+					name, tagged = tag.Name, true
+				}
+			}
+		}
+
+		if field.IsEmbedded && !tagged {
+			if embedded, goType := embeddedStruct(field.Type); embedded != nil {
+				if !seen[goType] {
+					seen[goType] = true
+					collectFields(embedded, depth+1, seen, fields)
+					delete(seen, goType)
+				}
+				continue
+			}
+		}
+
+		*fields = append(*fields, jsonField{
+			field:  &Field{Name: name, Schema: schemaOf(field.Type, seen)},
+			depth:  depth,
+			tagged: tagged,
+		})
+	}
+}
+
+// embeddedStruct возвращает структуру встроенного поля (в том числе по указателю) и имя ее типа
+func embeddedStruct(t *gomosaic.TypeInfo) (*gomosaic.TypeInfo, string) {
+	if t != nil && t.IsPtr {
+		t = t.ElemType
+	}
+	if t == nil || !t.IsNamed || t.ElemType == nil || t.ElemType.Struct == nil {
+		return nil, ""
+	}
+	goType := t.Package + "." + t.Name
+	if goType == "time.Time" {
+		// time.Time кодируется строкой через MarshalJSON, а не полями
+		return nil, ""
+	}
+	return t.ElemType, goType
+}
+
+// dominantField выбирает поле среди одноименных, nil если выбрать нельзя
+func dominantField(fields []jsonField) *Field {
+	minDepth := fields[0].depth
+	for _, f := range fields {
+		minDepth = min(minDepth, f.depth)
+	}
+
+	var candidates []jsonField
+	for _, f := range fields {
+		if f.depth == minDepth {
+			candidates = append(candidates, f)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0].field
+	}
+
+	var tagged []jsonField
+	for _, f := range candidates {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0].field
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package apisnapshot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fatih/structtag"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+func field(t *testing.T, name, tag string, typ *gomosaic.TypeInfo, embedded bool) *gomosaic.VarInfo {
+	t.Helper()
+	tags, err := structtag.Parse(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &gomosaic.VarInfo{Name: name, Type: typ, Tags: tags, IsEmbedded: embedded}
+}
+
+func named(name string, fields ...*gomosaic.VarInfo) *gomosaic.TypeInfo {
+	return &gomosaic.TypeInfo{
+		Name:     name,
+		Package:  "svc",
+		IsNamed:  true,
+		ElemType: &gomosaic.TypeInfo{Name: "struct", Struct: &gomosaic.StructInfo{Fields: fields}},
+	}
+}
+
+func TestSchemaOfEmbedded(t *testing.T) {
+	intType := &gomosaic.TypeInfo{Name: "int", IsBasic: true, BasicKind: gomosaic.Int, BasicInfo: gomosaic.IsInteger}
+	stringType := &gomosaic.TypeInfo{Name: "string", IsBasic: true, BasicKind: gomosaic.String, BasicInfo: gomosaic.IsString}
+
+	base := named("Base",
+		field(t, "ID", `json:"id"`, intType, false),
+		field(t, "Name", `json:"name"`, intType, false),
+	)
+	audit := named("Audit", field(t, "By", `json:"by"`, stringType, false))
+	left := named("Left", field(t, "Code", "", intType, false))
+	right := named("Right", field(t, "Code", "", stringType, false))
+
+	user := named("User",
+		field(t, "Base", "", base, true),
+		field(t, "Name", `json:"name"`, stringType, false),
+		field(t, "Audit", `json:"audit"`, &gomosaic.TypeInfo{IsPtr: true, ElemType: audit}, true),
+		field(t, "Left", "", &gomosaic.TypeInfo{IsPtr: true, ElemType: left}, true),
+		field(t, "Right", "", right, true),
+	)
+
+	got := SchemaOf(user)
+	want := &Schema{Type: TypeObject, GoType: "svc.User", Fields: []*Field{
+		// Поля встроенной структуры поднимаются, поле верхнего уровня перекрывает Base.Name
+		{Name: "id", Schema: &Schema{Type: TypeInteger}},
+		{Name: "name", Schema: &Schema{Type: TypeString}},
+		// Встроенная структура с именем в теге json остается вложенным объектом
+		{Name: "audit", Schema: &Schema{Type: TypeObject, GoType: "svc.Audit", Fields: []*Field{
+			{Name: "by", Schema: &Schema{Type: TypeString}},
+		}}},
+		// Одноименные поля Left.Code и Right.Code на одной глубине без тегов отбрасываются
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaOf() = %s, want %s", schemaString(got), schemaString(want))
+	}
+}
+
+func schemaString(s *Schema) string {
+	out := s.Type
+	if s.GoType != "" {
+		out += "(" + s.GoType + ")"
+	}
+	if s.Items != nil {
+		out += "[" + schemaString(s.Items) + "]"
+	}
+	if len(s.Fields) > 0 {
+		out += "{"
+		for i, f := range s.Fields {
+			if i > 0 {
+				out += ", "
+			}
+			out += f.Name + ": " + schemaString(f.Schema)
+		}
+		out += "}"
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+// Package apisnapshot описывает API, которое генерируют плагины, в нормализованном виде
+// (маршруты, расположение и имена параметров, схемы тела запроса и ответа), сохраняет
+// описание в lock файл и сравнивает снимки, разделяя изменения на несовместимые и совместимые.
+package apisnapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// DefaultFilename имя lock файла снимка API по умолчанию
+const DefaultFilename = "gomosaic.api.json"
+
+// version версия формата снимка
+const version = 1
+
+// Расположение параметров и результатов
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InCookie = "cookie"
+	InBody   = "body"
+)
+
+// Snapshot снимок API всех плагинов
+type Snapshot struct {
+	Version int    `json:"version"`
+	APIs    []*API `json:"apis"`
+}
+
+// API описание API одного вида, например HTTP
+type API struct {
+	Name   string   `json:"name"`   // Вид API, например http
+	Routes []*Route `json:"routes"` // Маршруты, отсортированные по пути и методу
+}
+
+// Route маршрут API
+type Route struct {
+	Method       string   `json:"method"`                 // Метод HTTP
+	Path         string   `json:"path"`                   // Путь с именованными параметрами, например /user/:id
+	Operation    string   `json:"operation"`              // Метод интерфейса, например UserService.GetUser
+	RequestWrap  string   `json:"requestWrap,omitempty"`  // Путь, в который обернуто тело запроса
+	ResponseWrap string   `json:"responseWrap,omitempty"` // Путь, в который обернуто тело ответа
+	Params       []*Param `json:"params,omitempty"`       // Параметры запроса
+	Results      []*Param `json:"results,omitempty"`      // Значения ответа
+}
+
+// Param параметр запроса или значение ответа
+type Param struct {
+	Name     string  `json:"name"`               // Имя в запросе или ответе
+	In       string  `json:"in"`                 // Расположение: path, query, header, cookie, body
+	Required bool    `json:"required,omitempty"` // Обязательный параметр
+	Schema   *Schema `json:"schema"`             // Схема значения
+}
+
+// Describer необязательный интерфейс плагина, который описывает генерируемое API для снимка.
+// Плагины, читающие одни и те же аннотации, возвращают API с одним именем, в снимок попадает одно из них.
+type Describer interface {
+	DescribeAPI(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (*API, error)
+}
+
+// Take собирает снимок API плагинов менеджера pm, реализующих Describer
+func Take(ctx context.Context, pm *gomosaic.PluginManager, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (*Snapshot, error) {
+	snapshot := &Snapshot{Version: version}
+	seen := make(map[string]bool)
+
+	for _, plugin := range pm.Plugins() {
+		describer, ok := plugin.(Describer)
+		if !ok {
+			continue
+		}
+		api, err := describer.DescribeAPI(ctx, module, types)
+		if err != nil {
+			return nil, err
+		}
+		if api == nil || seen[api.Name] {
+			continue
+		}
+		seen[api.Name] = true
+
+		sort.Slice(api.Routes, func(i, j int) bool {
+			if api.Routes[i].Path != api.Routes[j].Path {
+				return api.Routes[i].Path < api.Routes[j].Path
+			}
+			return api.Routes[i].Method < api.Routes[j].Method
+		})
+		snapshot.APIs = append(snapshot.APIs, api)
+	}
+
+	sort.Slice(snapshot.APIs, func(i, j int) bool { return snapshot.APIs[i].Name < snapshot.APIs[j].Name })
+
+	return snapshot, nil
+}
+
+// Marshal возвращает снимок в формате lock файла
+func (s *Snapshot) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write сохраняет снимок в lock файл
+func (s *Snapshot) Write(filename string) error {
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644) //nolint: gosec,mnd
+}
+
+// Unmarshal разбирает содержимое lock файла
+func Unmarshal(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, i18n.Errorf("apisnapshot.parse", err)
+	}
+	if s.Version != version {
+		return nil, i18n.Errorf("apisnapshot.version", s.Version, version)
+	}
+	return &s, nil
+}
+
+// Load загружает снимок из lock файла
+func Load(filename string) (*Snapshot, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, i18n.Errorf("apisnapshot.read", err)
+	}
+	return Unmarshal(data)
+}
@@ -2,17 +2,153 @@ package cmd
 
 import (
 	"log"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	basecmd "github.com/go-mosaic/gomosaic/internal/cmd"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	_ "github.com/go-mosaic/gomosaic/pkg/plugins"
 )
 
-func Run(version string) {
-	log.SetFlags(0)
-	cmd := &cobra.Command{Use: "gomosaic"}
+// Commander необязательный интерфейс плагина, который добавляет команды gomosaic <plugin> <subcommand>
+type Commander = basecmd.Commander
+
+// PluginContext возможности gomosaic, общие для команд плагина и codegen:
+// загрузка модуля, разбор пакетов и вывод диагностик
+type PluginContext = basecmd.PluginContext
+
+// Option параметр сборки корневой команды gomosaic
+type Option func(*options)
+
+type options struct {
+	version       string
+	pluginManager *gomosaic.PluginManager
+	generators    []gomosaic.Generator
+	commands      []*cobra.Command
+}
+
+// WithVersion задает версию, которая выводится флагом --version
+// и записывается в заголовок сгенерированных файлов
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithPluginManager заменяет менеджер плагинов.
+// Встроенные плагины зарегистрированы только в gomosaic.DefaultPluginManager (пакет plugins).
+func WithPluginManager(pm *gomosaic.PluginManager) Option {
+	return func(o *options) {
+		o.pluginManager = pm
+	}
+}
+
+// WithGenerators регистрирует дополнительные плагины в копии менеджера плагинов,
+// сам менеджер (по умолчанию gomosaic.DefaultPluginManager) не изменяется
+func WithGenerators(generators ...gomosaic.Generator) Option {
+	return func(o *options) {
+		o.generators = append(o.generators, generators...)
+	}
+}
+
+// WithCommands добавляет дополнительные команды в корневую команду
+func WithCommands(commands ...*cobra.Command) Option {
+	return func(o *options) {
+		o.commands = append(o.commands, commands...)
+	}
+}
+
+// New создает корневую команду gomosaic.
+// Используется для сборки собственного дистрибутива gomosaic со своими плагинами и командами.
+// Язык сообщений выбирается флагом --lang или переменными окружения LC_ALL, LC_MESSAGES, LANG
+// до создания команд, чтобы справка выводилась на выбранном языке.
+func New(opts ...Option) *cobra.Command {
+	i18n.SetLang(i18n.Detect(os.Args[1:], os.Getenv))
+
+	o := &options{
+		version:       "dev",
+		pluginManager: gomosaic.DefaultPluginManager,
+	}
+	for _, optApply := range opts {
+		optApply(o)
+	}
+
+	if len(o.generators) > 0 {
+		o.pluginManager = o.pluginManager.Clone()
+		for _, generator := range o.generators {
+			o.pluginManager.RegisterPlugin(generator)
+		}
+	}
+
+	env := &basecmd.Env{
+		Version:       o.version,
+		PluginManager: o.pluginManager,
+	}
+
+	var lang string
+	cmd := &cobra.Command{
+		Use:     "gomosaic",
+		Version: o.version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if lang == "" {
+				return nil
+			}
+			l, ok := i18n.ParseLang(lang)
+			if !ok {
+				return i18n.Errorf("cli.unknown-lang", lang, langList())
+			}
+			i18n.SetLang(l)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVar(&lang, "lang", "", i18n.T("cli.usage.lang", langList()))
 	cmd.AddCommand(
-		basecmd.CodegenCmd(),
+		basecmd.CodegenCmd(env),
+		basecmd.GenerateCmd(env),
+		basecmd.CacheCmd(),
+		basecmd.WatchCmd(env),
+		basecmd.PluginsCmd(env),
+		basecmd.LspCmd(env),
+		basecmd.MigrateCmd(),
+		basecmd.FmtCmd(),
+		basecmd.ExplainCmd(env),
+		basecmd.ApiCmd(env),
+		basecmd.LintCmd(env),
 	)
-	cobra.CheckErr(cmd.Execute())
+	cmd.AddCommand(o.commands...)
+	addPluginCommands(cmd, env)
+	return cmd
+}
+
+// addPluginCommands добавляет команды плагинов, реализующих Commander.
+// Команды gomosaic и WithCommands имеют приоритет над командами плагина с тем же именем.
+func addPluginCommands(root *cobra.Command, env *basecmd.Env) {
+	for _, plugin := range env.PluginManager.Plugins() {
+		pluginCmd := basecmd.PluginCmd(env, plugin)
+		if pluginCmd == nil {
+			continue
+		}
+		if found, _, err := root.Find([]string{plugin.Name()}); err == nil && found != root {
+			log.Println(i18n.T("cli.plugin.conflict", plugin.Name()))
+			continue
+		}
+		root.AddCommand(pluginCmd)
+	}
+}
+
+func langList() string {
+	langs := i18n.Langs()
+	names := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		names = append(names, string(lang))
+	}
+	return strings.Join(names, ", ")
+}
+
+func Run(version string) {
+	log.SetFlags(0)
+	cobra.CheckErr(New(WithVersion(version)).Execute())
 }
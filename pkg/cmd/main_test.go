@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+type commanderPlugin struct {
+	name    string
+	pc      *PluginContext
+	args    []string
+	modfile string
+}
+
+func (p *commanderPlugin) Name() string { return p.name }
+
+func (p *commanderPlugin) Generate(context.Context, *gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo) (map[string]gomosaic.File, error) {
+	return nil, nil
+}
+
+func (p *commanderPlugin) Commands(pc *PluginContext) []*cobra.Command {
+	p.pc = pc
+	return []*cobra.Command{{
+		Use: "serve",
+		Run: func(cmd *cobra.Command, args []string) {
+			p.args = args
+			p.modfile = cmd.Flag("modfile").Value.String()
+		},
+	}}
+}
+
+func TestPluginCommands(t *testing.T) {
+	mock := &commanderPlugin{name: "mock-server"}
+	lint := &commanderPlugin{name: "lint"}
+
+	root := New(WithPluginManager(gomosaic.NewPluginManager()), WithGenerators(mock, lint))
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+
+	root.SetArgs([]string{"mock-server", "serve", "--modfile", "testdata/go.mod", "./api"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.args) != 1 || mock.args[0] != "./api" {
+		t.Errorf("args = %v, want [./api]", mock.args)
+	}
+	if mock.pc.Plugin() != mock || mock.modfile != "testdata/go.mod" {
+		t.Errorf("plugin = %v, modfile = %q", mock.pc.Plugin(), mock.modfile)
+	}
+
+	// Встроенная команда lint имеет приоритет над командами плагина с тем же именем
+	found, _, err := root.Find([]string{"lint", "serve"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name() != "lint" || found.Parent() != root || found.HasSubCommands() {
+		t.Errorf("lint = %s, want gomosaic lint", found.CommandPath())
+	}
+}
+
+func TestWithGeneratorsCopiesPluginManager(t *testing.T) {
+	mock := &commanderPlugin{name: "mock-server"}
+
+	New(WithGenerators(mock))
+	if _, err := gomosaic.DefaultPluginManager.GetPlugin("mock-server"); err == nil {
+		t.Error("WithGenerators зарегистрировал плагин в DefaultPluginManager")
+	}
+	if _, err := gomosaic.DefaultPluginManager.GetPlugin("http-client"); err != nil {
+		t.Errorf("встроенный плагин не зарегистрирован: %v", err)
+	}
+
+	root := New()
+	if found, _, err := root.Find([]string{"mock-server"}); err == nil && found != root {
+		t.Errorf("команда %s из другого вызова New", found.CommandPath())
+	}
+}
@@ -0,0 +1,165 @@
+// Package analyzer предоставляет анализатор go/analysis, который проверяет аннотации gomosaic
+// так же, как плагины при генерации: разбирает аннотации типов с @gomosaic, ищет неизвестные
+// ключи и запускает проверки загрузчиков аннотаций плагинов. Анализатор подключается
+// к go vet через gomosaic-vet (go vet -vettool=$(which gomosaic-vet) ./...) и к golangci-lint.
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	_ "github.com/go-mosaic/gomosaic/pkg/plugins"
+)
+
+const doc = `check gomosaic annotations
+
+Checks annotations of interfaces marked with @gomosaic: unknown keys with
+plugin prefixes, annotation values and the method signatures that plugins
+require. Fixes are suggested for misspelled keys and for a missing
+context.Context parameter or error result.`
+
+// Analyzer проверяет аннотации плагинов, зарегистрированных в gomosaic.DefaultPluginManager,
+// включая встроенные плагины
+var Analyzer = New(gomosaic.DefaultPluginManager)
+
+// New создает анализатор для плагинов менеджера pm.
+// Используется в собственных дистрибутивах gomosaic со своими плагинами.
+func New(pm *gomosaic.PluginManager) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "gomosaic",
+		Doc:  doc,
+		URL:  "https://github.com/go-mosaic/gomosaic",
+		Run: func(pass *analysis.Pass) (any, error) {
+			return nil, run(pass, pm)
+		},
+	}
+}
+
+// gomosaicMarker маркер типов для генерации
+var gomosaicMarker = []byte("@gomosaic")
+
+func run(pass *analysis.Pass, pm *gomosaic.PluginManager) error {
+	if !hasMarker(pass.Files) {
+		return nil
+	}
+
+	module := &gomosaic.ModuleInfo{Path: pass.Pkg.Path()}
+	if len(pass.Files) > 0 {
+		dir := filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name())
+		module.Dir = dir
+		if goModPath, ok := gomosaic.FindGoMod(dir); ok {
+			var err error
+			module, err = gomosaic.LoadModuleInfo(goModPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	types, err := gomosaic.ParseLoadedPackages([]*packages.Package{{
+		ID:        pass.Pkg.Path(),
+		Name:      pass.Pkg.Name(),
+		PkgPath:   pass.Pkg.Path(),
+		Fset:      pass.Fset,
+		Syntax:    pass.Files,
+		Types:     pass.Pkg,
+		TypesInfo: pass.TypesInfo,
+	}})
+	if err != nil {
+		pass.Report(analysis.Diagnostic{Pos: pass.Files[0].Package, Category: "analyzer.parse", Message: i18n.T("analyzer.parse", err)})
+		return nil
+	}
+
+	err = gomosaic.CheckAnnotations(context.Background(), pm, module, types)
+	for _, e := range gomosaic.Diagnostics(err) {
+		report(pass, e)
+	}
+
+	return nil
+}
+
+// hasMarker проверяет наличие маркера @gomosaic в комментариях пакета
+func hasMarker(files []*ast.File) bool {
+	for _, file := range files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if bytes.Contains([]byte(comment.Text), gomosaicMarker) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func report(pass *analysis.Pass, err error) {
+	position, message := gomosaic.DiagnosticPosition(err)
+
+	file, pos := findPos(pass, position)
+	if file == nil {
+		pass.Reportf(pass.Files[0].Package, "%s", message)
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{Pos: pos, Category: gomosaic.MessageID(err), Message: message}
+
+	var unknownErr *gomosaic.UnknownAnnotationError
+	switch {
+	case errors.As(err, &unknownErr):
+		diagnostic.Pos, diagnostic.End = annotationKeyRange(file, pos, unknownErr.Key)
+		if unknownErr.Suggestion != "" {
+			diagnostic.SuggestedFixes = renameKeyFix(diagnostic.Pos, diagnostic.End, unknownErr.Suggestion)
+		}
+	case errors.Is(err, gomosaic.ErrMissingContext):
+		diagnostic.SuggestedFixes = missingContextFix(file, pos)
+	case errors.Is(err, gomosaic.ErrMissingError):
+		diagnostic.SuggestedFixes = missingErrorFix(file, pos)
+	}
+
+	pass.Report(diagnostic)
+}
+
+// findPos переводит позицию ошибки в позицию файла пакета
+func findPos(pass *analysis.Pass, position token.Position) (*ast.File, token.Pos) {
+	if !position.IsValid() {
+		return nil, token.NoPos
+	}
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+		if tokFile == nil || tokFile.Name() != position.Filename || position.Line > tokFile.LineCount() {
+			continue
+		}
+		pos := tokFile.LineStart(position.Line)
+		if position.Column > 0 {
+			pos += token.Pos(position.Column - 1)
+		}
+		return file, pos
+	}
+	return nil, token.NoPos
+}
+
+// annotationKeyRange возвращает диапазон ключа @key в комментарии, который заканчивается в позиции end
+func annotationKeyRange(file *ast.File, end token.Pos, key string) (token.Pos, token.Pos) {
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if comment.End() != end {
+				continue
+			}
+			if i := strings.Index(comment.Text, "@"+key); i != -1 {
+				start := comment.Pos() + token.Pos(i+1)
+				return start, start + token.Pos(len(key))
+			}
+		}
+	}
+	return end, token.NoPos
+}
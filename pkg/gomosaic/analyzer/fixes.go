@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+func renameKeyFix(pos, end token.Pos, key string) []analysis.SuggestedFix {
+	if !end.IsValid() {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message:   i18n.T("analyzer.fix.rename", key),
+		TextEdits: []analysis.TextEdit{{Pos: pos, End: end, NewText: []byte(key)}},
+	}}
+}
+
+// findMethod возвращает сигнатуру метода интерфейса, имя которого находится в позиции pos
+func findMethod(file *ast.File, pos token.Pos) (fn *ast.FuncType) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		field, ok := node.(*ast.Field)
+		if fn != nil || !ok {
+			return fn == nil
+		}
+		if len(field.Names) > 0 && field.Names[0].Pos() == pos {
+			fn, _ = field.Type.(*ast.FuncType)
+		}
+		return fn == nil
+	})
+	return fn
+}
+
+// missingContextFix добавляет первым параметром метода ctx context.Context
+func missingContextFix(file *ast.File, pos token.Pos) []analysis.SuggestedFix {
+	fn := findMethod(file, pos)
+	if fn == nil || fn.Params == nil || !fn.Params.Opening.IsValid() {
+		return nil
+	}
+	for _, field := range fn.Params.List {
+		for _, name := range field.Names {
+			if name.Name == "ctx" {
+				return nil
+			}
+		}
+	}
+
+	alias, edits := importContext(file)
+	param := "ctx " + alias + ".Context"
+	if len(fn.Params.List) > 0 {
+		if len(fn.Params.List[0].Names) == 0 {
+			// Параметры без имен нельзя смешивать с именованными
+			param = alias + ".Context"
+		}
+		param += ", "
+	}
+	edits = append(edits, analysis.TextEdit{Pos: fn.Params.Opening + 1, End: fn.Params.Opening + 1, NewText: []byte(param)})
+
+	return []analysis.SuggestedFix{{
+		Message:   i18n.T("analyzer.fix.context"),
+		TextEdits: edits,
+	}}
+}
+
+// missingErrorFix добавляет последним результатом метода error
+func missingErrorFix(file *ast.File, pos token.Pos) []analysis.SuggestedFix {
+	fn := findMethod(file, pos)
+	if fn == nil || fn.Params == nil {
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	switch results := fn.Results; {
+	case results == nil || len(results.List) == 0:
+		at := fn.Params.Closing + 1
+		edits = append(edits, analysis.TextEdit{Pos: at, End: at, NewText: []byte(" error")})
+	case results.Opening.IsValid():
+		text := ", error"
+		if len(results.List[0].Names) > 0 {
+			text = ", err error"
+		}
+		edits = append(edits, analysis.TextEdit{Pos: results.Closing, End: results.Closing, NewText: []byte(text)})
+	default:
+		edits = append(edits,
+			analysis.TextEdit{Pos: results.Pos(), End: results.Pos(), NewText: []byte("(")},
+			analysis.TextEdit{Pos: results.End(), End: results.End(), NewText: []byte(", error)")},
+		)
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   i18n.T("analyzer.fix.error"),
+		TextEdits: edits,
+	}}
+}
+
+// importContext возвращает имя пакета context в файле и правку, добавляющую импорт, если его нет
+func importContext(file *ast.File) (string, []analysis.TextEdit) {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != "context" {
+			continue
+		}
+		if spec.Name != nil && spec.Name.Name != "_" && spec.Name.Name != "." {
+			return spec.Name.Name, nil
+		}
+		if spec.Name == nil {
+			return "context", nil
+		}
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			return "context", []analysis.TextEdit{{Pos: gen.Lparen + 1, End: gen.Lparen + 1, NewText: []byte("\n\t\"context\"")}}
+		}
+		return "context", []analysis.TextEdit{{Pos: gen.Pos(), End: gen.Pos(), NewText: []byte("import \"context\"\n")}}
+	}
+
+	return "context", []analysis.TextEdit{{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport \"context\"")}}
+}
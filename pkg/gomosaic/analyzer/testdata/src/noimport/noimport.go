@@ -0,0 +1,7 @@
+package noimport
+
+// @gomosaic
+type Service interface {
+	// @http-method GET
+	Ping() error // want `первым параметром обязателен тип context.Context`
+}
@@ -0,0 +1,18 @@
+package svc
+
+import "context"
+
+// @gomosaic
+type UserService interface {
+	// @http-metod GET // want `неизвестная аннотация @http-metod для method, возможно, имелась в виду @http-method`
+	// @http-path /users/:id
+	GetUser(ctx context.Context, id string) (string, error)
+	// @http-method POST
+	CreateUser(name string) error // want `первым параметром обязателен тип context.Context`
+	// @http-method DELETE
+	DeleteUser(ctx context.Context, id string) // want `последним параметром результата обязателен тип error`
+	// @http-method PUT
+	UpdateUser(ctx context.Context, id string) (name string) // want `последним параметром результата обязателен тип error`
+	// @http-method GET
+	Count(ctx context.Context) int // want `последним параметром результата обязателен тип error`
+}
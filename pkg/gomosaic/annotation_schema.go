@@ -0,0 +1,315 @@
+package gomosaic
+
+import (
+	"context"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// AnnotationTarget объект модели, к которому относится аннотация
+type AnnotationTarget string
+
+const (
+	TargetInterface AnnotationTarget = "interface" // Комментарий интерфейса
+	TargetMethod    AnnotationTarget = "method"    // Комментарий метода интерфейса
+	TargetParam     AnnotationTarget = "param"     // Комментарий параметра метода
+	TargetResult    AnnotationTarget = "result"    // Комментарий результата метода
+)
+
+// markerAnnotation аннотация, которой помечаются типы для генерации
+const markerAnnotation = "gomosaic"
+
+// NolintAnnotation аннотация, подавляющая нарушения правил стиля gomosaic lint:
+// без значений подавляются все правила, иначе перечисленные
+const NolintAnnotation = markerAnnotation + "-nolint"
+
+// AnnotationDoc описание аннотации для редакторов, справки и проверок
+type AnnotationDoc struct {
+	Key         string           // Ключ без @, например http-method
+	Target      AnnotationTarget // Объект, к которому относится аннотация
+	Type        string           // Тип значения: flag, string, int, []string или inline
+	Title       string           // Заголовок (@docgen-title)
+	Description string           // Описание (@docgen-descr)
+	ValueDescr  string           // Описание значения (@docgen-option-descr)
+	Values      []string         // Допустимые значения из тега valid:"in"
+	Params      []string         // Параметры вида name=value для inline аннотаций
+	Options     []string         // Флаги-опции для inline аннотаций
+	Examples    []string         // Примеры (@docgen-example)
+}
+
+// AnnotationSchema аннотации с общим префиксом, которые читает плагин
+type AnnotationSchema struct {
+	Prefix      string           // Префикс ключей, например http
+	Annotations []*AnnotationDoc // Описания аннотаций
+}
+
+// AnnotationDescriber необязательный интерфейс плагина, описывающего свои аннотации.
+// Описание используется языковым сервером, анализатором и поиском неизвестных ключей.
+type AnnotationDescriber interface {
+	AnnotationSchema() *AnnotationSchema
+}
+
+// Validator необязательный интерфейс плагина, который умеет проверять аннотации
+// без генерации кода. Validate возвращает те же диагностики, что и Generate.
+type Validator interface {
+	Validate(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo) error
+}
+
+// AnnotationSchemas возвращает описания аннотаций зарегистрированных плагинов,
+// объединенные по префиксу и отсортированные по ключу. Несколько плагинов
+// могут читать одни и те же аннотации (например, HTTP клиент и серверы).
+func (pm *PluginManager) AnnotationSchemas() []*AnnotationSchema {
+	byPrefix := make(map[string]*AnnotationSchema)
+	seen := make(map[string]bool)
+
+	for _, plugin := range pm.Plugins() {
+		describer, ok := plugin.(AnnotationDescriber)
+		if !ok {
+			continue
+		}
+		schema := describer.AnnotationSchema()
+		if schema == nil {
+			continue
+		}
+
+		merged, ok := byPrefix[schema.Prefix]
+		if !ok {
+			merged = &AnnotationSchema{Prefix: schema.Prefix}
+			byPrefix[schema.Prefix] = merged
+		}
+		for _, doc := range schema.Annotations {
+			id := string(doc.Target) + ":" + doc.Key
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			merged.Annotations = append(merged.Annotations, doc)
+		}
+	}
+
+	marker := &AnnotationSchema{
+		Prefix: markerAnnotation,
+		Annotations: []*AnnotationDoc{{
+			Key:         markerAnnotation,
+			Target:      TargetInterface,
+			Type:        "flag",
+			Title:       i18n.T("annotation.marker.title"),
+			Description: i18n.T("annotation.marker.descr"),
+		}},
+	}
+	for _, target := range []AnnotationTarget{TargetInterface, TargetMethod, TargetParam, TargetResult} {
+		marker.Annotations = append(marker.Annotations, &AnnotationDoc{
+			Key:         NolintAnnotation,
+			Target:      target,
+			Type:        "[]string",
+			Title:       i18n.T("annotation.nolint.title"),
+			Description: i18n.T("annotation.nolint.descr"),
+			ValueDescr:  i18n.T("annotation.nolint.value"),
+			Examples:    []string{"@" + NolintAnnotation + " http-no-body"},
+		})
+	}
+
+	schemas := make([]*AnnotationSchema, 0, len(byPrefix)+1)
+	schemas = append(schemas, marker)
+	for _, schema := range byPrefix {
+		sort.Slice(schema.Annotations, func(i, j int) bool {
+			if schema.Annotations[i].Key == schema.Annotations[j].Key {
+				return schema.Annotations[i].Target < schema.Annotations[j].Target
+			}
+			return schema.Annotations[i].Key < schema.Annotations[j].Key
+		})
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Prefix < schemas[j].Prefix })
+
+	return schemas
+}
+
+// LookupAnnotation возвращает описание аннотации с ключом key для объекта target
+func LookupAnnotation(schemas []*AnnotationSchema, key string, target AnnotationTarget) (*AnnotationDoc, bool) {
+	for _, schema := range schemas {
+		for _, doc := range schema.Annotations {
+			if doc.Key == key && doc.Target == target {
+				return doc, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// schemaForKey возвращает схему, префикс которой принадлежит ключу
+func schemaForKey(schemas []*AnnotationSchema, key string) (*AnnotationSchema, bool) {
+	for _, schema := range schemas {
+		if key == schema.Prefix || strings.HasPrefix(key, schema.Prefix+"-") {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+// CheckAnnotations проверяет аннотации модели: ищет неизвестные ключи с префиксами
+// известных плагинов и запускает проверки плагинов, реализующих Validator.
+func CheckAnnotations(ctx context.Context, pm *PluginManager, module *ModuleInfo, types []*NameTypeInfo) (errs error) {
+	schemas := pm.AnnotationSchemas()
+
+	check := func(annotations Annotations, target AnnotationTarget) {
+		for _, a := range annotations {
+			if _, ok := schemaForKey(schemas, a.Key); !ok {
+				continue
+			}
+			if _, ok := LookupAnnotation(schemas, a.Key, target); ok {
+				continue
+			}
+			errs = multierror.Append(errs, &UnknownAnnotationError{
+				Key:        a.Key,
+				Target:     target,
+				Suggestion: suggestAnnotation(schemas, a.Key, target),
+				Pos:        a.Position,
+			})
+		}
+	}
+
+	for _, nameTypeInfo := range types {
+		if nameTypeInfo.Type == nil || nameTypeInfo.Type.Interface == nil {
+			continue
+		}
+		check(nameTypeInfo.Annotations, TargetInterface)
+		for _, m := range nameTypeInfo.Type.Interface.Methods {
+			check(m.Annotations, TargetMethod)
+			for _, p := range m.Params {
+				check(ownAnnotations(p.Annotations, m.Annotations), TargetParam)
+			}
+			for _, r := range m.Results {
+				check(ownAnnotations(r.Annotations, m.Annotations), TargetResult)
+			}
+		}
+	}
+
+	for _, plugin := range pm.Plugins() {
+		validator, ok := plugin.(Validator)
+		if !ok {
+			continue
+		}
+		if err := validator.Validate(ctx, module, CloneNameTypesInfo(types)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return dedupErrors(errs)
+}
+
+// ownAnnotations возвращает аннотации параметра без аннотаций метода. Параметры,
+// объявленные на одной строке с методом, получают комментарий метода.
+func ownAnnotations(annotations, method Annotations) (result Annotations) {
+	for _, a := range annotations {
+		if !slices.ContainsFunc(method, func(m *AnnotationInfo) bool { return *m.Position == *a.Position }) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// UnknownAnnotationError аннотация с префиксом известного плагина, которую плагин не читает
+type UnknownAnnotationError struct {
+	Key        string
+	Target     AnnotationTarget
+	Suggestion string // Похожий известный ключ, если есть
+	Pos        *PosInfo
+}
+
+func (e *UnknownAnnotationError) Error() string {
+	text := i18n.T("annotation.unknown", e.Key, e.Target)
+	if e.Suggestion != "" {
+		text = i18n.T("annotation.unknown-suggestion", e.Key, e.Target, e.Suggestion)
+	}
+	if e.Pos == nil || !e.Pos.IsValid {
+		return text
+	}
+	return e.Pos.String() + ": " + text
+}
+
+// MessageID возвращает идентификатор сообщения каталога i18n
+func (e *UnknownAnnotationError) MessageID() string {
+	if e.Suggestion != "" {
+		return "annotation.unknown-suggestion"
+	}
+	return "annotation.unknown"
+}
+
+// Position возвращает позицию аннотации в исходном коде
+func (e *UnknownAnnotationError) Position() token.Position {
+	if e.Pos == nil || !e.Pos.IsValid {
+		return token.Position{}
+	}
+	return token.Position{Filename: e.Pos.Filename, Line: e.Pos.Line, Column: e.Pos.Column}
+}
+
+// suggestAnnotation возвращает известный ключ, ближайший к key по расстоянию Левенштейна
+func suggestAnnotation(schemas []*AnnotationSchema, key string, target AnnotationTarget) (suggestion string) {
+	schema, ok := schemaForKey(schemas, key)
+	if !ok {
+		return ""
+	}
+
+	best := len(key)/2 + 1 //nolint: mnd
+	for _, doc := range schema.Annotations {
+		if doc.Target != target {
+			continue
+		}
+		if strings.HasPrefix(doc.Key, key+"-") {
+			return doc.Key
+		}
+		if d := levenshtein(key, doc.Key); d < best {
+			best, suggestion = d, doc.Key
+		}
+	}
+	return suggestion
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// dedupErrors убирает повторяющиеся диагностики, например одну и ту же ошибку
+// загрузчика аннотаций, которую вернули несколько HTTP плагинов
+func dedupErrors(err error) error {
+	merr, ok := err.(*multierror.Error)
+	if !ok {
+		return err
+	}
+
+	var (
+		result error
+		seen   = make(map[string]bool)
+	)
+	for _, e := range merr.Errors {
+		if seen[e.Error()] {
+			continue
+		}
+		seen[e.Error()] = true
+		result = multierror.Append(result, e)
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package gomosaic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+)
+
+type annotatedPlugin struct {
+	name string
+	docs []*AnnotationDoc
+	err  error
+}
+
+func (p *annotatedPlugin) Name() string { return p.name }
+
+func (p *annotatedPlugin) Generate(context.Context, *ModuleInfo, []*NameTypeInfo) (map[string]File, error) {
+	return nil, nil
+}
+
+func (p *annotatedPlugin) AnnotationSchema() *AnnotationSchema {
+	return &AnnotationSchema{Prefix: "http", Annotations: p.docs}
+}
+
+func (p *annotatedPlugin) Validate(context.Context, *ModuleInfo, []*NameTypeInfo) error {
+	return p.err
+}
+
+func annotations(tb testing.TB, line int, values ...string) (result Annotations) {
+	tb.Helper()
+	for _, value := range values {
+		a, err := annotation.Parse(value)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		result = append(result, &AnnotationInfo{
+			Annotation: a,
+			Position:   &PosInfo{IsValid: true, Filename: "svc.go", Line: line, Column: len(value) + 4},
+		})
+		line++
+	}
+	return result
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	method := &AnnotationDoc{Key: "http-method", Target: TargetMethod}
+	path := &AnnotationDoc{Key: "http-path", Target: TargetMethod}
+	name := &AnnotationDoc{Key: "http-name", Target: TargetParam}
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(&annotatedPlugin{name: "server", docs: []*AnnotationDoc{method, path}})
+	pm.RegisterPlugin(&annotatedPlugin{name: "client", docs: []*AnnotationDoc{method, name}, err: Error("ошибка загрузчика", nil)})
+
+	methodAnnotations := annotations(t, 7, "@http-metod GET", "@http-path /users", "@http", "@log-skip")
+	types := []*NameTypeInfo{{
+		Name:        "UserService",
+		Annotations: annotations(t, 4, "@gomosaic", "@http-nme"),
+		Type: &TypeInfo{Interface: &InterfaceInfo{Methods: []*MethodInfo{{
+			Name:        "GetUser",
+			Annotations: methodAnnotations,
+			// Параметры на строке метода получают его комментарий
+			Params: []*VarInfo{{Name: "ctx", Annotations: methodAnnotations}},
+		}}}},
+	}}
+
+	err := CheckAnnotations(context.Background(), pm, &ModuleInfo{}, types)
+	if err == nil {
+		t.Fatal("CheckAnnotations() error = nil")
+	}
+
+	want := []string{
+		"svc.go:5:13: неизвестная аннотация @http-nme для interface",
+		"svc.go:7:19: неизвестная аннотация @http-metod для method, возможно, имелась в виду @http-method",
+		"svc.go:9:9: неизвестная аннотация @http для method, возможно, имелась в виду @http-method",
+		"ошибка загрузчика",
+	}
+	got := flattenErrorsText(err)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("CheckAnnotations() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if schemas := pm.AnnotationSchemas(); len(schemas) != 2 || schemas[0].Prefix != "gomosaic" || len(schemas[1].Annotations) != 3 {
+		t.Errorf("AnnotationSchemas() = %+v", schemas)
+	}
+}
+
+func flattenErrorsText(err error) (result []string) {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "* ") {
+			result = append(result, strings.TrimPrefix(line, "* "))
+		}
+	}
+	return result
+}
@@ -0,0 +1,247 @@
+package gomosaic
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const (
+	artifactsContextKey ContextKey = "artifacts"
+)
+
+// Dependent необязательный интерфейс плагина, который использует артефакты других плагинов.
+// Плагины из Dependencies должны быть указаны в той же задаче и запускаются раньше зависимого плагина.
+type Dependent interface {
+	// Dependencies Имена плагинов, от которых зависит плагин
+	Dependencies() []string
+}
+
+// Artifacts реестр артефактов одного запуска генерации.
+// Через него плагины обмениваются вычисленными данными (например, моделью HTTP API),
+// а общие вычисления выполняются один раз для всех плагинов задачи.
+// Артефакты используются плагинами только для чтения.
+type Artifacts struct {
+	mu      sync.Mutex
+	entries map[string]*artifact
+}
+
+type artifact struct {
+	ready chan struct{}
+	value any
+	err   error
+}
+
+// load вычисляет значение артефакта и отмечает его готовым, в том числе при панике в load
+func (a *artifact) load(key string, load func() (any, error)) {
+	defer close(a.ready)
+	defer func() {
+		if r := recover(); r != nil {
+			a.value, a.err = nil, i18n.Errorf("artifact.panic", key, r)
+		}
+	}()
+
+	a.value, a.err = load()
+}
+
+// NewArtifacts создает пустой реестр артефактов
+func NewArtifacts() *Artifacts {
+	return &Artifacts{entries: make(map[string]*artifact)}
+}
+
+// ContextWithArtifacts передает плагинам реестр артефактов запуска
+func ContextWithArtifacts(ctx context.Context, artifacts *Artifacts) context.Context {
+	return context.WithValue(ctx, artifactsContextKey, artifacts)
+}
+
+// ArtifactsFromContext возвращает реестр артефактов запуска.
+// Если реестр не передан, возвращается новый пустой реестр.
+func ArtifactsFromContext(ctx context.Context) *Artifacts {
+	if artifacts, ok := ctx.Value(artifactsContextKey).(*Artifacts); ok {
+		return artifacts
+	}
+	return NewArtifacts()
+}
+
+// ArtifactKey возвращает ключ артефакта плагина в виде plugin.name
+func ArtifactKey(plugin, name string) string {
+	return plugin + "." + name
+}
+
+// PublishArtifact публикует артефакт, заменяя ранее опубликованное значение
+func PublishArtifact[T any](ctx context.Context, key string, value T) {
+	a := &artifact{ready: make(chan struct{}), value: value}
+	close(a.ready)
+
+	artifacts := ArtifactsFromContext(ctx)
+	artifacts.mu.Lock()
+	artifacts.entries[key] = a
+	artifacts.mu.Unlock()
+}
+
+// LookupArtifact возвращает опубликованный артефакт.
+// Если артефакт еще вычисляется через LoadArtifact, вызов дожидается результата.
+func LookupArtifact[T any](ctx context.Context, key string) (value T, ok bool) {
+	artifacts := ArtifactsFromContext(ctx)
+	artifacts.mu.Lock()
+	a, ok := artifacts.entries[key]
+	artifacts.mu.Unlock()
+	if !ok {
+		return value, false
+	}
+
+	<-a.ready
+	if a.err != nil {
+		return value, false
+	}
+	value, ok = a.value.(T)
+	return value, ok
+}
+
+// LoadArtifact возвращает артефакт, вычисляя его функцией load один раз за запуск генерации.
+// Остальные плагины, запросившие тот же ключ, получают то же значение и ту же ошибку.
+// Паника в load возвращается как ошибка, чтобы плагины, ожидающие артефакт, не зависли.
+func LoadArtifact[T any](ctx context.Context, key string, load func() (T, error)) (value T, err error) {
+	artifacts := ArtifactsFromContext(ctx)
+	artifacts.mu.Lock()
+	a, ok := artifacts.entries[key]
+	if !ok {
+		a = &artifact{ready: make(chan struct{})}
+		artifacts.entries[key] = a
+	}
+	artifacts.mu.Unlock()
+
+	if !ok {
+		a.load(key, func() (any, error) { return load() })
+	}
+
+	<-a.ready
+	if a.value == nil {
+		return value, a.err
+	}
+	value, ok = a.value.(T)
+	if !ok {
+		return value, i18n.Errorf("artifact.type", key, a.value, value)
+	}
+	return value, a.err
+}
+
+func pluginDependencies(plugin Generator) []string {
+	if dependent, ok := plugin.(Dependent); ok {
+		return dependent.Dependencies()
+	}
+	return nil
+}
+
+// pluginGraph зависимости между плагинами одного запуска
+type pluginGraph struct {
+	plugins    []Generator
+	byName     map[string]Generator
+	dependents map[string]bool
+}
+
+func newPluginGraph(plugins []Generator) *pluginGraph {
+	g := &pluginGraph{
+		plugins:    plugins,
+		byName:     make(map[string]Generator, len(plugins)),
+		dependents: make(map[string]bool),
+	}
+	for _, plugin := range plugins {
+		g.byName[plugin.Name()] = plugin
+		for _, dep := range pluginDependencies(plugin) {
+			g.dependents[dep] = true
+		}
+	}
+	return g
+}
+
+// levels разбивает плагины на уровни так, что каждый плагин запускается после своих зависимостей.
+// Плагины одного уровня не зависят друг от друга и могут работать параллельно.
+func (g *pluginGraph) levels() (levels [][]Generator, err error) {
+	plugins := g.plugins
+	for _, plugin := range plugins {
+		for _, dep := range pluginDependencies(plugin) {
+			if _, ok := g.byName[dep]; !ok {
+				return nil, i18n.Errorf("plugin.missing-dependency", plugin.Name(), dep)
+			}
+		}
+	}
+
+	done := make(map[string]bool, len(plugins))
+	for len(done) < len(plugins) {
+		var level []Generator
+		for _, plugin := range plugins {
+			if done[plugin.Name()] {
+				continue
+			}
+			ready := true
+			for _, dep := range pluginDependencies(plugin) {
+				ready = ready && done[dep]
+			}
+			if ready {
+				level = append(level, plugin)
+			}
+		}
+
+		if len(level) == 0 {
+			var names []string
+			for _, plugin := range plugins {
+				if !done[plugin.Name()] {
+					names = append(names, plugin.Name())
+				}
+			}
+			return nil, i18n.Errorf("plugin.dependency-cycle", names)
+		}
+
+		for _, plugin := range level {
+			done[plugin.Name()] = true
+		}
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+// hasDependents сообщает, используют ли артефакты плагина другие плагины запуска
+func (g *pluginGraph) hasDependents(plugin Generator) bool {
+	return g.dependents[plugin.Name()]
+}
+
+// dependencies возвращает все прямые и косвенные зависимости плагина, отсортированные по имени
+func (g *pluginGraph) dependencies(plugin Generator) (deps []Generator) {
+	seen := make(map[string]bool)
+	var visit func(plugin Generator)
+	visit = func(plugin Generator) {
+		for _, name := range pluginDependencies(plugin) {
+			dep, ok := g.byName[name]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			deps = append(deps, dep)
+			visit(dep)
+		}
+	}
+	visit(plugin)
+
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Name() < deps[j].Name()
+	})
+	return deps
+}
+
+// cacheable сообщает, можно ли кешировать результат плагина: результаты плагинов,
+// которым нужна загруженная программа, и плагинов, зависящих от них, не кешируются
+func (g *pluginGraph) cacheable(plugin Generator) bool {
+	if _, ok := plugin.(ProgramGenerator); ok {
+		return false
+	}
+	for _, dep := range g.dependencies(plugin) {
+		if _, ok := dep.(ProgramGenerator); ok {
+			return false
+		}
+	}
+	return true
+}
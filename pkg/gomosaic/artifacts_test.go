@@ -0,0 +1,185 @@
+package gomosaic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// routesPlugin публикует артефакт и загружает общую модель
+type routesPlugin struct {
+	loads *atomic.Int32
+}
+
+func (p *routesPlugin) Name() string { return "routes" }
+
+func (p *routesPlugin) Generate(ctx context.Context, module *ModuleInfo, _ []*NameTypeInfo) (map[string]File, error) {
+	if _, err := loadSharedModel(ctx, p.loads); err != nil {
+		return nil, err
+	}
+	PublishArtifact(ctx, ArtifactKey(p.Name(), "table"), []string{"GET /users", "POST /users"})
+
+	f := NewGoFile(module, OutputDirFromContext(ctx))
+	f.Comment("routes")
+	return map[string]File{"routes.go": f}, nil
+}
+
+// docsPlugin зависит от routesPlugin и использует его артефакт
+type docsPlugin struct {
+	name  string
+	deps  []string
+	loads *atomic.Int32
+}
+
+func (p *docsPlugin) Name() string { return p.name }
+
+func (p *docsPlugin) Dependencies() []string { return p.deps }
+
+func (p *docsPlugin) Generate(ctx context.Context, module *ModuleInfo, _ []*NameTypeInfo) (map[string]File, error) {
+	if _, err := loadSharedModel(ctx, p.loads); err != nil {
+		return nil, err
+	}
+	routes, _ := LookupArtifact[[]string](ctx, ArtifactKey("routes", "table"))
+
+	f := NewGoFile(module, OutputDirFromContext(ctx))
+	f.Comment(p.name + ": " + strings.Join(routes, ", "))
+	return map[string]File{p.name + ".go": f}, nil
+}
+
+func loadSharedModel(ctx context.Context, loads *atomic.Int32) ([]string, error) {
+	return LoadArtifact(ctx, "model", func() ([]string, error) {
+		loads.Add(1)
+		return []string{"UserService"}, nil
+	})
+}
+
+func TestGenerateAllDependencies(t *testing.T) {
+	loads := new(atomic.Int32)
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(&routesPlugin{loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "docs", deps: []string{"routes"}, loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "index", deps: []string{"docs"}, loads: loads})
+
+	outputDir := t.TempDir()
+	ctx := ContextWithOutputDir(context.Background(), outputDir)
+	module := &ModuleInfo{Path: "example.com", Dir: outputDir}
+	cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir))
+
+	results, err := cg.GenerateAll(ctx, module, nil, []string{"index", "docs", "routes"})
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Plugin)
+	}
+	if got, want := strings.Join(names, ","), "index,docs,routes"; got != want {
+		t.Errorf("порядок результатов = %s, want %s", got, want)
+	}
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("общая модель загружена %d раз, want 1", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "docs.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// docs: GET /users, POST /users\n"; !strings.Contains(string(data), want) {
+		t.Errorf("docs.go = %q, want %q", data, want)
+	}
+}
+
+func TestGenerateAllDependencyErrors(t *testing.T) {
+	loads := new(atomic.Int32)
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(&routesPlugin{loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "docs", deps: []string{"routes"}, loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "a", deps: []string{"b"}, loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "b", deps: []string{"a"}, loads: loads})
+
+	tests := []struct {
+		name    string
+		plugins []string
+		wantErr string
+	}{
+		{
+			name:    "зависимость не указана в задаче",
+			plugins: []string{"docs"},
+			wantErr: "плагин docs зависит от плагина routes, который не указан в задаче",
+		},
+		{
+			name:    "циклическая зависимость",
+			plugins: []string{"routes", "a", "b"},
+			wantErr: "циклическая зависимость между плагинами: [a b]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			ctx := ContextWithOutputDir(context.Background(), outputDir)
+			cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir))
+
+			_, err := cg.GenerateAll(ctx, &ModuleInfo{Path: "example.com"}, nil, tt.plugins)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("GenerateAll() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateAllDependencyCache(t *testing.T) {
+	loads := new(atomic.Int32)
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(&routesPlugin{loads: loads})
+	pm.RegisterPlugin(&docsPlugin{name: "docs", deps: []string{"routes"}, loads: loads})
+
+	outputDir := t.TempDir()
+	ctx := ContextWithOutputDir(context.Background(), outputDir)
+	module := &ModuleInfo{Path: "example.com", Dir: outputDir}
+	cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir), WithCache(NewCache(t.TempDir())))
+
+	for range 2 {
+		if _, err := cg.GenerateAll(ctx, module, nil, []string{"routes", "docs"}); err != nil {
+			t.Fatalf("GenerateAll() error = %v", err)
+		}
+	}
+
+	results, err := cg.GenerateAll(ctx, module, nil, []string{"routes", "docs"})
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	if results[0].Cached {
+		t.Error("плагин с зависимыми плагинами не должен пропускаться по кешу")
+	}
+	if !results[1].Cached {
+		t.Error("зависимый плагин должен пропускаться по кешу")
+	}
+}
+
+func TestLoadArtifactPanic(t *testing.T) {
+	ctx := ContextWithArtifacts(context.Background(), NewArtifacts())
+
+	_, err := LoadArtifact(ctx, "model", func() ([]string, error) {
+		panic("сбой загрузчика")
+	})
+	if err == nil || !strings.Contains(err.Error(), "сбой загрузчика") {
+		t.Fatalf("LoadArtifact() error = %v, want ошибку с текстом паники", err)
+	}
+
+	// Повторный запрос не зависает и получает ту же ошибку
+	if _, again := LoadArtifact(ctx, "model", func() ([]string, error) { return nil, nil }); again != err {
+		t.Errorf("LoadArtifact() повторно = %v, want %v", again, err)
+	}
+	if _, ok := LookupArtifact[[]string](ctx, "model"); ok {
+		t.Error("LookupArtifact() = ok для артефакта с ошибкой")
+	}
+}
@@ -0,0 +1,235 @@
+package gomosaic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const cacheDirName = "gomosaic"
+
+// Versioner может быть реализован плагином, чтобы изменение версии плагина сбрасывало кеш
+type Versioner interface {
+	// Version Версия плагина
+	Version() string
+}
+
+// CacheInputs может быть реализован плагином, результат которого зависит от файлов вне модели
+// (например, от шаблонов), чтобы изменение содержимого этих файлов сбрасывало кеш
+type CacheInputs interface {
+	// CacheInputs Пути к файлам, от которых зависит результат генерации
+	CacheInputs(ctx context.Context, module *ModuleInfo) ([]string, error)
+}
+
+// Cache контентно-адресуемый кеш результатов генерации.
+// Ключ вычисляется из модели, которую получает плагин, имени и версии плагина и его опций,
+// а значение содержит список сгенерированных файлов и хеши их содержимого.
+type Cache struct {
+	dir string
+}
+
+// NewCache создает кеш в указанной директории
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// DefaultCacheDir возвращает директорию кеша по умолчанию ($XDG_CACHE_HOME/gomosaic)
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cacheDirName), nil
+}
+
+// Dir возвращает директорию кеша
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// CacheKeyInfo данные, от которых зависит результат генерации плагина
+type CacheKeyInfo struct {
+	Plugin        string
+	PluginVersion string
+	Version       string
+	Module        *ModuleInfo
+	OutputDir     string
+	Options       map[string]string
+	Types         []*NameTypeInfo
+	Dependencies  []*CacheKeyInfo   // Плагины, артефакты которых использует плагин (только имя, версия и параметры)
+	Inputs        map[string]string // Хеши содержимого файлов из CacheInputs
+	Initialisms   []string          // Таблица аббревиатур, от которой зависят имена идентификаторов
+}
+
+// Key вычисляет ключ кеша
+func (c *Cache) Key(info *CacheKeyInfo) string {
+	h := sha256.New()
+	hashValue(h, reflect.ValueOf(info))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type cacheEntry struct {
+	Plugin   string              `json:"plugin"`
+	Files    []cacheEntryFile    `json:"files"`
+	Warnings []cacheEntryWarning `json:"warnings,omitempty"`
+}
+
+type cacheEntryFile struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+// cacheEntryWarning предупреждение плагина, которое повторяется при попадании в кеш
+type cacheEntryWarning struct {
+	ID       string         `json:"id,omitempty"`
+	Message  string         `json:"message"`
+	Position token.Position `json:"position"`
+}
+
+func (c *Cache) entryPath(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Lookup возвращает сгенерированные ранее файлы, если они существуют и не были изменены,
+// и предупреждения, которые плагин выдал при генерации
+func (c *Cache) Lookup(key string) (outputFiles []string, warnings error, ok bool) {
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, nil, false
+	}
+
+	for _, file := range entry.Files {
+		fileHash, err := hashFile(file.Path)
+		if err != nil || fileHash != file.Hash {
+			return nil, nil, false
+		}
+		outputFiles = append(outputFiles, file.Path)
+	}
+
+	for _, w := range entry.Warnings {
+		warnings = multierror.Append(warnings, &WarningError{id: w.ID, text: w.Message, pos: w.Position})
+	}
+
+	return outputFiles, warnings, true
+}
+
+// Store сохраняет список сгенерированных файлов и предупреждения плагина для ключа
+func (c *Cache) Store(key, plugin string, outputFiles []string, warnings error) error {
+	entry := cacheEntry{Plugin: plugin}
+
+	for _, w := range Diagnostics(warnings) {
+		position, message := DiagnosticPosition(w)
+		entry.Warnings = append(entry.Warnings, cacheEntryWarning{ID: MessageID(w), Message: message, Position: position})
+	}
+
+	for _, path := range outputFiles {
+		fileHash, err := hashFile(path)
+		if err != nil {
+			return i18n.Errorf("cache.hash", err)
+		}
+		entry.Files = append(entry.Files, cacheEntryFile{Path: path, Hash: fileHash})
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.entryPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint: mnd
+		return i18n.Errorf("cache.mkdir", err)
+	}
+
+	return os.WriteFile(path, data, 0o600) //nolint: mnd
+}
+
+// Clean удаляет все записи кеша
+func (c *Cache) Clean() error {
+	if err := os.RemoveAll(c.dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return i18n.Errorf("cache.clean", err)
+	}
+	return nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashValue записывает в хеш детерминированное представление значения,
+// включая неэкспортируемые поля (например, теги структур) и отсортированные ключи map.
+func hashValue(h hash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	default:
+		_, _ = io.WriteString(h, v.Kind().String())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			_, _ = io.WriteString(h, "nil;")
+			return
+		}
+		hashValue(h, v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		_, _ = io.WriteString(h, t.String()+"{")
+		for i := range v.NumField() {
+			_, _ = io.WriteString(h, t.Field(i).Name+":")
+			hashValue(h, v.Field(i))
+		}
+		_, _ = io.WriteString(h, "}")
+	case reflect.Slice, reflect.Array:
+		_, _ = io.WriteString(h, "["+strconv.Itoa(v.Len())+"]")
+		for i := range v.Len() {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		_, _ = io.WriteString(h, "map["+strconv.Itoa(len(keys))+"]")
+		for _, k := range keys {
+			hashValue(h, k)
+			hashValue(h, v.MapIndex(k))
+		}
+	case reflect.String:
+		_, _ = io.WriteString(h, strconv.Quote(v.String())+";")
+	case reflect.Bool:
+		_, _ = io.WriteString(h, strconv.FormatBool(v.Bool())+";")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, _ = io.WriteString(h, strconv.FormatInt(v.Int(), 10)+";")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, _ = io.WriteString(h, strconv.FormatUint(v.Uint(), 10)+";")
+	case reflect.Float32, reflect.Float64:
+		_, _ = io.WriteString(h, strconv.FormatFloat(v.Float(), 'g', -1, 64)+";")
+	}
+}
@@ -0,0 +1,199 @@
+package gomosaic
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/structtag"
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestCacheKey(t *testing.T) {
+	c := NewCache(t.TempDir())
+
+	newInfo := func(tag string) *CacheKeyInfo {
+		tags, err := structtag.Parse(tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &CacheKeyInfo{
+			Plugin:  "http-client",
+			Version: "dev",
+			Module:  &ModuleInfo{Path: "example.com/svc"},
+			Options: map[string]string{"b": "2", "a": "1"},
+			Types: []*NameTypeInfo{
+				{
+					Name: "User",
+					Type: &TypeInfo{Struct: &StructInfo{Fields: []*VarInfo{{Name: "ID", Tags: tags}}}},
+				},
+			},
+		}
+	}
+
+	if c.Key(newInfo(`json:"id"`)) != c.Key(newInfo(`json:"id"`)) {
+		t.Error("Key() is not deterministic")
+	}
+
+	if c.Key(newInfo(`json:"id"`)) == c.Key(newInfo(`json:"userId"`)) {
+		t.Error("Key() does not depend on struct tags")
+	}
+
+	other := newInfo(`json:"id"`)
+	other.PluginVersion = "v2"
+	if c.Key(newInfo(`json:"id"`)) == c.Key(other) {
+		t.Error("Key() does not depend on plugin version")
+	}
+}
+
+func TestCacheLookup(t *testing.T) {
+	c := NewCache(t.TempDir())
+	output := filepath.Join(t.TempDir(), "client_gen.go")
+
+	if err := os.WriteFile(output, []byte("package out\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key := c.Key(&CacheKeyInfo{Plugin: "http-client"})
+
+	if _, _, ok := c.Lookup(key); ok {
+		t.Fatal("Lookup() found entry in empty cache")
+	}
+
+	warning := Warnf("implstub.missing-constructor", token.Position{Filename: "svc_impl.go", Line: 1, Column: 1}, "NewSvcImpl")
+	if err := c.Store(key, "http-client", []string{output}, multierror.Append(nil, warning)); err != nil {
+		t.Fatal(err)
+	}
+
+	files, warnings, ok := c.Lookup(key)
+	if !ok || len(files) != 1 || files[0] != output {
+		t.Fatalf("Lookup() = %v, %v, want [%s]", files, ok, output)
+	}
+	if diags := Diagnostics(warnings); len(diags) != 1 || !IsErrWarning(diags[0]) ||
+		diags[0].Error() != warning.Error() || MessageID(diags[0]) != "implstub.missing-constructor" {
+		t.Errorf("Lookup() warnings = %v, want %v", warnings, warning)
+	}
+
+	if err := os.WriteFile(output, []byte("package out\n\n// edited\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok := c.Lookup(key); ok {
+		t.Error("Lookup() found entry for modified output file")
+	}
+
+	if err := c.Clean(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(c.Dir()); !os.IsNotExist(err) {
+		t.Errorf("Clean() did not remove cache dir: %v", err)
+	}
+}
+
+// inputsPlugin копирует содержимое входного файла в сгенерированный файл
+type inputsPlugin struct {
+	input string
+}
+
+func (p *inputsPlugin) Name() string { return "inputs" }
+
+func (p *inputsPlugin) CacheInputs(context.Context, *ModuleInfo) ([]string, error) {
+	return []string{p.input}, nil
+}
+
+func (p *inputsPlugin) Generate(context.Context, *ModuleInfo, []*NameTypeInfo) (map[string]File, error) {
+	data, err := os.ReadFile(p.input)
+	if err != nil {
+		return nil, err
+	}
+	f := NewTxtFile()
+	f.WriteBytes(data)
+	return map[string]File{"out.txt": f}, nil
+}
+
+func TestGenerateAllCacheInputs(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.tmpl")
+	if err := os.WriteFile(input, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(&inputsPlugin{input: input})
+
+	outputDir := t.TempDir()
+	ctx := ContextWithOutputDir(context.Background(), outputDir)
+	cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir), WithCache(NewCache(t.TempDir())))
+
+	generate := func() *GenerateResult {
+		t.Helper()
+		results, err := cg.GenerateAll(ctx, &ModuleInfo{}, nil, []string{"inputs"})
+		if err != nil {
+			t.Fatalf("GenerateAll() error = %v", err)
+		}
+		return results[0]
+	}
+
+	generate()
+	if !generate().Cached {
+		t.Error("повторная генерация без изменений должна пропускаться по кешу")
+	}
+
+	if err := os.WriteFile(input, []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if generate().Cached {
+		t.Error("изменение входного файла должно сбрасывать кеш")
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "v2" {
+		t.Errorf("out.txt = %q, want v2", data)
+	}
+}
+
+// warningPlugin генерирует файл и предупреждение о неизвестном параметре аннотации
+type warningPlugin struct {
+	runs int
+}
+
+func (p *warningPlugin) Name() string { return "warning" }
+
+func (p *warningPlugin) Generate(context.Context, *ModuleInfo, []*NameTypeInfo) (map[string]File, error) {
+	p.runs++
+	f := NewTxtFile()
+	f.WriteBytes([]byte("ok"))
+	return map[string]File{"out.txt": f}, Warn("устаревший параметр", token.Position{Filename: "svc.go", Line: 3, Column: 1})
+}
+
+func TestGenerateAllCacheWarnings(t *testing.T) {
+	plugin := new(warningPlugin)
+	pm := NewPluginManager()
+	pm.RegisterPlugin(plugin)
+
+	outputDir := t.TempDir()
+	ctx := ContextWithOutputDir(context.Background(), outputDir)
+	cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir), WithCache(NewCache(t.TempDir())))
+
+	for i := range 2 {
+		results, err := cg.GenerateAll(ctx, &ModuleInfo{}, nil, []string{"warning"})
+		if hasFailed(err) {
+			t.Fatalf("GenerateAll() error = %v", err)
+		}
+		if cached := i > 0; results[0].Cached != cached {
+			t.Errorf("запуск %d: Cached = %v, want %v", i+1, results[0].Cached, cached)
+		}
+		if diags := Diagnostics(err); len(diags) != 1 || diags[0].Error() != "svc.go:3:1: устаревший параметр" {
+			t.Errorf("запуск %d: предупреждения = %v", i+1, err)
+		}
+	}
+
+	if plugin.runs != 1 {
+		t.Errorf("плагин запущен %d раз, want 1", plugin.runs)
+	}
+}
@@ -0,0 +1,174 @@
+package gomosaic
+
+import (
+	"maps"
+
+	"github.com/fatih/structtag"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+)
+
+// CloneNameTypesInfo создает глубокую копию модели.
+// Каждый плагин получает собственную копию, поэтому изменения модели одним плагином
+// не видны другим плагинам, работающим параллельно.
+func CloneNameTypesInfo(types []*NameTypeInfo) []*NameTypeInfo {
+	if types == nil {
+		return nil
+	}
+	result := make([]*NameTypeInfo, len(types))
+	for i, t := range types {
+		result[i] = t.Clone()
+	}
+	return result
+}
+
+// Clone возвращает глубокую копию NameTypeInfo
+func (t *NameTypeInfo) Clone() *NameTypeInfo {
+	if t == nil {
+		return nil
+	}
+	return &NameTypeInfo{
+		Package:     t.Package.Clone(),
+		Name:        t.Name,
+		Title:       t.Title,
+		Doc:         t.Doc,
+		Pos:         t.Pos.Clone(),
+		Type:        t.Type.Clone(),
+		Annotations: t.Annotations.Clone(),
+		Methods:     cloneMethods(t.Methods),
+	}
+}
+
+// Clone возвращает копию PackageInfo
+func (p *PackageInfo) Clone() *PackageInfo {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// Clone возвращает копию PosInfo
+func (pos *PosInfo) Clone() *PosInfo {
+	if pos == nil {
+		return nil
+	}
+	c := *pos
+	return &c
+}
+
+// Clone возвращает глубокую копию TypeInfo
+func (t *TypeInfo) Clone() *TypeInfo {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.KeyType = t.KeyType.Clone()
+	c.ElemType = t.ElemType.Clone()
+	c.TypeParams = cloneTypes(t.TypeParams)
+	c.UnionTerms = cloneTypes(t.UnionTerms)
+	if t.Struct != nil {
+		c.Struct = &StructInfo{Fields: cloneVars(t.Struct.Fields)}
+	}
+	if t.Interface != nil {
+		c.Interface = &InterfaceInfo{Methods: cloneMethods(t.Interface.Methods)}
+	}
+	if t.Signature != nil {
+		c.Signature = &SignatureInfo{
+			Params:     cloneVars(t.Signature.Params),
+			Results:    cloneVars(t.Signature.Results),
+			TypeParams: cloneTypes(t.Signature.TypeParams),
+		}
+	}
+	return &c
+}
+
+// Clone возвращает глубокую копию MethodInfo
+func (m *MethodInfo) Clone() *MethodInfo {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Params = cloneVars(m.Params)
+	c.Results = cloneVars(m.Results)
+	c.Pos = m.Pos.Clone()
+	c.Annotations = m.Annotations.Clone()
+	if m.ReturnValues != nil {
+		c.ReturnValues = make([]*TypeAndValueInfo, len(m.ReturnValues))
+		for i, v := range m.ReturnValues {
+			vc := *v
+			c.ReturnValues[i] = &vc
+		}
+	}
+	return &c
+}
+
+// Clone возвращает глубокую копию VarInfo
+func (v *VarInfo) Clone() *VarInfo {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	c.Package = v.Package.Clone()
+	c.Type = v.Type.Clone()
+	c.Pos = v.Pos.Clone()
+	c.Annotations = v.Annotations.Clone()
+	if v.Tags != nil {
+		if tags, err := structtag.Parse(v.Tags.String()); err == nil {
+			c.Tags = tags
+		}
+	}
+	return &c
+}
+
+// Clone возвращает глубокую копию аннотаций
+func (ts *Annotations) Clone() Annotations {
+	if *ts == nil {
+		return nil
+	}
+	result := make(Annotations, len(*ts))
+	for i, a := range *ts {
+		result[i] = &AnnotationInfo{
+			Annotation: &annotation.Annotation{
+				Key:     a.Key,
+				Options: append([]string(nil), a.Options...),
+				Params:  maps.Clone(a.Params),
+			},
+			Position: a.Position.Clone(),
+		}
+	}
+	return result
+}
+
+func cloneTypes(types []*TypeInfo) []*TypeInfo {
+	if types == nil {
+		return nil
+	}
+	result := make([]*TypeInfo, len(types))
+	for i, t := range types {
+		result[i] = t.Clone()
+	}
+	return result
+}
+
+func cloneVars(vars []*VarInfo) []*VarInfo {
+	if vars == nil {
+		return nil
+	}
+	result := make([]*VarInfo, len(vars))
+	for i, v := range vars {
+		result[i] = v.Clone()
+	}
+	return result
+}
+
+func cloneMethods(methods []*MethodInfo) []*MethodInfo {
+	if methods == nil {
+		return nil
+	}
+	result := make([]*MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = m.Clone()
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package gomosaic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// DefaultConfigFilename имя файла конфигурации по умолчанию
+const DefaultConfigFilename = "gomosaic.json"
+
+// Config конфигурация запуска генерации
+type Config struct {
+	ModFile     string       `json:"modfile,omitempty"`     // Путь к go.mod (по умолчанию go.mod рядом с файлом конфигурации)
+	Initialisms []string     `json:"initialisms,omitempty"` // Дополнительные аббревиатуры для имен идентификаторов (например, GRPC)
+	Jobs        []*JobConfig `json:"jobs"`                  // Задачи генерации
+	// Уровни правил gomosaic lint: идентификатор правила -> error, warning или off
+	Lint map[string]string `json:"lint,omitempty"`
+}
+
+// JobConfig задача генерации: набор плагинов, входные пакеты и директория вывода
+type JobConfig struct {
+	Plugins  []string                     `json:"plugins"`           // Имена плагинов
+	Packages []string                     `json:"packages"`          // Пакеты, в которых ищутся типы для генерации
+	Output   string                       `json:"output"`            // Директория вывода
+	Options  map[string]map[string]string `json:"options,omitempty"` // Параметры плагинов: плагин -> параметр -> значение
+}
+
+// LoadConfig загружает конфигурацию из JSON файла.
+// Относительные пути в конфигурации разрешаются относительно директории файла.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("config.read", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, i18n.Errorf("config.parse", path, err)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ModFile == "" {
+		cfg.ModFile = "go.mod"
+	}
+	cfg.ModFile = resolvePath(dir, cfg.ModFile)
+
+	for _, word := range cfg.Initialisms {
+		if !isInitialism(word) {
+			return nil, i18n.Errorf("config.initialism", path, word)
+		}
+	}
+
+	for i, job := range cfg.Jobs {
+		if len(job.Plugins) == 0 {
+			return nil, i18n.Errorf("config.job-no-plugins", path, i)
+		}
+		if len(job.Packages) == 0 {
+			return nil, i18n.Errorf("config.job-no-packages", path, i)
+		}
+		if job.Output == "" {
+			return nil, i18n.Errorf("config.job-no-output", path, i)
+		}
+		for plugin := range job.Options {
+			if !slices.Contains(job.Plugins, plugin) {
+				return nil, i18n.Errorf("config.job-unused-options", path, i, plugin)
+			}
+		}
+		job.Output = resolvePath(dir, job.Output)
+	}
+
+	return &cfg, nil
+}
+
+func isInitialism(word string) bool {
+	for i, r := range word {
+		isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return word != ""
+}
+
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,69 @@
+package gomosaic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "относительные пути разрешаются от директории файла",
+			data: `{"jobs":[{"plugins":["http-client"],"packages":["./svc/..."],"output":"out"}]}`,
+		},
+		{
+			name:    "задача без плагинов",
+			data:    `{"jobs":[{"packages":["./svc/..."],"output":"out"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "параметры для плагина не из задачи",
+			data:    `{"jobs":[{"plugins":["http-client"],"packages":["./svc/..."],"output":"out","options":{"log-middleware":{"filename":"log.go"}}}]}`,
+			wantErr: true,
+		},
+		{
+			name: "дополнительные аббревиатуры",
+			data: `{"initialisms":["GRPC","K8S"],"jobs":[{"plugins":["http-client"],"packages":["./svc/..."],"output":"out"}]}`,
+		},
+		{
+			name:    "аббревиатура с недопустимыми символами",
+			data:    `{"initialisms":["gRPC-web"],"jobs":[{"plugins":["http-client"],"packages":["./svc/..."],"output":"out"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "некорректный JSON",
+			data:    `{"jobs":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, DefaultConfigFilename)
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if want := filepath.Join(dir, "go.mod"); cfg.ModFile != want {
+				t.Errorf("ModFile = %s, want %s", cfg.ModFile, want)
+			}
+			if want := filepath.Join(dir, "out"); cfg.Jobs[0].Output != want {
+				t.Errorf("Output = %s, want %s", cfg.Jobs[0].Output, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package gomosaic
+
+import (
+	"maps"
+	"sync"
+)
+
+// DeclSource интерфейс и метод, по которым сгенерировано объявление
+type DeclSource struct {
+	NameTypeInfo *NameTypeInfo
+	Method       *MethodInfo // nil, если объявление относится ко всему интерфейсу
+}
+
+// DeclSourceRecorder запоминает, по какому интерфейсу и методу сгенерировано объявление верхнего уровня.
+// Проверка типов сопоставляет по этим отметкам ошибки сгенерированного кода с методами и их аннотациями.
+type DeclSourceRecorder interface {
+	// SetDeclSource отмечает объявление decl: имя функции, типа, константы или переменной,
+	// для методов "Тип.Метод". Отметка типа относится и ко всем его неотмеченным методам.
+	SetDeclSource(decl string, nameTypeInfo *NameTypeInfo, method *MethodInfo)
+}
+
+type declSources struct {
+	mu      sync.Mutex
+	sources map[string]*DeclSource
+}
+
+func (s *declSources) set(decl string, source *DeclSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sources == nil {
+		s.sources = make(map[string]*DeclSource)
+	}
+	s.sources[decl] = source
+}
+
+func (s *declSources) all() map[string]*DeclSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.sources)
+}
@@ -0,0 +1,166 @@
+package gomosaic
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// GenAnnotation директива задачи генерации в заголовке файла пакета:
+// @gomosaic-gen plugins outputDir. Плагины перечисляются через запятую,
+// директория вывода указывается относительно директории пакета.
+const GenAnnotation = markerAnnotation + "-gen"
+
+// genDirectiveMarker быстрый признак директив в файле до разбора
+var genDirectiveMarker = []byte("@" + GenAnnotation)
+
+// DiscoverJobs находит директивы @gomosaic-gen в комментариях до объявления package
+// (в том числе в документации пакета) файлов пакетов paths и собирает из них задачи.
+// Входным пакетом задачи становится пакет с директивой. Плагины директивы остаются в одной
+// задаче, чтобы учитывались их зависимости, общие артефакты и конфликты файлов. Директивы
+// пакета с одной директорией вывода объединяются, а пакеты с одинаковыми плагинами и директорией
+// вывода становятся входными пакетами одной задачи. Задачи упорядочены по директории вывода.
+func DiscoverJobs(dir string, paths []string) (jobs []*JobConfig, errs error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, packagePatterns(paths)...)
+	if err != nil {
+		return nil, err
+	}
+
+	type jobKey struct{ output, plugins string }
+	byKey := make(map[jobKey]*JobConfig)
+
+	for _, pkg := range pkgs {
+		var (
+			outputs []string
+			plugins = make(map[string][]string)
+		)
+		for _, filename := range pkg.GoFiles {
+			directives, err := parseGenDirectives(filename)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			for _, d := range directives {
+				if _, ok := plugins[d.output]; !ok {
+					outputs = append(outputs, d.output)
+				}
+				for _, plugin := range d.plugins {
+					if !slices.Contains(plugins[d.output], plugin) {
+						plugins[d.output] = append(plugins[d.output], plugin)
+					}
+				}
+			}
+		}
+
+		for _, output := range outputs {
+			// Порядок плагинов не влияет на генерацию, зависимости упорядочивает GenerateAll
+			sort.Strings(plugins[output])
+			key := jobKey{output: output, plugins: strings.Join(plugins[output], ",")}
+			job, ok := byKey[key]
+			if !ok {
+				job = &JobConfig{Plugins: plugins[output], Output: output}
+				byKey[key] = job
+				jobs = append(jobs, job)
+			}
+			job.Packages = append(job.Packages, pkg.PkgPath)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Output != jobs[j].Output {
+			return jobs[i].Output < jobs[j].Output
+		}
+		return strings.Join(jobs[i].Plugins, ",") < strings.Join(jobs[j].Plugins, ",")
+	})
+
+	// Задачи с общей директорией вывода не должны генерировать одни и те же файлы
+	for i, job := range jobs {
+		for _, prev := range jobs[:i] {
+			if prev.Output != job.Output {
+				continue
+			}
+			if j := slices.IndexFunc(job.Plugins, func(plugin string) bool { return slices.Contains(prev.Plugins, plugin) }); j != -1 {
+				errs = multierror.Append(errs, i18n.Errorf("generate.output-conflict",
+					strings.Join(prev.Packages, ", "), strings.Join(job.Packages, ", "), job.Output, job.Plugins[j]))
+			}
+		}
+	}
+
+	return jobs, errs
+}
+
+// genDirective разобранная директива @gomosaic-gen
+type genDirective struct {
+	plugins []string
+	output  string // Абсолютный путь директории вывода
+}
+
+// parseGenDirectives разбирает директивы в комментариях до объявления package файла
+func parseGenDirectives(filename string) (directives []*genDirective, errs error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(src, genDirectiveMarker) {
+		return nil, nil
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, comment := range group.List {
+			text := strings.TrimLeft(strings.TrimLeft(comment.Text, "/"), " ")
+			if text != "@"+GenAnnotation && !strings.HasPrefix(text, "@"+GenAnnotation+" ") {
+				continue
+			}
+
+			posInfo := parsePosition(fset.Position(comment.Pos()))
+			a, err := annotation.Parse(text)
+			if err != nil {
+				errs = multierror.Append(errs, Error(err.Error(), posInfo))
+				continue
+			}
+			if len(a.Options) != 2 || len(a.Params) > 0 { //nolint: mnd
+				errs = multierror.Append(errs, Errorf("generate.directive", posInfo, GenAnnotation))
+				continue
+			}
+
+			output := a.Options[1]
+			if !filepath.IsAbs(output) {
+				output = filepath.Join(filepath.Dir(filename), output)
+			}
+			directives = append(directives, &genDirective{
+				plugins: strings.Split(a.Options[0], ","),
+				output:  output,
+			})
+		}
+	}
+
+	return directives, errs
+}
@@ -0,0 +1,109 @@
+package gomosaic
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseGenDirectives(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []*genDirective
+		wantErr bool
+	}{
+		{
+			name: "директивы в документации пакета",
+			src:  "// Package svc сервис.\n//\n// @gomosaic-gen http-server-chi,http-client ../transport\npackage svc\n",
+			want: []*genDirective{{plugins: []string{"http-server-chi", "http-client"}, output: "transport"}},
+		},
+		{
+			name: "директивы в заголовке файла",
+			src:  "// @gomosaic-gen http-client ../client\n// @gomosaic-gen log-middleware /abs/log\n\npackage svc\n",
+			want: []*genDirective{
+				{plugins: []string{"http-client"}, output: "client"},
+				{plugins: []string{"log-middleware"}, output: "/abs/log"},
+			},
+		},
+		{
+			name: "директивы после package не учитываются",
+			src:  "package svc\n\n// @gomosaic-gen http-client ../client\ntype S interface{}\n",
+		},
+		{
+			name: "другие аннотации gomosaic не учитываются",
+			src:  "// @gomosaic-general x\npackage svc\n",
+		},
+		{
+			name:    "не указана директория вывода",
+			src:     "// @gomosaic-gen http-client\npackage svc\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "svc")
+			if err := os.Mkdir(dir, 0o700); err != nil {
+				t.Fatal(err)
+			}
+			filename := filepath.Join(dir, "svc.go")
+			if err := os.WriteFile(filename, []byte(tt.src), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseGenDirectives(filename)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGenDirectives() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, d := range tt.want {
+				if !filepath.IsAbs(d.output) {
+					d.output = filepath.Join(filepath.Dir(dir), d.output)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGenDirectives() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverJobs(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":              "module example.com/app\n\ngo 1.22\n",
+		"users/users.go":      "// @gomosaic-gen http-server-chi,log-middleware ../transport\npackage users\n",
+		"orders/orders.go":    "// @gomosaic-gen http-server-chi ../transport\n// @gomosaic-gen log-middleware ../transport\n// @gomosaic-gen http-client client\npackage orders\n",
+		"orders/client.go":    "// @gomosaic-gen http-client-test client\npackage orders\n",
+		"internal/plain/p.go": "package plain\n",
+	})
+
+	jobs, err := DiscoverJobs(dir, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*JobConfig{
+		{Plugins: []string{"http-client", "http-client-test"}, Packages: []string{"example.com/app/orders"}, Output: filepath.Join(dir, "orders", "client")},
+		{Plugins: []string{"http-server-chi", "log-middleware"}, Packages: []string{"example.com/app/orders", "example.com/app/users"}, Output: filepath.Join(dir, "transport")},
+	}
+	if !reflect.DeepEqual(jobs, want) {
+		for _, job := range jobs {
+			t.Logf("%+v", job)
+		}
+		t.Errorf("DiscoverJobs() = %d задач, want %d", len(jobs), len(want))
+	}
+}
+
+func TestDiscoverJobsOutputConflict(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.22\n",
+		"users/users.go":   "// @gomosaic-gen http-server-chi,log-middleware ../transport\npackage users\n",
+		"orders/orders.go": "// @gomosaic-gen http-server-chi ../transport\npackage orders\n",
+	})
+
+	_, err := DiscoverJobs(dir, []string{"./..."})
+	if MessageID(err) != "generate.output-conflict" {
+		t.Errorf("DiscoverJobs() error = %v, want generate.output-conflict", err)
+	}
+}
@@ -1,12 +1,19 @@
 package gomosaic
 
 import (
+	"errors"
 	"go/token"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
 type Level string
 
 type WarningError struct {
+	id   string
 	text string
 	pos  token.Position
 }
@@ -18,18 +25,126 @@ func (e *WarningError) Error() string {
 	return e.pos.String() + ": " + e.text
 }
 
+// Position возвращает позицию предупреждения в исходном коде
+func (e *WarningError) Position() token.Position {
+	return e.pos
+}
+
+// MessageID возвращает идентификатор сообщения каталога i18n (пустой для Warn)
+func (e *WarningError) MessageID() string {
+	return e.id
+}
+
 type FailedError struct {
+	id      string
 	text    string
 	posInfo *PosInfo
+	cause   error
 }
 
 func (e *FailedError) Error() string {
-	if !e.posInfo.IsValid {
+	if e.posInfo == nil || !e.posInfo.IsValid {
 		return e.text
 	}
 	return e.posInfo.String() + ": " + e.text
 }
 
+// Position возвращает позицию ошибки в исходном коде
+func (e *FailedError) Position() token.Position {
+	if e.posInfo == nil || !e.posInfo.IsValid {
+		return token.Position{}
+	}
+	return token.Position{
+		Filename: e.posInfo.Filename,
+		Line:     e.posInfo.Line,
+		Column:   e.posInfo.Column,
+	}
+}
+
+// Unwrap возвращает причину ошибки, например ErrMissingContext
+func (e *FailedError) Unwrap() error {
+	return e.cause
+}
+
+// MessageID возвращает идентификатор сообщения каталога i18n (пустой для Error)
+func (e *FailedError) MessageID() string {
+	return e.id
+}
+
+// Причины ошибок сигнатуры метода, для которых анализатор предлагает исправления
+var (
+	ErrMissingContext = i18n.Errorf("signature.missing-context")
+	ErrMissingError   = i18n.Errorf("signature.missing-error")
+)
+
+// SignatureError ошибка сигнатуры метода с причиной cause (ErrMissingContext, ErrMissingError)
+func SignatureError(cause error, posInfo *PosInfo) error {
+	return &FailedError{
+		id:      "signature.invalid",
+		text:    i18n.T("signature.invalid", cause),
+		posInfo: posInfo,
+		cause:   cause,
+	}
+}
+
+// Errorf возвращает ошибку с сообщением id каталога i18n на текущем языке.
+// Идентификатор сообщения не зависит от языка и доступен через MessageID.
+func Errorf(id string, posInfo *PosInfo, args ...any) error {
+	return &FailedError{
+		id:      id,
+		text:    i18n.T(id, args...),
+		posInfo: posInfo,
+	}
+}
+
+// Warnf возвращает предупреждение с сообщением id каталога i18n на текущем языке
+func Warnf(id string, position token.Position, args ...any) error {
+	return &WarningError{
+		id:   id,
+		text: i18n.T(id, args...),
+		pos:  position,
+	}
+}
+
+// MessageID возвращает идентификатор сообщения каталога i18n диагностики err
+// или пустую строку, если текст диагностики задан без каталога
+func MessageID(err error) string {
+	var m interface{ MessageID() string }
+	if errors.As(err, &m) {
+		return m.MessageID()
+	}
+	return ""
+}
+
+// Diagnostics разворачивает ошибки и предупреждения, в том числе вложенные multierror,
+// в плоский список диагностик
+func Diagnostics(err error) (diags []error) {
+	if err == nil {
+		return nil
+	}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, e := range merr.Errors {
+			diags = append(diags, Diagnostics(e)...)
+		}
+		return diags
+	}
+	return []error{err}
+}
+
+// DiagnosticPosition возвращает позицию диагностики в исходном коде (Error, Warn и т.п.)
+// и текст диагностики без префикса позиции. Если позиции нет, возвращается пустая позиция и полный текст.
+func DiagnosticPosition(err error) (position token.Position, message string) {
+	message = err.Error()
+	if p, ok := err.(interface{ Position() token.Position }); ok { //nolint: errorlint
+		position = p.Position()
+	}
+	if position.IsValid() {
+		message = strings.TrimPrefix(message, position.String()+": ")
+	}
+	return position, message
+}
+
 func Error(text string, posInfo *PosInfo) error {
 	return &FailedError{
 		text:    text,
@@ -53,3 +168,20 @@ func IsErrWarning(e error) bool {
 	_, ok := e.(*WarningError)
 	return ok
 }
+
+// hasFailed проверяет, есть ли среди диагностик ошибки, а не только предупреждения
+func hasFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, e := range merr.Errors {
+			if !IsErrWarning(e) {
+				return true
+			}
+		}
+		return false
+	}
+	return !IsErrWarning(err)
+}
@@ -0,0 +1,28 @@
+package gomosaic
+
+import (
+	"errors"
+	"go/token"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestDiagnostics(t *testing.T) {
+	pos := token.Position{Filename: "svc.go", Line: 3, Column: 2}
+	warn := Warn("устаревшая аннотация", pos)
+	plain := errors.New("ошибка загрузчика")
+
+	nested := multierror.Append(plain, multierror.Append(nil, warn))
+	diags := Diagnostics(nested)
+	if len(diags) != 2 || diags[0] != plain || diags[1] != warn {
+		t.Fatalf("Diagnostics() = %v, want [%v %v]", diags, plain, warn)
+	}
+
+	if got, message := DiagnosticPosition(warn); got != pos || message != "устаревшая аннотация" {
+		t.Errorf("DiagnosticPosition() = %v, %q", got, message)
+	}
+	if got, message := DiagnosticPosition(plain); got.IsValid() || message != plain.Error() {
+		t.Errorf("DiagnosticPosition() без позиции = %v, %q", got, message)
+	}
+}
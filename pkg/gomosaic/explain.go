@@ -0,0 +1,24 @@
+package gomosaic
+
+import "context"
+
+// ExplainField значение поля параметров плагина и его источник
+type ExplainField struct {
+	Path     string   // Путь поля в структуре параметров, например Default.Accept
+	Value    string   // Значение поля
+	Source   string   // Аннотация, значение по умолчанию или правило, которое задало значение
+	Position *PosInfo // Позиция аннотации, если значение задала аннотация
+}
+
+// Explanation разобранная плагином структура параметров интерфейса, метода или параметра метода
+type Explanation struct {
+	Title  string          // Структура и объект модели, например MethodOpt UserService.GetUser
+	Fields []*ExplainField // Поля структуры
+}
+
+// Explainer необязательный интерфейс плагина, который показывает, как аннотации
+// интерфейса iface и его метода method разобраны в параметры плагина.
+// Если method пустой, объясняются параметры интерфейса.
+type Explainer interface {
+	Explain(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo, iface, method string) ([]*Explanation, error)
+}
@@ -1,6 +1,7 @@
 package gomosaic
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -9,7 +10,10 @@ import (
 	"github.com/dave/jennifer/jen"
 )
 
-var _ File = &GoFile{}
+var (
+	_ File               = &GoFile{}
+	_ DeclSourceRecorder = &GoFile{}
+)
 
 type GoFileOption func(*goFileOpts)
 
@@ -19,24 +23,103 @@ func UseTestPkg() GoFileOption {
 	}
 }
 
+// Editable создает файл, который принадлежит пользователю после создания:
+// без заголовка сгенерированного файла и тега сборки !gomosaic.
+func Editable() GoFileOption {
+	return func(o *goFileOpts) {
+		o.editable = true
+	}
+}
+
 type goFileOpts struct {
 	useTestPkg bool
+	editable   bool
 }
 
 type GoFile struct {
 	*jen.File
 
+	packageName string
 	packagePath string
+	imports     *ImportPlanner
+	editable    bool
+	sources     *declSources
+}
+
+// GeneratedHeader возвращает комментарий-заголовок сгенерированного Go файла
+func GeneratedHeader(version string) string {
+	return "// Code generated by \"gomosaic " + strings.Join(os.Args[1:], " ") + "\" " + version + "; DO NOT EDIT.\n"
 }
 
 func (f *GoFile) Render(w io.Writer, version string) error {
-	f.HeaderComment("// Code generated by \"gomosaic " + strings.Join(os.Args[1:], " ") + "\" " + version + "; DO NOT EDIT.\n")
-	f.Id("//go:build !gomosaic")
-	if err := f.File.Render(w); err != nil {
+	if _, err := f.PlanImports(); err != nil {
 		return err
 	}
 
-	return nil
+	out := f.newRenderFile(GeneratedHeader(version))
+	f.imports.apply(out)
+
+	return out.Render(w)
+}
+
+// PlanImports вычисляет псевдонимы импортов по текущему содержимому файла (путь пакета -> псевдоним).
+// Render планирует импорты так же, поэтому псевдонимы совпадают, если файл после вызова не изменялся.
+func (f *GoFile) PlanImports() (map[string]string, error) {
+	// черновой рендеринг нужен только для того, чтобы узнать используемые пакеты и идентификаторы
+	draft := f.newRenderFile("")
+	draft.NoFormat = true
+
+	var buf bytes.Buffer
+	if err := draft.Render(&buf); err != nil {
+		return nil, err
+	}
+
+	paths, idents, err := scanImports(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	f.imports.Reserve(idents...)
+	return f.imports.Plan(paths), nil
+}
+
+// newRenderFile создает файл для рендеринга, чтобы jen не запоминал псевдонимы между проходами.
+func (f *GoFile) newRenderFile(header string) *jen.File {
+	out := jen.NewFile(f.packageName)
+	if f.editable {
+		out.Add(f.File)
+		return out
+	}
+	out.HeaderComment(header)
+	out.Add(f.File)
+	out.Id("//go:build !gomosaic")
+	return out
+}
+
+// Imports возвращает планировщик импортов файла.
+// Итоговые псевдонимы до рендеринга возвращает PlanImports.
+func (f *GoFile) Imports() *ImportPlanner {
+	return f.imports
+}
+
+// ImportAlias задает предпочтительный псевдоним для пакета.
+func (f *GoFile) ImportAlias(pkgPath, alias string) {
+	f.imports.PreferAlias(pkgPath, alias)
+}
+
+// ImportName задает настоящее имя пакета, если оно отличается от последнего элемента пути.
+func (f *GoFile) ImportName(pkgPath, name string) {
+	f.imports.PackageName(pkgPath, name)
+}
+
+// SetDeclSource отмечает, по какому интерфейсу и методу сгенерировано объявление decl
+func (f *GoFile) SetDeclSource(decl string, nameTypeInfo *NameTypeInfo, method *MethodInfo) {
+	f.sources.set(decl, &DeclSource{NameTypeInfo: nameTypeInfo, Method: method})
+}
+
+// DeclSources возвращает отметки источников объявлений файла (имя объявления -> источник)
+func (f *GoFile) DeclSources() map[string]*DeclSource {
+	return f.sources.all()
 }
 
 func (f *GoFile) isCurrPkg(pkgPath string) bool {
@@ -59,10 +142,7 @@ func NewGoFile(module *ModuleInfo, outputDir string, opts ...GoFileOption) *GoFi
 		optApply(o)
 	}
 
-	packagePath := strings.ReplaceAll(outputDir, module.Dir, "")
-	packagePath = filepath.Join(module.Path, strings.TrimLeft(packagePath, string(os.PathSeparator)))
-
-	packageName := guessAlias(filepath.Base(outputDir))
+	packagePath, packageName := OutputPackage(module, outputDir)
 	if o.useTestPkg {
 		packagePath += "/_test"
 		packageName += "_test"
@@ -70,6 +150,18 @@ func NewGoFile(module *ModuleInfo, outputDir string, opts ...GoFileOption) *GoFi
 
 	return &GoFile{
 		File:        jen.NewFile(packageName),
+		packageName: packageName,
 		packagePath: packagePath,
+		imports:     NewImportPlanner(),
+		editable:    o.editable,
+		sources:     &declSources{},
 	}
 }
+
+// OutputPackage возвращает путь и имя пакета директории вывода
+func OutputPackage(module *ModuleInfo, outputDir string) (packagePath, packageName string) {
+	packagePath = strings.ReplaceAll(outputDir, module.Dir, "")
+	packagePath = filepath.Join(module.Path, strings.TrimLeft(packagePath, string(os.PathSeparator)))
+
+	return packagePath, guessAlias(filepath.Base(outputDir))
+}
@@ -29,7 +29,8 @@ func (f *TxtFile) WriteText(format string, a ...any) {
 }
 
 func (f *TxtFile) Render(w io.Writer, version string) error {
-	return nil
+	_, err := w.Write(f.buf.Bytes())
+	return err
 }
 
 func NewTxtFile() *TxtFile {
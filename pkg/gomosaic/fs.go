@@ -1,9 +1,12 @@
 package gomosaic
 
 import (
-	"fmt"
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
 // FileSystem отвечает за запись сгенерированных файлов
@@ -20,17 +23,57 @@ func NewFileSystem(version, outputDir string) *FileSystem {
 	}
 }
 
-// SaveFile сохраняет AST в файл
+// SaveFile сохраняет AST в файл, перенося защищенные области из существующего файла.
+// Предупреждения об исчезнувших областях возвращаются вместе с путем сохраненного файла.
 func (fs *FileSystem) SaveFile(filename string, file File) (path string, err error) {
-	path = filepath.Join(fs.outputDir, filename)
-	f, err := os.Create(path)
+	data, err := fs.RenderFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	data, warnings := fs.KeepRegions(filename, data)
+	if hasFailed(warnings) {
+		return "", warnings
+	}
+
+	path, err = fs.WriteFile(filename, data)
 	if err != nil {
-		return "", fmt.Errorf("не удалось создать файл: %w", err)
+		return "", err
 	}
-	defer f.Close()
 
-	if err := file.Render(f, fs.version); err != nil {
-		return "", fmt.Errorf("не удалось записать файл: %w", err)
+	return path, warnings
+}
+
+// KeepRegions переносит в отрендеренный файл содержимое защищенных областей
+// (// gomosaic:keep begin <id> ... // gomosaic:keep end) из уже существующего файла.
+// Возвращает предупреждения, если область исчезла из сгенерированного кода,
+// и ошибку, если области существующего файла не удалось разобрать.
+func (fs *FileSystem) KeepRegions(filename string, data []byte) ([]byte, error) {
+	path := filepath.Join(fs.outputDir, filename)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, i18n.Errorf("fs.read", err)
+	}
+
+	return mergeKeepRegions(path, data, existing)
+}
+
+// RenderFile рендерит файл в память
+func (fs *FileSystem) RenderFile(file File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := file.Render(&buf, fs.version); err != nil {
+		return nil, i18n.Errorf("fs.render", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteFile записывает отрендеренный файл в директорию вывода
+func (fs *FileSystem) WriteFile(filename string, data []byte) (path string, err error) {
+	path = filepath.Join(fs.outputDir, filename)
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint: gosec,mnd
+		return "", i18n.Errorf("fs.write", err)
 	}
 
 	return path, nil
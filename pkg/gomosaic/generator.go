@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"sort"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
 )
 
 type ContextKey string
 
 const (
 	outputDirContextKey ContextKey = "output_dir"
+	caserContextKey     ContextKey = "caser"
 )
 
 func ContextWithOutputDir(ctx context.Context, outputDir string) context.Context {
@@ -20,6 +26,20 @@ func OutputDirFromContext(ctx context.Context) string {
 	return ctx.Value(outputDirContextKey).(string)
 }
 
+// ContextWithCaser передает плагинам преобразователь регистра с аббревиатурами запуска
+func ContextWithCaser(ctx context.Context, caser *strcase.Caser) context.Context {
+	return context.WithValue(ctx, caserContextKey, caser)
+}
+
+// CaserFromContext возвращает преобразователь регистра запуска.
+// Если он не передан, используется общая таблица аббревиатур пакета strcase.
+func CaserFromContext(ctx context.Context) *strcase.Caser {
+	if caser, ok := ctx.Value(caserContextKey).(*strcase.Caser); ok {
+		return caser
+	}
+	return strcase.Default()
+}
+
 // Generator интерфейс для плагинов
 type Generator interface {
 	// Generate Генерация файлов на основе информации о модуле и типах
@@ -28,47 +48,308 @@ type Generator interface {
 	Name() string
 }
 
+type CodeGeneratorOption func(*CodeGenerator)
+
+// WithCache включает пропуск генерации, если модель и параметры плагина не изменились
+func WithCache(cache *Cache) CodeGeneratorOption {
+	return func(cg *CodeGenerator) {
+		cg.cache = cache
+	}
+}
+
+// WithTypeCheck включает проверку типов сгенерированного кода перед сохранением файлов
+func WithTypeCheck() CodeGeneratorOption {
+	return func(cg *CodeGenerator) {
+		cg.typeCheck = true
+	}
+}
+
+// WithProgram передает загруженную программу плагинам, реализующим ProgramGenerator
+func WithProgram(program *Program) CodeGeneratorOption {
+	return func(cg *CodeGenerator) {
+		cg.program = program
+	}
+}
+
+// WithPluginOptions передает плагинам параметры, заданные по имени плагина
+func WithPluginOptions(options map[string]map[string]string) CodeGeneratorOption {
+	return func(cg *CodeGenerator) {
+		cg.pluginOptions = options
+	}
+}
+
 // CodeGenerator основной генератор кода
 type CodeGenerator struct {
 	pluginManager *PluginManager
 	fs            *FileSystem
+	cache         *Cache
+	program       *Program
+	pluginOptions map[string]map[string]string
+	typeCheck     bool
 }
 
 // NewCodeGenerator создает новый экземпляр CodeGenerator
-func NewCodeGenerator(pluginManager *PluginManager, fs *FileSystem) *CodeGenerator {
-	return &CodeGenerator{
+func NewCodeGenerator(pluginManager *PluginManager, fs *FileSystem, opts ...CodeGeneratorOption) *CodeGenerator {
+	cg := &CodeGenerator{
 		pluginManager: pluginManager,
 		fs:            fs,
 	}
+	for _, optApply := range opts {
+		optApply(cg)
+	}
+	return cg
+}
+
+// GenerateResult результат работы плагина
+type GenerateResult struct {
+	Plugin      string   // Имя плагина
+	OutputFiles []string // Сохраненные файлы
+	Cached      bool     // Генерация пропущена, так как модель и параметры плагина не изменились
+}
+
+type renderedFile struct {
+	filename string
+	data     []byte
+	sources  map[string]*DeclSource // Источники объявлений для сопоставления ошибок проверки типов
+}
+
+type pluginOutput struct {
+	result   *GenerateResult
+	cacheKey string
+	files    []*renderedFile
+	warnings error // Предупреждения плагина, сохраняются в кеше вместе с файлами
 }
 
 // Generate использует плагин для генерации кода и сохраняет файлы
 func (cg *CodeGenerator) Generate(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo, pluginName string) (outputFiles []string, err error) {
-	plugin, err := cg.pluginManager.GetPlugin(pluginName)
-	if err != nil {
-		return nil, fmt.Errorf("не удалось получить плагин: %w", err)
+	results, err := cg.GenerateAll(ctx, module, types, []string{pluginName})
+	if len(results) == 0 {
+		return nil, err
+	}
+
+	return results[0].OutputFiles, err
+}
+
+// GenerateAll параллельно запускает плагины и сохраняет файлы.
+// Если включена проверка типов, файлы сохраняются и при ошибках компиляции,
+// а ошибки возвращаются вместе с результатами. Предупреждения плагинов тоже
+// возвращаются вместе с результатами и повторяются, если плагин пропущен по кешу.
+// Каждый плагин получает собственную копию модели, а результаты возвращаются
+// в порядке переданных имен плагинов независимо от порядка завершения.
+// Плагины, реализующие Dependent, запускаются после своих зависимостей,
+// а все плагины запуска используют общий реестр артефактов (ArtifactsFromContext).
+// Число одновременно работающих плагинов ограничивается ContextWithConcurrency.
+func (cg *CodeGenerator) GenerateAll(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo, pluginNames []string) (results []*GenerateResult, err error) {
+	plugins := make([]Generator, 0, len(pluginNames))
+	for _, pluginName := range pluginNames {
+		plugin, err := cg.pluginManager.GetPlugin(pluginName)
+		if err != nil {
+			return nil, i18n.Errorf("generate.plugin", err)
+		}
+		plugins = append(plugins, plugin)
 	}
 
-	files, err := plugin.Generate(ctx, module, types)
+	graph := newPluginGraph(plugins)
+	levels, err := graph.levels()
 	if err != nil {
-		return nil, fmt.Errorf("не удалось сгенерировать код: %w", err)
+		return nil, err
+	}
+
+	ctx = ContextWithArtifacts(ctx, NewArtifacts())
+
+	outputByName := make(map[string]*pluginOutput, len(plugins))
+	for _, level := range levels {
+		levelOutputs, err := ParallelMap(ctx, level, func(ctx context.Context, plugin Generator) (*pluginOutput, error) {
+			return cg.runPlugin(ctx, plugin, module, types, graph)
+		})
+		if err != nil {
+			return nil, i18n.Errorf("generate.failed", err)
+		}
+		for i, out := range levelOutputs {
+			outputByName[level[i].Name()] = out
+		}
+	}
+
+	outputs := make([]*pluginOutput, 0, len(plugins))
+	for _, plugin := range plugins {
+		outputs = append(outputs, outputByName[plugin.Name()])
+	}
+
+	owners := make(map[string]string, len(outputs))
+	for _, out := range outputs {
+		for _, file := range out.files {
+			if owner, ok := owners[file.filename]; ok {
+				return nil, i18n.Errorf("generate.file-conflict", owner, out.result.Plugin, file.filename)
+			}
+			owners[file.filename] = out.result.Plugin
+		}
 	}
 
+	var diagnostics error
+	for _, out := range outputs {
+		if out.warnings != nil {
+			diagnostics = multierror.Append(diagnostics, out.warnings)
+		}
+	}
+	for _, out := range outputs {
+		for _, file := range out.files {
+			data, err := cg.fs.KeepRegions(file.filename, file.data)
+			if hasFailed(err) {
+				return nil, err
+			}
+			if err != nil {
+				diagnostics = multierror.Append(diagnostics, err)
+			}
+			file.data = data
+		}
+	}
+
+	if cg.typeCheck {
+		var files []*renderedFile
+		for _, out := range outputs {
+			files = append(files, out.files...)
+		}
+		if err := typeCheck(OutputDirFromContext(ctx), files); err != nil {
+			diagnostics = multierror.Append(diagnostics, err)
+		}
+	}
+
+	// некомпилируемый код не попадает в кеш, чтобы ошибки повторялись при следующем запуске
+	storeCache := cg.cache != nil && !hasFailed(diagnostics)
+
+	for _, out := range outputs {
+		results = append(results, out.result)
+
+		if out.result.Cached {
+			continue
+		}
+
+		for _, file := range out.files {
+			outputFilename, err := cg.fs.WriteFile(file.filename, file.data)
+			if err != nil {
+				return nil, i18n.Errorf("generate.save", err)
+			}
+
+			out.result.OutputFiles = append(out.result.OutputFiles, outputFilename)
+		}
+
+		if storeCache && out.cacheKey != "" {
+			if err := cg.cache.Store(out.cacheKey, out.result.Plugin, out.result.OutputFiles, out.warnings); err != nil {
+				return nil, i18n.Errorf("cache.save", err)
+			}
+		}
+	}
+
+	return results, diagnostics
+}
+
+// runPlugin запускает плагин на копии модели и рендерит файлы в память.
+// Плагин, от которого зависят другие плагины запуска, не пропускается по кешу,
+// так как его артефакты нужны зависимым плагинам.
+func (cg *CodeGenerator) runPlugin(ctx context.Context, plugin Generator, module *ModuleInfo, types []*NameTypeInfo, graph *pluginGraph) (*pluginOutput, error) {
+	out := &pluginOutput{
+		result: &GenerateResult{Plugin: plugin.Name()},
+	}
+
+	ctx = ContextWithPluginOptions(ctx, &PluginOptions{
+		Plugin: plugin.Name(),
+		Values: cg.pluginOptions[plugin.Name()],
+	})
+
+	programGen, isProgramGen := plugin.(ProgramGenerator)
+
+	if cg.cache != nil && graph.cacheable(plugin) {
+		// если входные файлы плагина недоступны, кеш не используется, а ошибку сообщит сам плагин
+		if info, err := cg.cacheKeyInfo(ctx, plugin, module, types, graph); err == nil {
+			out.cacheKey = cg.cache.Key(info)
+
+			// предупреждения загрузки аннотаций плагина повторяются из кеша, хотя плагин не запускается
+			if outputFiles, warnings, ok := cg.cache.Lookup(out.cacheKey); ok && !graph.hasDependents(plugin) {
+				out.result.OutputFiles = outputFiles
+				out.result.Cached = true
+				out.warnings = warnings
+				return out, nil
+			}
+		}
+	}
+
+	var (
+		files map[string]File
+		err   error
+	)
+	if isProgramGen {
+		if cg.program == nil {
+			return nil, i18n.Errorf("generate.program-required", plugin.Name())
+		}
+		files, err = programGen.GenerateProgram(ctx, module, CloneNameTypesInfo(types), cg.program)
+	} else {
+		files, err = plugin.Generate(ctx, module, CloneNameTypesInfo(types))
+	}
+	if hasFailed(err) {
+		return nil, err
+	}
+	out.warnings = err
+
 	keys := make([]string, 0, len(files))
 	for k := range files {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	for _, filename := range keys {
-		file := files[filename]
-		outputFilename, err := cg.fs.SaveFile(filename, file)
+	out.files, err = ParallelMap(ctx, keys, func(_ context.Context, filename string) (*renderedFile, error) {
+		data, err := cg.fs.RenderFile(files[filename])
 		if err != nil {
-			return nil, fmt.Errorf("не удалось сохранить файл: %w", err)
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		rendered := &renderedFile{filename: filename, data: data}
+		if goFile, ok := files[filename].(*GoFile); ok {
+			rendered.sources = goFile.DeclSources()
 		}
+		return rendered, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
 
-		outputFiles = append(outputFiles, outputFilename)
+func (cg *CodeGenerator) cacheKeyInfo(ctx context.Context, plugin Generator, module *ModuleInfo, types []*NameTypeInfo, graph *pluginGraph) (*CacheKeyInfo, error) {
+	info := &CacheKeyInfo{
+		Plugin:        plugin.Name(),
+		PluginVersion: pluginVersion(plugin),
+		Version:       cg.fs.version,
+		Module:        module,
+		OutputDir:     OutputDirFromContext(ctx),
+		Options:       PluginOptionsFromContext(ctx).Values,
+		Types:         types,
+		Initialisms:   CaserFromContext(ctx).Initialisms(),
+	}
+	for _, dep := range graph.dependencies(plugin) {
+		info.Dependencies = append(info.Dependencies, &CacheKeyInfo{
+			Plugin:        dep.Name(),
+			PluginVersion: pluginVersion(dep),
+			Options:       cg.pluginOptions[dep.Name()],
+		})
 	}
+	if inputs, ok := plugin.(CacheInputs); ok {
+		paths, err := inputs.CacheInputs(ctx, module)
+		if err != nil {
+			return nil, err
+		}
+		info.Inputs = make(map[string]string, len(paths))
+		for _, path := range paths {
+			if info.Inputs[path], err = hashFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return info, nil
+}
 
-	return outputFiles, nil
+func pluginVersion(plugin Generator) string {
+	if v, ok := plugin.(Versioner); ok {
+		return v.Version()
+	}
+	return ""
 }
@@ -28,3 +28,8 @@ func guessAlias(path string) string {
 
 	return alias
 }
+
+// PackageAlias возвращает предполагаемое имя пакета по пути импорта
+func PackageAlias(pkgPath string) string {
+	return guessAlias(pkgPath)
+}
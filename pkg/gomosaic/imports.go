@@ -0,0 +1,275 @@
+package gomosaic
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+var majorVersionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+// ImportPlanner планирует псевдонимы импортов для одного сгенерированного файла.
+// Плагины могут задать предпочтительные псевдонимы и зарезервировать имена,
+// а планировщик детерминированно разрешает коллизии: одинаковый набор
+// импортов всегда дает одинаковые псевдонимы независимо от порядка использования.
+type ImportPlanner struct {
+	preferred map[string]string
+	names     map[string]string
+	reserved  map[string]struct{}
+	aliases   map[string]string
+}
+
+// NewImportPlanner создает новый планировщик импортов
+func NewImportPlanner() *ImportPlanner {
+	return &ImportPlanner{
+		preferred: make(map[string]string),
+		names:     make(map[string]string),
+		reserved:  make(map[string]struct{}),
+		aliases:   make(map[string]string),
+	}
+}
+
+// PreferAlias регистрирует предпочтительный псевдоним для пакета.
+// Псевдоним используется, если он не конфликтует с другими именами в файле.
+func (p *ImportPlanner) PreferAlias(pkgPath, alias string) {
+	p.preferred[pkgPath] = alias
+}
+
+// PackageName сообщает настоящее имя пакета, если оно отличается от последнего элемента пути.
+func (p *ImportPlanner) PackageName(pkgPath, name string) {
+	p.names[pkgPath] = name
+}
+
+// Reserve резервирует имена, которые не могут быть использованы как псевдонимы импортов.
+func (p *ImportPlanner) Reserve(names ...string) {
+	for _, name := range names {
+		p.reserved[name] = struct{}{}
+	}
+}
+
+// Alias возвращает псевдоним пакета из последнего плана.
+func (p *ImportPlanner) Alias(pkgPath string) (string, bool) {
+	alias, ok := p.aliases[pkgPath]
+	return alias, ok
+}
+
+// Aliases возвращает копию последнего плана псевдонимов (путь пакета -> псевдоним).
+func (p *ImportPlanner) Aliases() map[string]string {
+	aliases := make(map[string]string, len(p.aliases))
+	for pkgPath, alias := range p.aliases {
+		aliases[pkgPath] = alias
+	}
+	return aliases
+}
+
+// Plan вычисляет псевдонимы для переданных путей пакетов.
+// Порядок разрешения: предпочтительные псевдонимы, стандартная библиотека, остальные пакеты.
+// Пакеты с совпадающим именем получают псевдоним из родительских элементов пути
+// (например, v1user и v2user), а числовой суффикс используется только в крайнем случае.
+func (p *ImportPlanner) Plan(paths []string) map[string]string {
+	paths = uniqueSorted(paths)
+
+	aliases := make(map[string]string, len(paths))
+	taken := make(map[string]struct{}, len(paths)+len(p.reserved))
+	for name := range p.reserved {
+		taken[name] = struct{}{}
+	}
+
+	isFree := func(name string) bool {
+		if name == "" || jen.IsReservedWord(name) {
+			return false
+		}
+		_, ok := taken[name]
+		return !ok
+	}
+
+	claim := func(pkgPath, name string) {
+		aliases[pkgPath] = name
+		taken[name] = struct{}{}
+	}
+
+	var stdPaths, otherPaths []string
+	for _, pkgPath := range paths {
+		if alias, ok := p.preferred[pkgPath]; ok && isFree(alias) {
+			claim(pkgPath, alias)
+			continue
+		}
+		if isStdPkg(pkgPath) {
+			stdPaths = append(stdPaths, pkgPath)
+		} else {
+			otherPaths = append(otherPaths, pkgPath)
+		}
+	}
+
+	for _, pkgPath := range stdPaths {
+		if name := p.baseName(pkgPath); isFree(name) {
+			claim(pkgPath, name)
+		} else {
+			otherPaths = append(otherPaths, pkgPath)
+		}
+	}
+
+	groups := make(map[string]int, len(otherPaths))
+	for _, pkgPath := range otherPaths {
+		groups[p.baseName(pkgPath)]++
+	}
+
+	for _, pkgPath := range otherPaths {
+		name := p.baseName(pkgPath)
+		if groups[name] == 1 && isFree(name) {
+			claim(pkgPath, name)
+			continue
+		}
+
+		candidates := qualifiedCandidates(pkgPath)
+		resolved := false
+		for _, candidate := range candidates {
+			if isFree(candidate) {
+				claim(pkgPath, candidate)
+				resolved = true
+				break
+			}
+		}
+		if resolved {
+			continue
+		}
+
+		for i := 1; ; i++ {
+			if candidate := name + strconv.Itoa(i); isFree(candidate) {
+				claim(pkgPath, candidate)
+				break
+			}
+		}
+	}
+
+	p.aliases = aliases
+
+	return p.Aliases()
+}
+
+// baseName возвращает имя пакета без учета коллизий.
+func (p *ImportPlanner) baseName(pkgPath string) string {
+	if name, ok := p.names[pkgPath]; ok {
+		return name
+	}
+	elems := pathElems(pkgPath)
+	return guessAlias(elems[len(elems)-1])
+}
+
+// isPackageName проверяет, совпадает ли псевдоним с настоящим именем пакета.
+func (p *ImportPlanner) isPackageName(pkgPath, alias string) bool {
+	if name, ok := p.names[pkgPath]; ok {
+		return name == alias
+	}
+	return isStdPkg(pkgPath) && p.baseName(pkgPath) == alias
+}
+
+// apply передает план в jen.File.
+func (p *ImportPlanner) apply(f *jen.File) {
+	for pkgPath, alias := range p.aliases {
+		if p.isPackageName(pkgPath, alias) {
+			f.ImportName(pkgPath, alias)
+		} else {
+			f.ImportAlias(pkgPath, alias)
+		}
+	}
+}
+
+// pathElems возвращает элементы пути пакета без суффикса мажорной версии модуля.
+func pathElems(pkgPath string) []string {
+	elems := strings.Split(strings.Trim(pkgPath, "/"), "/")
+	if len(elems) > 1 && majorVersionRegexp.MatchString(elems[len(elems)-1]) {
+		elems = elems[:len(elems)-1]
+	}
+	return elems
+}
+
+// qualifiedCandidates возвращает псевдонимы, дополненные родительскими элементами пути:
+// для example.com/api/v1/user это v1user, apiv1user, examplecomapiv1user.
+func qualifiedCandidates(pkgPath string) (candidates []string) {
+	elems := pathElems(pkgPath)
+	name := guessAlias(elems[len(elems)-1])
+	for i := len(elems) - 2; i >= 0; i-- { //nolint: mnd
+		name = guessAlias(elems[i]) + name
+		candidates = append(candidates, name)
+	}
+	return candidates
+}
+
+func isStdPkg(pkgPath string) bool {
+	first, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+func uniqueSorted(paths []string) []string {
+	result := make([]string, 0, len(paths))
+	seen := make(map[string]struct{}, len(paths))
+	for _, pkgPath := range paths {
+		if _, ok := seen[pkgPath]; ok {
+			continue
+		}
+		seen[pkgPath] = struct{}{}
+		result = append(result, pkgPath)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// scanImports разбирает исходный код и возвращает импортированные пакеты
+// и идентификаторы, объявленные либо используемые в файле (кроме обращений к пакетам).
+func scanImports(src []byte) (paths []string, idents []string, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	importNames := make(map[string]struct{}, len(file.Imports))
+	for _, spec := range file.Imports {
+		pkgPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = append(paths, pkgPath)
+		if spec.Name != nil {
+			importNames[spec.Name.Name] = struct{}{}
+		} else {
+			importNames[guessAlias(pathElems(pkgPath)[len(pathElems(pkgPath))-1])] = struct{}{}
+		}
+	}
+
+	// skip содержит обращения к пакетам и селекторы полей, которые не могут быть перекрыты импортом
+	skip := make(map[*ast.Ident]struct{})
+	identSet := make(map[string]struct{})
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.SelectorExpr:
+			skip[n.Sel] = struct{}{}
+			if x, ok := n.X.(*ast.Ident); ok {
+				if _, ok := importNames[x.Name]; ok {
+					skip[x] = struct{}{}
+				}
+			}
+		case *ast.Ident:
+			if _, ok := skip[n]; !ok && n != file.Name {
+				identSet[n.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	for name := range identSet {
+		idents = append(idents, name)
+	}
+	sort.Strings(idents)
+
+	return paths, idents, nil
+}
@@ -0,0 +1,153 @@
+package gomosaic
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestImportPlannerPlan(t *testing.T) {
+	type args struct {
+		paths     []string
+		preferred map[string]string
+		reserved  []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "пакеты с одинаковым именем",
+			args: args{
+				paths: []string{"example.com/api/v2/user", "example.com/api/v1/user"},
+			},
+			want: map[string]string{
+				"example.com/api/v1/user": "v1user",
+				"example.com/api/v2/user": "v2user",
+			},
+		},
+		{
+			name: "пакет не перекрывает стандартную библиотеку",
+			args: args{
+				paths: []string{"example.com/dto/http", "net/http"},
+			},
+			want: map[string]string{
+				"net/http":             "http",
+				"example.com/dto/http": "dtohttp",
+			},
+		},
+		{
+			name: "зарезервированные идентификаторы",
+			args: args{
+				paths:    []string{"example.com/api/resp", "github.com/go-mosaic/runtime/span"},
+				reserved: []string{"resp", "span"},
+			},
+			want: map[string]string{
+				"example.com/api/resp":              "apiresp",
+				"github.com/go-mosaic/runtime/span": "runtimespan",
+			},
+		},
+		{
+			name: "мажорная версия модуля",
+			args: args{
+				paths: []string{"github.com/go-chi/chi/v5", "github.com/labstack/echo/v4"},
+			},
+			want: map[string]string{
+				"github.com/go-chi/chi/v5":    "chi",
+				"github.com/labstack/echo/v4": "echo",
+			},
+		},
+		{
+			name: "предпочтительный псевдоним",
+			args: args{
+				paths:     []string{"example.com/dto/http", "net/http"},
+				preferred: map[string]string{"example.com/dto/http": "dto"},
+			},
+			want: map[string]string{
+				"net/http":             "http",
+				"example.com/dto/http": "dto",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewImportPlanner()
+			for pkgPath, alias := range tt.args.preferred {
+				p.PreferAlias(pkgPath, alias)
+			}
+			p.Reserve(tt.args.reserved...)
+
+			if got := p.Plan(tt.args.paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanImports(t *testing.T) {
+	src := []byte(`package out
+
+import (
+	span "github.com/go-mosaic/runtime/span"
+	"net/http"
+)
+
+func f(req *http.Request, r int) {
+	span := span.StartLogSpan(nil, "")
+	span.Finish(req.Context())
+}
+`)
+
+	paths, idents, err := scanImports(src)
+	if err != nil {
+		t.Fatalf("scanImports() error = %v", err)
+	}
+
+	wantPaths := []string{"github.com/go-mosaic/runtime/span", "net/http"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("scanImports() paths = %v, want %v", paths, wantPaths)
+	}
+
+	wantIdents := []string{"f", "int", "nil", "r", "req", "span"}
+	if !reflect.DeepEqual(idents, wantIdents) {
+		t.Errorf("scanImports() idents = %v, want %v", idents, wantIdents)
+	}
+}
+
+func TestGoFilePlanImports(t *testing.T) {
+	f := NewGoFile(&ModuleInfo{Path: "example.com/app", Dir: "/app"}, "/app/out")
+	f.Func().Id("handler").Params(
+		jen.Id("r").Op("*").Qual("net/http", "Request"),
+		jen.Id("dto").Op("*").Qual("example.com/dto/http", "User"),
+	).Block()
+
+	aliases, err := f.PlanImports()
+	if err != nil {
+		t.Fatalf("PlanImports() error = %v", err)
+	}
+	want := map[string]string{"net/http": "http", "example.com/dto/http": "dtohttp"}
+	if !reflect.DeepEqual(aliases, want) {
+		t.Errorf("PlanImports() = %v, want %v", aliases, want)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Render(&buf, "dev"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "dto *dtohttp.User") {
+		t.Errorf("Render() не использует псевдоним из PlanImports:\n%s", buf.String())
+	}
+}
+
+func TestGoFileRenderInvalidCode(t *testing.T) {
+	f := NewGoFile(&ModuleInfo{Path: "example.com/app", Dir: "/app"}, "/app/out")
+	f.Func().Id("broken").Params().Op("{").Block()
+
+	if err := f.Render(new(bytes.Buffer), "dev"); err == nil {
+		t.Error("Render() ошибка разбора кода не возвращена")
+	}
+}
@@ -0,0 +1,153 @@
+package gomosaic
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const (
+	keepBegin = "gomosaic:keep begin"
+	keepEnd   = "gomosaic:keep end"
+)
+
+// KeepRegion возвращает пустую защищенную область с идентификатором id.
+// Код, который пользователь добавит между маркерами
+//
+//	// gomosaic:keep begin <id>
+//	// gomosaic:keep end
+//
+// переносится из существующего файла при каждой генерации.
+func KeepRegion(id string) *jen.Statement {
+	return jen.Comment(keepBegin + " " + id).Line().Comment(keepEnd)
+}
+
+// keepRegion защищенная область файла
+type keepRegion struct {
+	id    string
+	line  int // номер строки маркера начала
+	start int // индекс первой строки содержимого
+	end   int // индекс строки маркера конца
+}
+
+// parseKeepRegions находит защищенные области в строках файла.
+// Маркеры могут находиться в комментарии любого синтаксиса (//, #, <!-- -->),
+// поэтому области поддерживаются и в текстовых файлах.
+func parseKeepRegions(lines []string) (regions []*keepRegion, err error) {
+	var (
+		current *keepRegion
+		seen    = make(map[string]bool)
+	)
+	for i, line := range lines {
+		if idx := strings.Index(line, keepBegin); idx != -1 {
+			if current != nil {
+				return nil, i18n.Errorf("keep.unclosed", current.line, current.id, keepEnd)
+			}
+			fields := strings.Fields(line[idx+len(keepBegin):])
+			if len(fields) == 0 {
+				return nil, i18n.Errorf("keep.no-id", i+1)
+			}
+			if seen[fields[0]] {
+				return nil, i18n.Errorf("keep.duplicate", i+1, fields[0])
+			}
+			seen[fields[0]] = true
+			current = &keepRegion{id: fields[0], line: i + 1, start: i + 1}
+			continue
+		}
+		if strings.Contains(line, keepEnd) {
+			if current == nil {
+				return nil, i18n.Errorf("keep.no-begin", i+1, keepEnd)
+			}
+			current.end = i
+			regions = append(regions, current)
+			current = nil
+		}
+	}
+	if current != nil {
+		return nil, i18n.Errorf("keep.unclosed", current.line, current.id, keepEnd)
+	}
+	return regions, nil
+}
+
+// mergeKeepRegions переносит содержимое защищенных областей существующего файла в сгенерированный.
+// Если область исчезла из сгенерированного кода, она вместе с непустым содержимым
+// дописывается в конец файла (в Go файлах код комментируется, чтобы файл компилировался)
+// и возвращается предупреждение. Ошибка разбора существующего файла прерывает запись,
+// чтобы не потерять пользовательский код.
+func mergeKeepRegions(path string, generated, existing []byte) (merged []byte, errs error) {
+	genLines := strings.SplitAfter(string(generated), "\n")
+	genRegions, err := parseKeepRegions(genLines)
+	if err != nil {
+		return nil, i18n.Errorf("keep.generated-invalid", path, err)
+	}
+
+	if len(existing) == 0 {
+		return generated, nil
+	}
+
+	oldLines := strings.SplitAfter(string(existing), "\n")
+	oldRegions, err := parseKeepRegions(oldLines)
+	if err != nil {
+		return nil, i18n.Errorf("keep.existing-invalid", path, err)
+	}
+
+	kept := make(map[string]*keepRegion, len(oldRegions))
+	for _, r := range oldRegions {
+		kept[r.id] = r
+	}
+
+	var b strings.Builder
+	b.Grow(len(generated) + len(existing))
+
+	next := 0
+	for _, r := range genRegions {
+		old, ok := kept[r.id]
+		if !ok {
+			continue
+		}
+		delete(kept, r.id)
+
+		b.WriteString(strings.Join(genLines[next:r.start], ""))
+		b.WriteString(strings.Join(oldLines[old.start:old.end], ""))
+		next = r.end
+	}
+	b.WriteString(strings.Join(genLines[next:], ""))
+
+	for _, r := range oldRegions {
+		if _, ok := kept[r.id]; !ok || strings.TrimSpace(strings.Join(oldLines[r.start:r.end], "")) == "" {
+			continue
+		}
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		writeOrphanRegion(&b, oldLines[r.start-1:r.end+1], strings.HasSuffix(path, ".go"))
+		errs = multierror.Append(errs, Warnf("keep.orphaned", token.Position{Filename: path, Line: r.line}, r.id))
+	}
+
+	return []byte(b.String()), errs
+}
+
+// writeOrphanRegion дописывает строки исчезнувшей области вместе с маркерами без отступа
+// маркера начала. В Go файлах строки кода комментируются: область могла находиться
+// внутри функции, и ее код вне функции не скомпилируется. Уже закомментированные строки
+// не меняются, поэтому при повторной генерации содержимое остается прежним.
+func writeOrphanRegion(b *strings.Builder, lines []string, commentCode bool) {
+	indent := lines[0][:len(lines[0])-len(strings.TrimLeft(lines[0], " \t"))]
+	for i, line := range lines {
+		line = strings.TrimPrefix(line, indent)
+		if commentCode && i > 0 && i < len(lines)-1 {
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+				line = "// " + line
+			}
+		}
+		b.WriteString(line)
+	}
+	if !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,218 @@
+package gomosaic
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeKeepRegions(t *testing.T) {
+	const generated = `package api
+
+// gomosaic:keep begin imports
+// gomosaic:keep end
+
+func Register() {
+	// gomosaic:keep begin options
+	// gomosaic:keep end
+}
+`
+
+	tests := []struct {
+		name        string
+		generated   string
+		existing    string
+		want        string
+		wantWarning string
+		wantErr     string
+	}{
+		{
+			name:      "файл еще не создан",
+			generated: generated,
+			want:      generated,
+		},
+		{
+			name:      "содержимое областей переносится",
+			generated: generated,
+			existing: `package api
+
+// gomosaic:keep begin imports
+import "net/http"
+// gomosaic:keep end
+
+func Register() {
+	// gomosaic:keep begin options
+	_ = http.MethodGet
+	// gomosaic:keep end
+	old()
+}
+`,
+			want: `package api
+
+// gomosaic:keep begin imports
+import "net/http"
+// gomosaic:keep end
+
+func Register() {
+	// gomosaic:keep begin options
+	_ = http.MethodGet
+	// gomosaic:keep end
+}
+`,
+		},
+		{
+			name:      "исчезнувшая область",
+			generated: generated,
+			existing: `package api
+
+// gomosaic:keep begin handlers
+func custom() {}
+// gomosaic:keep end
+`,
+			want: generated + `
+// gomosaic:keep begin handlers
+// func custom() {}
+// gomosaic:keep end
+`,
+			wantWarning: "api.go:3: защищенная область handlers больше не генерируется, она перенесена в конец файла",
+		},
+		{
+			name:      "исчезнувшая область внутри функции",
+			generated: generated,
+			existing: `package api
+
+func Register() {
+	// gomosaic:keep begin UserService.options
+	// авторизация
+	opt.Middleware(auth.RequireToken)
+	// gomosaic:keep end
+}
+`,
+			want: generated + `
+// gomosaic:keep begin UserService.options
+// авторизация
+// opt.Middleware(auth.RequireToken)
+// gomosaic:keep end
+`,
+			wantWarning: "api.go:4: защищенная область UserService.options больше не генерируется",
+		},
+		{
+			name:      "перенесенная область при повторной генерации",
+			generated: generated,
+			existing: generated + `
+// gomosaic:keep begin handlers
+// func custom() {}
+// gomosaic:keep end
+`,
+			want: generated + `
+// gomosaic:keep begin handlers
+// func custom() {}
+// gomosaic:keep end
+`,
+			wantWarning: "api.go:11: защищенная область handlers больше не генерируется",
+		},
+		{
+			name:      "пустая исчезнувшая область",
+			generated: generated,
+			existing: `package api
+
+// gomosaic:keep begin handlers
+// gomosaic:keep end
+`,
+			want: generated,
+		},
+		{
+			name:      "незакрытая область в существующем файле",
+			generated: generated,
+			existing: `package api
+
+// gomosaic:keep begin imports
+import "net/http"
+`,
+			wantErr: "api.go: не удалось прочитать защищенные области, исправьте файл или удалите его: строка 3: область imports не закрыта маркером \"gomosaic:keep end\"",
+		},
+		{
+			name: "повторная область в сгенерированном файле",
+			generated: `# gomosaic:keep begin a
+# gomosaic:keep end
+# gomosaic:keep begin a
+# gomosaic:keep end
+`,
+			wantErr: "api.go: сгенерированный файл содержит некорректные защищенные области: строка 3: область a уже объявлена",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeKeepRegions("api.go", []byte(tt.generated), []byte(tt.existing))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("mergeKeepRegions() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantWarning == "" && err != nil {
+				t.Fatalf("mergeKeepRegions() error = %v", err)
+			}
+			if tt.wantWarning != "" && (err == nil || hasFailed(err) || !strings.Contains(err.Error(), tt.wantWarning)) {
+				t.Fatalf("mergeKeepRegions() error = %v, want warning %s", err, tt.wantWarning)
+			}
+			if string(got) != tt.want {
+				t.Errorf("mergeKeepRegions() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSystemSaveFileKeepRegions(t *testing.T) {
+	outputDir := t.TempDir()
+	fs := NewFileSystem("dev", outputDir)
+
+	newFile := func() File {
+		f := NewGoFile(&ModuleInfo{Path: "example.com/api", Dir: outputDir}, outputDir)
+		f.Func().Id("Register").Params().Block(KeepRegion("options"))
+		return f
+	}
+
+	path, err := fs.SaveFile("api_gen.go", newFile())
+	if err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), "// gomosaic:keep end", "println(\"custom\")\n\t// gomosaic:keep end", 1)
+	if err := os.WriteFile(filepath.Join(outputDir, "api_gen.go"), []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.SaveFile("api_gen.go", newFile()); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != edited {
+		t.Errorf("защищенная область не сохранена:\n%s", data)
+	}
+
+	// Область больше не генерируется, но ее код остается в файле
+	withoutRegion := NewGoFile(&ModuleInfo{Path: "example.com/api", Dir: outputDir}, outputDir)
+	withoutRegion.Func().Id("Register").Params().Block()
+	if _, err := fs.SaveFile("api_gen.go", withoutRegion); err == nil || hasFailed(err) {
+		t.Fatalf("SaveFile() error = %v, want keep.orphaned warning", err)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "// gomosaic:keep begin options\n// println(\"custom\")\n// gomosaic:keep end\n") {
+		t.Errorf("код исчезнувшей области удален из файла:\n%s", data)
+	}
+}
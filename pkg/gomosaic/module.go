@@ -73,3 +73,18 @@ func LoadModuleInfo(goModPath string) (*ModuleInfo, error) {
 		GoVersion: modFile.Go.Version,
 	}, nil
 }
+
+// FindGoMod ищет файл go.mod в директории dir и выше
+func FindGoMod(dir string) (string, bool) {
+	for {
+		goModPath := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(goModPath); err == nil {
+			return goModPath, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,96 @@
+package gomosaic
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	limiterContextKey ContextKey = "limiter"
+	slotContextKey    ContextKey = "limiter-slot"
+)
+
+// limiter общий для всех уровней генерации семафор, ограничивающий число рабочих горутин
+type limiter chan struct{}
+
+// ContextWithConcurrency ограничивает число одновременно работающих плагинов и интерфейсов.
+// Ограничение общее для всех вложенных вызовов ParallelMap.
+func ContextWithConcurrency(ctx context.Context, n int) context.Context {
+	if n < 1 {
+		n = 1
+	}
+	return context.WithValue(ctx, limiterContextKey, make(limiter, n))
+}
+
+// ConcurrencyFromContext возвращает ограничение параллелизма (по умолчанию GOMAXPROCS)
+func ConcurrencyFromContext(ctx context.Context) int {
+	return cap(limiterFromContext(ctx))
+}
+
+var (
+	defaultLimiterOnce sync.Once
+	defaultLimiter     limiter
+)
+
+// limiterFromContext возвращает ограничитель из контекста, а без него общий
+// для процесса ограничитель на GOMAXPROCS слотов
+func limiterFromContext(ctx context.Context) limiter {
+	if l, ok := ctx.Value(limiterContextKey).(limiter); ok {
+		return l
+	}
+	defaultLimiterOnce.Do(func() {
+		defaultLimiter = make(limiter, runtime.GOMAXPROCS(0))
+	})
+	return defaultLimiter
+}
+
+// ParallelMap вызывает fn для каждого элемента и возвращает результаты в исходном порядке.
+// Каждый элемент обрабатывается горутиной, занявшей слот ограничителя. Вызов из горутины,
+// которая уже занимает слот (вложенный ParallelMap), не ждет свободного слота, а обрабатывает
+// элемент сам, поэтому одновременно работает не больше горутин, чем слотов, и вложенные
+// вызовы не могут заблокировать друг друга.
+// Ошибки всех элементов объединяются в порядке элементов, что делает вывод детерминированным.
+func ParallelMap[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error)) (results []R, errs error) {
+	results = make([]R, len(items))
+	itemErrs := make([]error, len(items))
+
+	lim := limiterFromContext(ctx)
+	holdsSlot, _ := ctx.Value(slotContextKey).(bool)
+	slotCtx := context.WithValue(ctx, slotContextKey, true)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		if holdsSlot {
+			select {
+			case lim <- struct{}{}:
+			default:
+				// Слот вызывающей горутины уже учтен в ограничителе
+				results[i], itemErrs[i] = fn(slotCtx, item)
+				continue
+			}
+		} else {
+			lim <- struct{}{}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-lim
+				wg.Done()
+			}()
+			results[i], itemErrs[i] = fn(slotCtx, item)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range itemErrs {
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return results, errs
+}
@@ -0,0 +1,112 @@
+package gomosaic
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	ctx := ContextWithConcurrency(context.Background(), 2) //nolint: mnd
+
+	items := []int{5, 4, 3, 2, 1}
+
+	var running, maxRunning atomic.Int32
+
+	results, err := ParallelMap(ctx, items, func(ctx context.Context, item int) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Duration(item) * time.Millisecond)
+
+		// вложенный вызов использует тот же ограничитель и не должен блокироваться
+		nested, err := ParallelMap(ctx, []int{item, item}, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil //nolint: mnd
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Itoa(nested[0] + nested[1]), nil
+	})
+	if err != nil {
+		t.Fatalf("ParallelMap() error = %v", err)
+	}
+
+	want := []string{"20", "16", "12", "8", "4"}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("ParallelMap() = %v, want %v", results, want)
+	}
+
+	if maxRunning.Load() > 2 { //nolint: mnd
+		t.Errorf("ParallelMap() max concurrency = %d, want <= 2", maxRunning.Load())
+	}
+}
+
+func TestParallelMapSerial(t *testing.T) {
+	ctx := ContextWithConcurrency(context.Background(), 1)
+
+	var running, maxRunning atomic.Int32
+	track := func() func() {
+		n := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		return func() { running.Add(-1) }
+	}
+
+	_, err := ParallelMap(ctx, []int{1, 2, 3}, func(ctx context.Context, item int) (int, error) {
+		done := track()
+		time.Sleep(time.Millisecond)
+		done()
+
+		// вложенный вызов выполняется в горутине, занявшей единственный слот
+		_, err := ParallelMap(ctx, []int{item, item}, func(_ context.Context, i int) (int, error) {
+			defer track()()
+			time.Sleep(time.Millisecond)
+			return i, nil
+		})
+		return item, err
+	})
+	if err != nil {
+		t.Fatalf("ParallelMap() error = %v", err)
+	}
+	if maxRunning.Load() != 1 {
+		t.Errorf("ParallelMap() max concurrency = %d, want 1", maxRunning.Load())
+	}
+}
+
+func TestParallelMapErrors(t *testing.T) {
+	ctx := ContextWithConcurrency(context.Background(), 4) //nolint: mnd
+
+	_, err := ParallelMap(ctx, []int{1, 2, 3}, func(_ context.Context, item int) (int, error) {
+		if item == 1 {
+			time.Sleep(5 * time.Millisecond) //nolint: mnd
+		}
+		if item != 2 { //nolint: mnd
+			return 0, errors.New("item " + strconv.Itoa(item))
+		}
+		return item, nil
+	})
+	if err == nil {
+		t.Fatal("ParallelMap() error = nil")
+	}
+
+	want := "2 errors occurred:\n\t* item 1\n\t* item 3\n\n"
+	if err.Error() != want {
+		t.Errorf("ParallelMap() error = %q, want %q", err.Error(), want)
+	}
+}
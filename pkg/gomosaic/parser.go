@@ -6,6 +6,7 @@ import (
 	"go/constant"
 	"go/token"
 	"go/types"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -207,6 +208,15 @@ func (ts *Annotations) Has(key string) bool {
 	return ok
 }
 
+// Value возвращает значение аннотации или пустую строку, если аннотации нет.
+// В отличие от Get может вызываться из шаблонов: {{ .Annotations.Value "http-path" }}.
+func (ts *Annotations) Value(key string) string {
+	if a, ok := ts.Get(key); ok {
+		return a.Value()
+	}
+	return ""
+}
+
 // TypeInfo информация о типе
 type NameTypeInfo struct {
 	Package     *PackageInfo  // Информация о пакете
@@ -272,6 +282,7 @@ type MethodInfo struct {
 	Doc          string      // Документация (комментарии)
 	Pos          *PosInfo    // Позиция в файле
 	Annotations  Annotations // Аннотации
+	IsVariadic   bool        // Последний параметр вариативный (...T), его тип в Params — срез []T
 	ReturnValues []*TypeAndValueInfo
 }
 
@@ -285,6 +296,7 @@ type VarInfo struct {
 	Pos         *PosInfo     // Позиция в файле
 	IsContext   bool         // Является типом context.Context
 	IsError     bool         // Является типом error
+	IsEmbedded  bool         // Встроенное поле структуры
 	Annotations Annotations  // Аннотации
 	Tags        *structtag.Tags
 }
@@ -318,9 +330,33 @@ type CommentInfo struct {
 
 // ParsePackage парсит пакет и возвращает информацию о типах
 func ParsePackage(dir string, paths []string) (nameTypesInfo []*NameTypeInfo, err error) {
-	patterns := make([]string, len(paths))
-	for i := range paths {
-		patterns[i] = "pattern=" + paths[i]
+	markedPkgPaths, _, err := findMarkedPackages(dir, packagePatterns(paths))
+	if err != nil {
+		return nil, err
+	}
+
+	nameTypesInfo, _, err = loadPackages(dir, markedPkgPaths, nil)
+	return nameTypesInfo, err
+}
+
+// LoadProgram парсит пакеты так же, как ParsePackage, и дополнительно возвращает
+// загруженную программу для плагинов, реализующих ProgramGenerator.
+// В программу попадают все пакеты по шаблонам, в том числе без маркера,
+// чтобы плагины видели, например, реализации интерфейсов из других пакетов.
+func LoadProgram(dir string, paths []string) (nameTypesInfo []*NameTypeInfo, program *Program, err error) {
+	markedPkgPaths, unmarkedPkgPaths, err := findMarkedPackages(dir, packagePatterns(paths))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return loadPackages(dir, markedPkgPaths, unmarkedPkgPaths)
+}
+
+// loadPackages загружает пакеты с маркером и пакеты программы без маркера одним вызовом,
+// чтобы типы всех пакетов были согласованы, а информация о типах разбирается только у пакетов с маркером
+func loadPackages(dir string, marked, unmarked []string) (nameTypesInfo []*NameTypeInfo, program *Program, err error) {
+	if len(marked)+len(unmarked) == 0 {
+		return make([]*NameTypeInfo, 0), NewProgram(nil), nil
 	}
 
 	cfg := &packages.Config{
@@ -328,79 +364,107 @@ func ParsePackage(dir string, paths []string) (nameTypesInfo []*NameTypeInfo, er
 		Dir:  dir,
 	}
 
-	pkgs, err := packages.Load(cfg, patterns...)
+	pkgs, err := packages.Load(cfg, append(slices.Clone(marked), unmarked...)...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	nameTypesInfo = make([]*NameTypeInfo, 0, 1024) //nolint: mnd
-
+	markedPkgs := make([]*packages.Package, 0, len(marked))
 	for _, pkg := range pkgs {
-		returnValues := parseReturnValues(pkg, pkg.Syntax)
+		if slices.Contains(marked, pkg.PkgPath) {
+			markedPkgs = append(markedPkgs, pkg)
+		}
+	}
 
-		scope := pkg.Types.Scope()
-		for _, name := range scope.Names() {
-			obj := scope.Lookup(name)
-			if !obj.Exported() {
-				continue
-			}
+	nameTypesInfo, err = ParseLoadedPackages(markedPkgs)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			named, ok := obj.Type().(*types.Named)
-			if !ok {
-				continue
-			}
+	return nameTypesInfo, NewProgram(pkgs), nil
+}
 
-			title, doc, annotations, err := findDocAndAnnotations(pkg, named.Obj().Name(), named.Obj().Pos())
-			if err != nil {
-				return nil, err
-			}
+// ParseLoadedPackages возвращает информацию о типах уже загруженных пакетов.
+// Пакеты должны быть загружены как минимум с NeedName, NeedTypes, NeedTypesInfo и NeedSyntax.
+func ParseLoadedPackages(pkgs []*packages.Package) (nameTypesInfo []*NameTypeInfo, err error) {
+	for _, p := range pkgs {
+		pkgTypesInfo, err := parseLoadedPackage(newLoadedPackage(p))
+		if err != nil {
+			return nil, err
+		}
+
+		nameTypesInfo = append(nameTypesInfo, pkgTypesInfo...)
+	}
+
+	return nameTypesInfo, nil
+}
+
+// parseLoadedPackage возвращает информацию о типах пакета, помеченных аннотацией @gomosaic
+func parseLoadedPackage(pkg *loadedPackage) (nameTypesInfo []*NameTypeInfo, err error) {
+	returnValues := parseReturnValues(pkg, pkg.Syntax)
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+
+		title, doc, annotations, err := findDocAndAnnotations(pkg, named.Obj().Name(), named.Obj().Pos())
+		if err != nil {
+			return nil, err
+		}
 
-			if !annotations.Has("gomosaic") {
+		if !annotations.Has("gomosaic") {
+			continue
+		}
+
+		typeInfo, err := typeToTypeInfo(pkg, obj.Type().Underlying())
+		if err != nil {
+			return nil, err
+		}
+
+		nameTypeInfo := &NameTypeInfo{
+			Package:     packageToPackageInfo(named.Obj().Pkg()),
+			Name:        named.Obj().Name(),
+			Title:       title,
+			Doc:         doc,
+			Pos:         parsePosition(pkg.Fset.Position(obj.Pos())),
+			Annotations: annotations,
+			Type:        typeInfo,
+		}
+
+		for i := range named.NumMethods() {
+			method := named.Method(i)
+			if !method.Exported() {
 				continue
 			}
 
-			typeInfo, err := typeToTypeInfo(pkg, obj.Type().Underlying())
+			methodInfo, err := funcToMethodInfo(pkg, method)
 			if err != nil {
 				return nil, err
 			}
 
-			nameTypeInfo := &NameTypeInfo{
-				Package:     packageToPackageInfo(named.Obj().Pkg()),
-				Name:        named.Obj().Name(),
-				Title:       title,
-				Doc:         doc,
-				Pos:         parsePosition(pkg.Fset.Position(obj.Pos())),
-				Annotations: annotations,
-				Type:        typeInfo,
+			if values, ok := returnValues[method.FullName()]; ok {
+				methodInfo.ReturnValues = values
 			}
 
-			for i := range named.NumMethods() {
-				method := named.Method(i)
-				if !method.Exported() {
-					continue
-				}
-
-				methodInfo, err := funcToMethodInfo(pkg, method)
-				if err != nil {
-					return nil, err
-				}
-
-				if values, ok := returnValues[method.FullName()]; ok {
-					methodInfo.ReturnValues = values
-				}
-
-				nameTypeInfo.Methods = append(nameTypeInfo.Methods, methodInfo)
-			}
-
-			nameTypesInfo = append(nameTypesInfo, nameTypeInfo)
+			nameTypeInfo.Methods = append(nameTypeInfo.Methods, methodInfo)
 		}
+
+		nameTypesInfo = append(nameTypesInfo, nameTypeInfo)
 	}
 
 	return nameTypesInfo, nil
 }
 
 // varToVarInfo преобразует types.Var в VarInfo
-func varToVarInfo(pkg *packages.Package, v *types.Var) (*VarInfo, error) {
+func varToVarInfo(pkg *loadedPackage, v *types.Var) (*VarInfo, error) {
 	title, doc, annotations, err := findDocAndAnnotations(pkg, v.Name(), v.Pos())
 	if err != nil {
 		return nil, err
@@ -430,7 +494,7 @@ func varToVarInfo(pkg *packages.Package, v *types.Var) (*VarInfo, error) {
 }
 
 // funcToMethodInfo преобразует types.Func в MethodInfo
-func funcToMethodInfo(pkg *packages.Package, method *types.Func) (*MethodInfo, error) {
+func funcToMethodInfo(pkg *loadedPackage, method *types.Func) (*MethodInfo, error) {
 	title, doc, annotations, err := findDocAndAnnotations(pkg, method.Name(), method.Pos())
 	if err != nil {
 		return nil, err
@@ -448,6 +512,7 @@ func funcToMethodInfo(pkg *packages.Package, method *types.Func) (*MethodInfo, e
 
 	if sig := method.Signature(); sig != nil {
 		methodInfo.ShortName = method.Name()
+		methodInfo.IsVariadic = sig.Variadic()
 		if named, ok := sig.Recv().Type().(*types.Named); ok {
 			name := named.Obj().Name()
 			if named.Obj().Pkg() != nil {
@@ -475,7 +540,7 @@ func funcToMethodInfo(pkg *packages.Package, method *types.Func) (*MethodInfo, e
 }
 
 // tuplesToVarsInfo преобразует types.Tuple в []VarInfo
-func tuplesToVarsInfo(pkg *packages.Package, tuple *types.Tuple) (varsInfo []*VarInfo, err error) {
+func tuplesToVarsInfo(pkg *loadedPackage, tuple *types.Tuple) (varsInfo []*VarInfo, err error) {
 	for i := range tuple.Len() {
 		v := tuple.At(i)
 		varInfo, err := varToVarInfo(pkg, v)
@@ -497,7 +562,7 @@ func packageToPackageInfo(pkg *types.Package) *PackageInfo {
 }
 
 // typeToTypeInfo преобразует types.Type в TypeInfo
-func typeToTypeInfo(pkg *packages.Package, t types.Type) (*TypeInfo, error) {
+func typeToTypeInfo(pkg *loadedPackage, t types.Type) (*TypeInfo, error) {
 	typeInfo := &TypeInfo{}
 
 	switch t := t.(type) {
@@ -623,6 +688,7 @@ func typeToTypeInfo(pkg *packages.Package, t types.Type) (*TypeInfo, error) {
 			if tags, err := structtag.Parse(t.Tag(i)); err == nil {
 				varInfo.Tags = tags
 			}
+			varInfo.IsEmbedded = field.Embedded()
 
 			structInfo.Fields = append(structInfo.Fields, varInfo)
 		}
@@ -722,7 +788,7 @@ func typeToTypeInfo(pkg *packages.Package, t types.Type) (*TypeInfo, error) {
 }
 
 // parseReturnValues ищет возвращаемые значения базового типа в функциях и мапит их на полное имя функции или метода структуры.
-func parseReturnValues(pkg *packages.Package, files []*ast.File) (returnValues map[string][]*TypeAndValueInfo) {
+func parseReturnValues(pkg *loadedPackage, files []*ast.File) (returnValues map[string][]*TypeAndValueInfo) {
 	returnValues = make(map[string][]*TypeAndValueInfo, 128) //nolint: mnd
 
 	for _, file := range files {
@@ -777,7 +843,7 @@ func parseReturnValues(pkg *packages.Package, files []*ast.File) (returnValues m
 }
 
 // findDocAndAnnotations находит аннотации, заголовок и описание для поля, структуры, метода по позиции в AST
-func findDocAndAnnotations(pkg *packages.Package, name string, pos token.Pos) (title, description string, annotations Annotations, err error) {
+func findDocAndAnnotations(pkg *loadedPackage, name string, pos token.Pos) (title, description string, annotations Annotations, err error) {
 	var annotationComments []*CommentInfo
 	allComments := findComments(pkg, name, pos)
 	for _, comment := range allComments {
@@ -800,28 +866,23 @@ func findDocAndAnnotations(pkg *packages.Package, name string, pos token.Pos) (t
 }
 
 // findComments находит коментарии для поля, структуры, метода по позиции в AST
-func findComments(pkg *packages.Package, name string, pos token.Pos) (commentsInfo []*CommentInfo) {
+func findComments(pkg *loadedPackage, name string, pos token.Pos) (commentsInfo []*CommentInfo) {
 	position := pkg.Fset.Position(pos)
 
-	for _, file := range pkg.Syntax {
-		for _, commentGroup := range file.Comments {
-			cg := pkg.Fset.Position(commentGroup.End())
-			if cg.Line == position.Line-1 && cg.Filename == position.Filename {
-				for _, comment := range commentGroup.List {
-					text := strings.TrimLeft(strings.TrimLeft(comment.Text, "/"), " ")
-					isTitle := strings.HasPrefix(text, name)
-					isAnnotation := strings.HasPrefix(text, "@")
-					if isTitle {
-						text = strings.ReplaceAll(text, name+" ", "")
-					}
-					commentsInfo = append(commentsInfo, &CommentInfo{
-						Value:        text,
-						IsTitle:      isTitle,
-						IsAnnotation: isAnnotation,
-						Position:     pkg.Fset.Position(comment.End()),
-					})
-				}
+	for _, commentGroup := range pkg.comments.lookup(position.Filename, position.Line-1) {
+		for _, comment := range commentGroup.List {
+			text := strings.TrimLeft(strings.TrimLeft(comment.Text, "/"), " ")
+			isTitle := strings.HasPrefix(text, name)
+			isAnnotation := strings.HasPrefix(text, "@")
+			if isTitle {
+				text = strings.ReplaceAll(text, name+" ", "")
 			}
+			commentsInfo = append(commentsInfo, &CommentInfo{
+				Value:        text,
+				IsTitle:      isTitle,
+				IsAnnotation: isAnnotation,
+				Position:     pkg.Fset.Position(comment.End()),
+			})
 		}
 	}
 
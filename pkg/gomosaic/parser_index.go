@@ -0,0 +1,197 @@
+package gomosaic
+
+import (
+	"bytes"
+	"go/ast"
+	"go/build"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// gomosaicMarker маркер, без которого в пакете не может быть типов для генерации
+var gomosaicMarker = []byte("@gomosaic")
+
+// loadedPackage загруженный пакет с индексом комментариев
+type loadedPackage struct {
+	*packages.Package
+
+	comments commentIndex
+}
+
+func newLoadedPackage(pkg *packages.Package) *loadedPackage {
+	return &loadedPackage{
+		Package:  pkg,
+		comments: newCommentIndex(pkg),
+	}
+}
+
+// commentIndex индекс групп комментариев по имени файла и строке окончания группы
+type commentIndex map[string]map[int][]*ast.CommentGroup
+
+// newCommentIndex строит индекс комментариев один раз для всех файлов пакета
+func newCommentIndex(pkg *packages.Package) commentIndex {
+	idx := make(commentIndex, len(pkg.Syntax))
+
+	for _, file := range pkg.Syntax {
+		for _, commentGroup := range file.Comments {
+			end := pkg.Fset.Position(commentGroup.End())
+
+			lines, ok := idx[end.Filename]
+			if !ok {
+				lines = make(map[int][]*ast.CommentGroup, len(file.Comments))
+				idx[end.Filename] = lines
+			}
+
+			lines[end.Line] = append(lines[end.Line], commentGroup)
+		}
+	}
+
+	return idx
+}
+
+// lookup возвращает группы комментариев, которые заканчиваются на указанной строке файла
+func (idx commentIndex) lookup(filename string, line int) []*ast.CommentGroup {
+	return idx[filename][line]
+}
+
+// findMarkedPackages выполняет дешевую загрузку пакетов без синтаксиса и типов
+// и разделяет пути пакетов на те, в файлах которых встречается маркер @gomosaic, и остальные.
+func findMarkedPackages(dir string, patterns []string) (marked, unmarked []string, err error) {
+	markedPkgs, unmarkedPkgs, err := loadPackageList(dir, patterns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return packagePaths(markedPkgs), packagePaths(unmarkedPkgs), nil
+}
+
+// loadPackageList делает то же, что findMarkedPackages, и возвращает пакеты
+// с путем импорта и директорией для сопоставления с шаблонами через packageMatcher
+func loadPackageList(dir string, patterns []string) (marked, unmarked []*packages.Package, err error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, pkg := range pkgs {
+		ok, err := hasMarker(pkg.GoFiles)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if ok {
+			marked = append(marked, pkg)
+		} else {
+			unmarked = append(unmarked, pkg)
+		}
+	}
+
+	return marked, unmarked, nil
+}
+
+func packagePaths(pkgs []*packages.Package) []string {
+	pkgPaths := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgPaths = append(pkgPaths, pkg.PkgPath)
+	}
+	return pkgPaths
+}
+
+// packageMatcher возвращает функцию, которая проверяет, подходит ли пакет под шаблон go list.
+// Шаблоны путей файловой системы (./svc/..., /abs/path) сравниваются с директорией пакета
+// относительно dir, остальные с путем импорта. Как и в go list, /... в конце шаблона
+// подходит и для самого пакета.
+func packageMatcher(dir, pattern string) func(pkg *packages.Package) bool {
+	name := func(pkg *packages.Package) string { return pkg.PkgPath }
+	if build.IsLocalImport(pattern) || filepath.IsAbs(pattern) {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		pattern = filepath.ToSlash(pattern)
+		name = func(pkg *packages.Package) string { return filepath.ToSlash(pkg.Dir) }
+	}
+
+	re := regexp.QuoteMeta(pattern)
+	if strings.HasSuffix(re, `/\.\.\.`) {
+		re = strings.TrimSuffix(re, `/\.\.\.`) + `(/\.\.\.)?`
+	}
+	re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+	reg := regexp.MustCompile(`^` + re + `$`)
+
+	return func(pkg *packages.Package) bool {
+		return reg.MatchString(name(pkg))
+	}
+}
+
+// hasMarker проверяет наличие маркера @gomosaic хотя бы в одном из файлов
+func hasMarker(filenames []string) (bool, error) {
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return false, err
+		}
+
+		if bytes.Contains(data, gomosaicMarker) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// PackageFiles возвращает Go файлы пакетов, подходящих под переданные шаблоны.
+// Используется для отслеживания изменений входных пакетов без полной загрузки типов.
+func PackageFiles(dir string, paths []string) (filenames []string, err error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, packagePatterns(paths)...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		filenames = append(filenames, pkg.GoFiles...)
+	}
+
+	return filenames, nil
+}
+
+// MarkedPackageFiles возвращает Go файлы пакетов, подходящих под переданные шаблоны,
+// раздельно для пакетов с маркером @gomosaic и остальных. Типы разбираются только
+// из помеченных пакетов, поэтому изменения остальных не влияют на генерацию,
+// пока в них не появится маркер.
+func MarkedPackageFiles(dir string, paths []string) (marked, unmarked []string, err error) {
+	markedPkgs, unmarkedPkgs, err := loadPackageList(dir, packagePatterns(paths))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, pkg := range markedPkgs {
+		marked = append(marked, pkg.GoFiles...)
+	}
+	for _, pkg := range unmarkedPkgs {
+		unmarked = append(unmarked, pkg.GoFiles...)
+	}
+
+	return marked, unmarked, nil
+}
+
+func packagePatterns(paths []string) []string {
+	patterns := make([]string, len(paths))
+	for i := range paths {
+		patterns[i] = "pattern=" + paths[i]
+	}
+	return patterns
+}
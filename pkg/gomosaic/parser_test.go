@@ -0,0 +1,151 @@
+package gomosaic
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// makeSource генерирует исходный код пакета с ifaceCount аннотированными интерфейсами.
+func makeSource(ifaceCount, methodCount int) string {
+	var sb strings.Builder
+	sb.WriteString("package svc\n\n")
+	for i := range ifaceCount {
+		fmt.Fprintf(&sb, "// Service%d сервис номер %d\n// Описание сервиса\n// @gomosaic\n// @http-client-enable\n", i, i)
+		fmt.Fprintf(&sb, "type Service%d interface {\n", i)
+		for j := range methodCount {
+			fmt.Fprintf(&sb, "\t// Method%d метод\n\t// @http-method GET\n\t// @http-path /items/:id\n", j)
+			fmt.Fprintf(&sb, "\tMethod%d(id int, name string) (result string, err error)\n", j)
+		}
+		sb.WriteString("}\n\n")
+		fmt.Fprintf(&sb, "// Request%d запрос\ntype Request%d struct {\n\t// ID идентификатор\n\t// @http-name id\n\tID int\n}\n\n", i, i)
+	}
+	return sb.String()
+}
+
+func loadTestPackage(tb testing.TB, src string) *packages.Package {
+	tb.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "svc.go", src, parser.ParseComments)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+
+	pkg, err := new(types.Config).Check("example.com/svc", fset, []*ast.File{file}, info)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return &packages.Package{
+		Name:      pkg.Name(),
+		PkgPath:   pkg.Path(),
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     pkg,
+		TypesInfo: info,
+	}
+}
+
+func TestParseLoadedPackage(t *testing.T) {
+	pkg := loadTestPackage(t, makeSource(2, 3)) //nolint: mnd
+
+	nameTypesInfo, err := parseLoadedPackage(newLoadedPackage(pkg))
+	if err != nil {
+		t.Fatalf("parseLoadedPackage() error = %v", err)
+	}
+
+	if len(nameTypesInfo) != 2 {
+		t.Fatalf("parseLoadedPackage() len = %d, want 2", len(nameTypesInfo))
+	}
+
+	iface := nameTypesInfo[0]
+	if iface.Title != "сервис номер 0" {
+		t.Errorf("parseLoadedPackage() Title = %q, want %q", iface.Title, "сервис номер 0")
+	}
+
+	if !iface.Annotations.Has("http-client-enable") {
+		t.Errorf("parseLoadedPackage() annotation http-client-enable not found")
+	}
+
+	methods := iface.Type.Interface.Methods
+	if len(methods) != 3 {
+		t.Fatalf("parseLoadedPackage() methods len = %d, want 3", len(methods))
+	}
+
+	if a, ok := methods[0].Annotations.Get("http-path"); !ok || a.Value() != "/items/:id" {
+		t.Errorf("parseLoadedPackage() method annotation http-path = %v, want /items/:id", a)
+	}
+}
+
+func TestHasMarker(t *testing.T) {
+	dir := t.TempDir()
+
+	marked := filepath.Join(dir, "marked.go")
+	plain := filepath.Join(dir, "plain.go")
+
+	if err := os.WriteFile(marked, []byte("package svc\n\n// @gomosaic\ntype S interface{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plain, []byte("package svc\n\ntype T struct{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := hasMarker([]string{plain}); err != nil || ok {
+		t.Errorf("hasMarker(plain) = %v, %v, want false", ok, err)
+	}
+
+	if ok, err := hasMarker([]string{plain, marked}); err != nil || !ok {
+		t.Errorf("hasMarker(plain, marked) = %v, %v, want true", ok, err)
+	}
+}
+
+func TestMarkedPackageFiles(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":       "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go":   "package svc\n\n// @gomosaic\ntype UserService interface{}\n",
+		"svc/types.go": "package svc\n\ntype User struct{}\n",
+		"impl/impl.go": "package impl\n\ntype userService struct{}\n",
+		"impl/README":  "не Go файл\n",
+	})
+
+	marked, unmarked, err := MarkedPackageFiles(dir, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMarked := []string{filepath.Join(dir, "svc", "svc.go"), filepath.Join(dir, "svc", "types.go")}
+	if !slices.Equal(marked, wantMarked) {
+		t.Errorf("marked = %v, want %v", marked, wantMarked)
+	}
+	if wantUnmarked := []string{filepath.Join(dir, "impl", "impl.go")}; !slices.Equal(unmarked, wantUnmarked) {
+		t.Errorf("unmarked = %v, want %v", unmarked, wantUnmarked)
+	}
+}
+
+func BenchmarkParseLoadedPackage(b *testing.B) {
+	pkg := loadTestPackage(b, makeSource(100, 10)) //nolint: mnd
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := parseLoadedPackage(newLoadedPackage(pkg)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
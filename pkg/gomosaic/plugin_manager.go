@@ -1,8 +1,11 @@
 package gomosaic
 
 import (
-	"fmt"
+	"maps"
 	"plugin"
+	"sort"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
 var DefaultPluginManager = NewPluginManager()
@@ -23,23 +26,29 @@ func NewPluginManager() *PluginManager {
 func (pm *PluginManager) LoadPlugin(path string) error {
 	plug, err := plugin.Open(path)
 	if err != nil {
-		return fmt.Errorf("не удалось загрузить плагин: %w", err)
+		return i18n.Errorf("plugin.load", err)
 	}
 
 	sym, err := plug.Lookup("Plugin")
 	if err != nil {
-		return fmt.Errorf("символ плагина не найден: %w", err)
+		return i18n.Errorf("plugin.symbol", err)
 	}
 
 	generator, ok := sym.(Generator)
 	if !ok {
-		return fmt.Errorf("недопустимый тип плагина")
+		return i18n.Errorf("plugin.type")
 	}
 
 	pm.plugins[generator.Name()] = generator
 	return nil
 }
 
+// Clone возвращает копию менеджера с теми же плагинами,
+// регистрация плагинов в копии не затрагивает исходный менеджер
+func (pm *PluginManager) Clone() *PluginManager {
+	return &PluginManager{plugins: maps.Clone(pm.plugins)}
+}
+
 func (pm *PluginManager) RegisterPlugin(plugin Generator) {
 	pm.plugins[plugin.Name()] = plugin
 }
@@ -48,11 +57,23 @@ func (pm *PluginManager) RegisterPlugin(plugin Generator) {
 func (pm *PluginManager) GetPlugin(name string) (Generator, error) {
 	plugin, exists := pm.plugins[name]
 	if !exists {
-		return nil, fmt.Errorf("плагин %s не найден", name)
+		return nil, i18n.Errorf("plugin.not-found", name)
 	}
 	return plugin, nil
 }
 
+// Plugins возвращает зарегистрированные плагины, отсортированные по имени
+func (pm *PluginManager) Plugins() []Generator {
+	plugins := make([]Generator, 0, len(pm.plugins))
+	for _, plugin := range pm.plugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name() < plugins[j].Name()
+	})
+	return plugins
+}
+
 func RegisterPlugin(plugin Generator) {
 	DefaultPluginManager.RegisterPlugin(plugin)
 }
@@ -0,0 +1,81 @@
+package gomosaic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+const (
+	pluginOptionsContextKey ContextKey = "plugin_options"
+)
+
+// Configurable необязательный интерфейс плагина с параметрами, передаваемыми через
+// --opt plugin.key=value или конфигурацию. Options возвращает указатель на структуру
+// параметров с тегами option, по которой строится справка и проверяются имена параметров.
+type Configurable interface {
+	Options() any
+}
+
+// PluginOptions параметры плагина, переданные из командной строки или конфигурации
+type PluginOptions struct {
+	Plugin string            // Имя плагина
+	Values map[string]string // Значения параметров в синтаксисе аннотаций
+}
+
+// ContextWithPluginOptions передает плагину его параметры
+func ContextWithPluginOptions(ctx context.Context, opts *PluginOptions) context.Context {
+	return context.WithValue(ctx, pluginOptionsContextKey, opts)
+}
+
+// PluginOptionsFromContext возвращает параметры плагина (пустые, если параметры не переданы)
+func PluginOptionsFromContext(ctx context.Context) *PluginOptions {
+	if opts, ok := ctx.Value(pluginOptionsContextKey).(*PluginOptions); ok {
+		return opts
+	}
+	return &PluginOptions{}
+}
+
+// Annotations представляет параметры в виде аннотаций с префиксом,
+// чтобы их можно было декодировать тем же механизмом тегов option, что и аннотации в коде.
+// Значение разбирается как аргументы аннотации: "a b key=value".
+func (o *PluginOptions) Annotations(prefix string) (annotations Annotations, err error) {
+	keys := make([]string, 0, len(o.Values))
+	for key := range o.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		a, err := annotation.Parse("@" + prefix + "-" + key + " " + o.Values[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.position(key), err)
+		}
+		annotations = append(annotations, &AnnotationInfo{
+			Annotation: a,
+			Position:   &PosInfo{Filename: o.position(key)},
+		})
+	}
+
+	return annotations, nil
+}
+
+func (o *PluginOptions) position(key string) string {
+	return "--opt " + o.Plugin + "." + key
+}
+
+// ParsePluginOption разбирает параметр вида plugin.key=value
+func ParsePluginOption(s string) (plugin, key, value string, err error) {
+	name, value, ok := strings.Cut(s, "=")
+	if ok {
+		plugin, key, ok = strings.Cut(name, ".")
+	}
+	if !ok || plugin == "" || key == "" {
+		return "", "", "", i18n.Errorf("plugin.option-format", s)
+	}
+	return plugin, key, value, nil
+}
@@ -0,0 +1,63 @@
+package gomosaic
+
+import "testing"
+
+func TestParsePluginOption(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		wantPlugin string
+		wantKey    string
+		wantValue  string
+		wantErr    bool
+	}{
+		{
+			name:       "параметр со значением",
+			s:          "http-client.filename=api_client.go",
+			wantPlugin: "http-client",
+			wantKey:    "filename",
+			wantValue:  "api_client.go",
+		},
+		{
+			name:       "значение с точкой и знаком равенства",
+			s:          "http-client.tags=a=b.c",
+			wantPlugin: "http-client",
+			wantKey:    "tags",
+			wantValue:  "a=b.c",
+		},
+		{
+			name:       "пустое значение",
+			s:          "log-middleware.enabled=",
+			wantPlugin: "log-middleware",
+			wantKey:    "enabled",
+		},
+		{
+			name:    "без значения",
+			s:       "http-client.filename",
+			wantErr: true,
+		},
+		{
+			name:    "без имени плагина",
+			s:       ".filename=a.go",
+			wantErr: true,
+		},
+		{
+			name:    "без имени параметра",
+			s:       "http-client=a.go",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, key, value, err := ParsePluginOption(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePluginOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if plugin != tt.wantPlugin || key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("ParsePluginOption() = %q, %q, %q, want %q, %q, %q",
+					plugin, key, value, tt.wantPlugin, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
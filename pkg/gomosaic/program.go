@@ -0,0 +1,111 @@
+package gomosaic
+
+import (
+	"context"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ProgramGenerator необязательный интерфейс плагина, которому нужна полная типизированная программа:
+// поиск реализаций интерфейсов, методов DTO, чтение тел функций и т.п.
+// Если плагин реализует ProgramGenerator, вместо Generate вызывается GenerateProgram.
+// Результаты таких плагинов не кешируются, так как зависят не только от модели.
+type ProgramGenerator interface {
+	Generator
+	// GenerateProgram Генерация файлов на основе информации о модуле, типах и загруженной программе
+	GenerateProgram(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo, program *Program) (map[string]File, error)
+}
+
+// Program загруженные пакеты и сопоставление модели с объектами go/types
+type Program struct {
+	Packages []*packages.Package // Пакеты по шаблонам задач, включая пакеты без маркера @gomosaic
+
+	once     sync.Once
+	typesPkg map[string]*types.Package
+}
+
+// NewProgram создает программу из загруженных пакетов
+func NewProgram(pkgs []*packages.Package) *Program {
+	return &Program{Packages: pkgs}
+}
+
+// Package возвращает загруженный пакет по пути (nil, если пакет не загружен)
+func (p *Program) Package(path string) *packages.Package {
+	for _, pkg := range p.Packages {
+		if pkg.PkgPath == path {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// Object возвращает объект go/types для типа, найденного парсером
+func (p *Program) Object(t *NameTypeInfo) types.Object {
+	if t == nil || t.Package == nil {
+		return nil
+	}
+	return p.lookup(t.Package.Path, t.Name)
+}
+
+// TypeObject возвращает объект go/types для именованного типа, в том числе по указателю на него.
+// Для базовых типов и error возвращается объект из universe.
+func (p *Program) TypeObject(t *TypeInfo) types.Object {
+	for t != nil && t.IsPtr && !t.IsNamed {
+		t = t.ElemType
+	}
+	if t == nil || t.Name == "" {
+		return nil
+	}
+	if t.Package == "" {
+		return types.Universe.Lookup(t.Name)
+	}
+	return p.lookup(t.Package, t.Name)
+}
+
+// Method возвращает объект метода интерфейса или именованного типа
+func (p *Program) Method(t *NameTypeInfo, m *MethodInfo) *types.Func {
+	obj := p.Object(t)
+	if obj == nil || m == nil {
+		return nil
+	}
+
+	method, _, _ := types.LookupFieldOrMethod(obj.Type(), true, obj.Pkg(), m.Name)
+	fn, _ := method.(*types.Func)
+	return fn
+}
+
+func (p *Program) lookup(path, name string) types.Object {
+	p.once.Do(p.indexPackages)
+
+	pkg, ok := p.typesPkg[path]
+	if !ok {
+		return nil
+	}
+	return pkg.Scope().Lookup(name)
+}
+
+// indexPackages индексирует загруженные пакеты и все импортируемые ими пакеты,
+// чтобы находить типы, объявленные за пределами пакетов с аннотациями (например, DTO).
+func (p *Program) indexPackages() {
+	p.typesPkg = make(map[string]*types.Package)
+
+	var visit func(pkg *types.Package)
+	visit = func(pkg *types.Package) {
+		if pkg == nil {
+			return
+		}
+		if _, ok := p.typesPkg[pkg.Path()]; ok {
+			return
+		}
+		p.typesPkg[pkg.Path()] = pkg
+		for _, imp := range pkg.Imports() {
+			visit(imp)
+		}
+	}
+
+	for _, pkg := range p.Packages {
+		visit(pkg.Types)
+	}
+}
@@ -0,0 +1,181 @@
+package gomosaic
+
+import (
+	"context"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const programSource = `package svc
+
+// @gomosaic
+type UserService interface {
+	GetUser(id int) (*User, error)
+}
+
+type User struct {
+	ID int
+}
+
+func (u *User) Validate() error { return nil }
+
+type userService struct{}
+
+func (s *userService) GetUser(id int) (*User, error) { return nil, nil }
+
+type otherService struct{}
+`
+
+// implementationsPlugin перечисляет типы программы, реализующие интерфейсы с аннотацией
+type implementationsPlugin struct{}
+
+func (p *implementationsPlugin) Name() string { return "implementations" }
+
+func (p *implementationsPlugin) Generate(context.Context, *ModuleInfo, []*NameTypeInfo) (map[string]File, error) {
+	panic("должен вызываться GenerateProgram")
+}
+
+func (p *implementationsPlugin) GenerateProgram(ctx context.Context, module *ModuleInfo, nameTypes []*NameTypeInfo, program *Program) (map[string]File, error) {
+	f := NewGoFile(module, OutputDirFromContext(ctx))
+	for _, t := range nameTypes {
+		iface, _ := program.Object(t).Type().Underlying().(*types.Interface)
+		if iface == nil {
+			continue
+		}
+
+		var names []string
+		for _, pkg := range program.Packages {
+			scope := pkg.Types.Scope()
+			for _, name := range scope.Names() {
+				if obj, ok := scope.Lookup(name).(*types.TypeName); ok && types.Implements(types.NewPointer(obj.Type()), iface) {
+					names = append(names, name)
+				}
+			}
+		}
+		sort.Strings(names)
+		f.Comment(t.Name + ": " + strings.Join(names, ", "))
+	}
+	return map[string]File{"implementations.go": f}, nil
+}
+
+func TestProgramLookup(t *testing.T) {
+	pkg := loadTestPackage(t, programSource)
+	program := NewProgram([]*packages.Package{pkg})
+
+	nameTypes, err := ParseLoadedPackages([]*packages.Package{pkg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userService := nameTypes[0]
+	getUser := userService.Type.Interface.Methods[0]
+
+	if obj := program.Object(userService); obj == nil || obj.Name() != "UserService" {
+		t.Fatalf("Object() = %v, want UserService", obj)
+	}
+
+	if fn := program.Method(userService, getUser); fn == nil || fn.Name() != "GetUser" {
+		t.Errorf("Method() = %v, want GetUser", fn)
+	}
+
+	userType := getUser.Results[0].Type
+	obj := program.TypeObject(userType)
+	if obj == nil || obj.Name() != "User" {
+		t.Fatalf("TypeObject(%s) = %v, want User", userType, obj)
+	}
+
+	validate, _, _ := types.LookupFieldOrMethod(obj.Type(), true, obj.Pkg(), "Validate")
+	if validate == nil {
+		t.Error("метод Validate у DTO не найден")
+	}
+
+	if obj := program.TypeObject(getUser.Results[1].Type); obj != types.Universe.Lookup("error") {
+		t.Errorf("TypeObject(error) = %v, want universe error", obj)
+	}
+}
+
+func TestGenerateProgram(t *testing.T) {
+	pkg := loadTestPackage(t, programSource)
+
+	nameTypes, err := ParseLoadedPackages([]*packages.Package{pkg})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(new(implementationsPlugin))
+
+	outputDir := t.TempDir()
+	ctx := ContextWithOutputDir(context.Background(), outputDir)
+	module := &ModuleInfo{Path: "example.com", Dir: outputDir}
+
+	cg := NewCodeGenerator(pm, NewFileSystem("dev", outputDir))
+	if _, err := cg.GenerateAll(ctx, module, nameTypes, []string{"implementations"}); err == nil {
+		t.Fatal("GenerateAll() без программы должен вернуть ошибку")
+	}
+
+	cg = NewCodeGenerator(pm, NewFileSystem("dev", outputDir), WithProgram(NewProgram([]*packages.Package{pkg})))
+	if _, err := cg.GenerateAll(ctx, module, nameTypes, []string{"implementations"}); err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "implementations.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// UserService: userService\n"; !strings.Contains(string(data), want) {
+		t.Errorf("implementations.go = %q, want %q", data, want)
+	}
+}
+
+func TestRunProgramUnmarkedPackages(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go": "package svc\n\n// @gomosaic\ntype UserService interface {\n\tGetUser(id int) error\n}\n",
+		// Реализация в пакете без маркера должна попасть в программу
+		"impl/impl.go": "package impl\n\nimport \"example.com/app/svc\"\n\nvar _ svc.UserService = (*userService)(nil)\n\n" +
+			"type userService struct{}\n\nfunc (s *userService) GetUser(id int) error { return nil }\n",
+	})
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(new(implementationsPlugin))
+
+	cfg := &Config{
+		ModFile: filepath.Join(dir, "go.mod"),
+		Jobs: []*JobConfig{
+			{Plugins: []string{"implementations"}, Packages: []string{"./..."}, Output: filepath.Join(dir, "gen")},
+		},
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "gen"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Run(context.Background(), cfg, RunWithPluginManager(pm)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "gen", "implementations.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// UserService: userService\n"; !strings.Contains(string(data), want) {
+		t.Errorf("implementations.go = %q, want %q", data, want)
+	}
+
+	// Модель по-прежнему строится только по пакетам с маркером
+	nameTypes, program, err := LoadProgram(dir, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nameTypes) != 1 || nameTypes[0].Name != "UserService" {
+		t.Errorf("LoadProgram() = %d типов, want UserService", len(nameTypes))
+	}
+	if program.Package("example.com/app/impl") == nil {
+		t.Error("пакет без маркера не загружен в программу")
+	}
+}
@@ -0,0 +1,192 @@
+package gomosaic
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+// RunOption параметр программного запуска генерации
+type RunOption func(*runOpts)
+
+type runOpts struct {
+	version       string
+	pluginManager *PluginManager
+	cache         *Cache
+	typeCheck     bool
+}
+
+// RunWithVersion задает версию, которая записывается в заголовок сгенерированных файлов
+func RunWithVersion(version string) RunOption {
+	return func(o *runOpts) {
+		o.version = version
+	}
+}
+
+// RunWithPluginManager задает менеджер плагинов (по умолчанию DefaultPluginManager,
+// встроенные плагины регистрируются в нем импортом пакета github.com/go-mosaic/gomosaic/pkg/plugins)
+func RunWithPluginManager(pm *PluginManager) RunOption {
+	return func(o *runOpts) {
+		o.pluginManager = pm
+	}
+}
+
+// RunWithCache включает кеш генерации (по умолчанию кеш не используется)
+func RunWithCache(cache *Cache) RunOption {
+	return func(o *runOpts) {
+		o.cache = cache
+	}
+}
+
+// RunWithTypeCheck включает проверку типов сгенерированного кода
+func RunWithTypeCheck() RunOption {
+	return func(o *runOpts) {
+		o.typeCheck = true
+	}
+}
+
+// Run выполняет все задачи конфигурации без командной строки и возвращает результаты
+// плагинов в порядке задач. Относительные пути разрешаются от текущей директории.
+// Аббревиатуры из конфигурации действуют только в этом запуске и передаются плагинам через CaserFromContext.
+// Ошибки проверки типов возвращаются вместе с результатами уже сохраненных файлов.
+// Предупреждения задач не останавливают генерацию и возвращаются вместе после всех задач.
+// Число одновременно работающих плагинов ограничивается ContextWithConcurrency.
+// Пакеты загружаются до запуска задач, общий для нескольких задач пакет разбирается один раз.
+func Run(ctx context.Context, cfg *Config, opts ...RunOption) (results []*GenerateResult, err error) {
+	o := &runOpts{
+		version:       "dev",
+		pluginManager: DefaultPluginManager,
+	}
+	for _, optApply := range opts {
+		optApply(o)
+	}
+
+	ctx = ContextWithCaser(ctx, strcase.New(cfg.Initialisms...))
+
+	modfile := cfg.ModFile
+	if modfile == "" {
+		modfile = "go.mod"
+	}
+
+	modfile, err = filepath.Abs(modfile)
+	if err != nil {
+		return nil, err
+	}
+
+	moduleInfo, err := LoadModuleInfo(modfile)
+	if err != nil {
+		return nil, err
+	}
+
+	jobTypes, program, err := loadJobs(filepath.Dir(modfile), cfg.Jobs, needsProgram(o.pluginManager, cfg.Jobs))
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics error
+	for i, job := range cfg.Jobs {
+		jobResults, err := runJob(ctx, o, moduleInfo, program, jobTypes[i], job)
+		results = append(results, jobResults...)
+		if hasFailed(err) {
+			return results, multierror.Append(diagnostics, err)
+		}
+		if err != nil {
+			diagnostics = multierror.Append(diagnostics, err)
+		}
+	}
+
+	return results, diagnostics
+}
+
+// needsProgram проверяет, есть ли среди плагинов задач ProgramGenerator
+func needsProgram(pm *PluginManager, jobs []*JobConfig) bool {
+	for _, job := range jobs {
+		for _, name := range job.Plugins {
+			if plugin, err := pm.GetPlugin(name); err == nil {
+				if _, ok := plugin.(ProgramGenerator); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// loadJobs загружает пакеты всех задач одним вызовом, чтобы пакет, используемый
+// в нескольких задачах, разбирался один раз, и возвращает модель каждой задачи.
+// Пакеты с маркером ищутся один раз по объединению шаблонов всех задач,
+// а затем распределяются по задачам сопоставлением с их шаблонами.
+// Если withProgram, в программу загружаются и пакеты по шаблонам без маркера.
+func loadJobs(dir string, jobs []*JobConfig, withProgram bool) (jobTypes [][]*NameTypeInfo, program *Program, err error) {
+	var patterns []string
+	for _, job := range jobs {
+		for _, pattern := range job.Packages {
+			if !slices.Contains(patterns, pattern) {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	marked, unmarked, err := loadPackageList(dir, packagePatterns(patterns))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkgPaths := packagePaths(marked)
+	var programPaths []string
+	if withProgram {
+		programPaths = packagePaths(unmarked)
+	}
+
+	nameTypesInfo, program, err := loadPackages(dir, pkgPaths, programPaths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byPackage := make(map[string][]*NameTypeInfo, len(pkgPaths))
+	for _, t := range nameTypesInfo {
+		byPackage[t.Package.Path] = append(byPackage[t.Package.Path], t)
+	}
+
+	jobTypes = make([][]*NameTypeInfo, len(jobs))
+	for i, job := range jobs {
+		matchers := make([]func(*packages.Package) bool, 0, len(job.Packages))
+		for _, pattern := range job.Packages {
+			matchers = append(matchers, packageMatcher(dir, pattern))
+		}
+
+		jobTypes[i] = make([]*NameTypeInfo, 0)
+		for _, pkg := range marked {
+			if slices.ContainsFunc(matchers, func(match func(*packages.Package) bool) bool { return match(pkg) }) {
+				jobTypes[i] = append(jobTypes[i], byPackage[pkg.PkgPath]...)
+			}
+		}
+	}
+
+	return jobTypes, program, nil
+}
+
+func runJob(ctx context.Context, o *runOpts, moduleInfo *ModuleInfo, program *Program, nameTypesInfo []*NameTypeInfo, job *JobConfig) ([]*GenerateResult, error) {
+	outputDir, err := filepath.Abs(job.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	cgOpts := []CodeGeneratorOption{WithProgram(program), WithPluginOptions(job.Options)}
+	if o.cache != nil {
+		cgOpts = append(cgOpts, WithCache(o.cache))
+	}
+	if o.typeCheck {
+		cgOpts = append(cgOpts, WithTypeCheck())
+	}
+
+	fs := NewFileSystem(o.version, outputDir)
+	cg := NewCodeGenerator(o.pluginManager, fs, cgOpts...)
+
+	return cg.GenerateAll(ContextWithOutputDir(ctx, outputDir), moduleInfo, nameTypesInfo, job.Plugins)
+}
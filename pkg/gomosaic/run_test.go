@@ -0,0 +1,169 @@
+package gomosaic
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+	"github.com/go-mosaic/gomosaic/pkg/strcase"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+// stubPlugin генерирует пустую функцию для каждого типа
+type stubPlugin struct{}
+
+func (p *stubPlugin) Name() string { return "stub" }
+
+func (p *stubPlugin) Generate(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo) (map[string]File, error) {
+	f := NewGoFile(module, OutputDirFromContext(ctx))
+	for _, t := range types {
+		f.Func().Id("Stub" + t.Name).Params().Block()
+	}
+	return map[string]File{"stub_gen.go": f}, nil
+}
+
+// writeModule создает модуль из файлов и возвращает его директорию
+func writeModule(tb testing.TB, files map[string]string) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRunContinuesAfterWarnings(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go": "package svc\n\n// @gomosaic\ntype UserService interface{}\n",
+		// Защищенная область, которую плагин больше не генерирует, дает предупреждение keep.orphaned
+		"first/stub_gen.go": "package first\n\n// gomosaic:keep begin old\nvar custom = 1\n// gomosaic:keep end\n",
+	})
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(new(stubPlugin))
+
+	cfg := &Config{
+		ModFile: filepath.Join(dir, "go.mod"),
+		Jobs: []*JobConfig{
+			{Plugins: []string{"stub"}, Packages: []string{"./svc"}, Output: filepath.Join(dir, "first")},
+			{Plugins: []string{"stub"}, Packages: []string{"./svc"}, Output: filepath.Join(dir, "second")},
+		},
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "second"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Run(context.Background(), cfg, RunWithPluginManager(pm))
+	if hasFailed(err) {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var merr *multierror.Error
+	if !errors.As(err, &merr) || len(merr.Errors) != 1 || MessageID(merr.Errors[0]) != "keep.orphaned" {
+		t.Errorf("Run() diagnostics = %v, want keep.orphaned", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Run() = %d результатов, want 2", len(results))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "second", "stub_gen.go")); err != nil {
+		t.Errorf("вторая задача не выполнена: %v", err)
+	}
+}
+
+// caserPlugin запоминает имя, построенное преобразователем регистра запуска
+type caserPlugin struct {
+	name string
+}
+
+func (p *caserPlugin) Name() string { return "caser" }
+
+func (p *caserPlugin) Generate(ctx context.Context, _ *ModuleInfo, _ []*NameTypeInfo) (map[string]File, error) {
+	p.name = CaserFromContext(ctx).ToCamel("grpc_client")
+	return nil, nil
+}
+
+func TestRunInitialismsDoNotLeak(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go": "package svc\n\n// @gomosaic\ntype UserService interface{}\n",
+	})
+
+	plugin := new(caserPlugin)
+	pm := NewPluginManager()
+	pm.RegisterPlugin(plugin)
+
+	run := func(initialisms ...string) string {
+		t.Helper()
+		cfg := &Config{
+			ModFile:     filepath.Join(dir, "go.mod"),
+			Initialisms: initialisms,
+			Jobs: []*JobConfig{
+				{Plugins: []string{"caser"}, Packages: []string{"./svc"}, Output: filepath.Join(dir, "out")},
+			},
+		}
+		if _, err := Run(context.Background(), cfg, RunWithPluginManager(pm)); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		return plugin.name
+	}
+
+	if got := run("GRPC"); got != "GRPCClient" {
+		t.Errorf("запуск с аббревиатурой GRPC: ToCamel() = %q, want GRPCClient", got)
+	}
+	if got := run(); got != "GrpcClient" {
+		t.Errorf("аббревиатура предыдущего запуска попала в следующий: ToCamel() = %q, want GrpcClient", got)
+	}
+	if got := strcase.ToCamel("grpc_client"); got != "GrpcClient" {
+		t.Errorf("аббревиатура запуска попала в общую таблицу strcase: ToCamel() = %q, want GrpcClient", got)
+	}
+}
+
+func TestLoadJobs(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod":         "module example.com/app\n\ngo 1.22\n",
+		"svc/a/a.go":     "package a\n\n// @gomosaic\ntype A interface{}\n",
+		"svc/b/b.go":     "package b\n\n// @gomosaic\ntype B interface{}\n",
+		"other/other.go": "package other\n\n// @gomosaic\ntype Other interface{}\n",
+		"plain/plain.go": "package plain\n\ntype Plain interface{}\n",
+	})
+
+	jobs := []*JobConfig{
+		{Packages: []string{"./svc/..."}},
+		{Packages: []string{"example.com/app/other", "./plain"}},
+		{Packages: []string{"./svc/a", "example.com/app/svc/..."}},
+	}
+
+	jobTypes, _, err := loadJobs(dir, jobs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"A", "B"}, {"Other"}, {"A", "B"}}
+	for i, types := range jobTypes {
+		var names []string
+		for _, typ := range types {
+			names = append(names, typ.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(want[i], ",") {
+			t.Errorf("задача %d: типы %v, want %v", i, names, want[i])
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package gomosaic
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// typeCheckMode режим загрузки пакета вывода для проверки типов
+const typeCheckMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax
+
+// typeCheck проверяет типы пакета вывода, подменяя сгенерированные файлы их содержимым из памяти.
+// Ошибки в сгенерированных файлах сопоставляются с методами интерфейсов и их аннотациями
+// по отметкам генераторов (DeclSourceRecorder), ошибки в остальных файлах пакета не сообщаются.
+func typeCheck(outputDir string, files []*renderedFile) (errs error) {
+	overlay := make(map[string][]byte, len(files))
+	sources := make(map[string]map[string]*DeclSource, len(files))
+	hasTests := false
+	for _, file := range files {
+		if !strings.HasSuffix(file.filename, ".go") {
+			continue
+		}
+		filename := filepath.Join(outputDir, file.filename)
+		overlay[filename] = file.data
+		sources[filename] = file.sources
+		hasTests = hasTests || strings.HasSuffix(file.filename, "_test.go")
+	}
+
+	if len(overlay) == 0 {
+		return nil
+	}
+
+	cfg := &packages.Config{
+		Mode:    typeCheckMode,
+		Dir:     outputDir,
+		Overlay: overlay,
+		Tests:   hasTests,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return Warnf("typecheck.failed", token.Position{}, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			pos := parseErrorPos(e.Pos)
+			if _, ok := overlay[pos.Filename]; !ok || seen[e.Error()] {
+				continue
+			}
+			seen[e.Error()] = true
+
+			errs = multierror.Append(errs, newTypeCheckError(pkg, e, pos, sources[pos.Filename]))
+		}
+	}
+
+	return errs
+}
+
+// newTypeCheckError создает диагностику, указывающую на метод или тип, из-за которого
+// был сгенерирован некомпилируемый код. Ошибки импорта считаются предупреждениями,
+// так как обычно означают, что в модуль не добавлены зависимости.
+func newTypeCheckError(pkg *packages.Package, e packages.Error, pos token.Position, sources map[string]*DeclSource) error {
+	if e.Kind == packages.ListError || strings.Contains(e.Msg, "could not import") {
+		return Warnf("typecheck.import", token.Position{}, e.Error())
+	}
+
+	if source := findDeclSource(enclosingDeclNames(pkg, pos), sources); source != nil {
+		nameTypeInfo, method := source.NameTypeInfo, source.Method
+		if method != nil {
+			return Errorf("typecheck.method", method.Pos, e.Error(), nameTypeInfo.Name+"."+method.Name+annotationKeys(method.Annotations))
+		}
+		return Errorf("typecheck.type", nameTypeInfo.Pos, e.Error(), nameTypeInfo.Name+annotationKeys(nameTypeInfo.Annotations))
+	}
+
+	return Errorf("typecheck.error", &PosInfo{IsValid: true, Filename: pos.Filename, Line: pos.Line, Column: pos.Column}, e.Error())
+}
+
+// parseErrorPos разбирает позицию ошибки вида file:line:column.
+// Номера строки и колонки берутся с конца, так как имя файла может содержать двоеточие.
+func parseErrorPos(s string) (pos token.Position) {
+	pos.Filename = s
+	for range 2 {
+		idx := strings.LastIndex(pos.Filename, ":")
+		if idx < 0 {
+			break
+		}
+		n, err := strconv.Atoi(pos.Filename[idx+1:])
+		if err != nil {
+			break
+		}
+		pos.Column, pos.Line = pos.Line, n
+		pos.Filename = pos.Filename[:idx]
+	}
+	return pos
+}
+
+// enclosingDeclNames возвращает имена объявления, содержащего позицию, в порядке поиска отметок:
+// "Тип.Метод" и тип получателя для методов, имя функции, либо имена типов, констант и переменных.
+func enclosingDeclNames(pkg *packages.Package, pos token.Position) (names []string) {
+	for _, file := range pkg.Syntax {
+		if pkg.Fset.Position(file.Pos()).Filename != pos.Filename {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			if pkg.Fset.Position(decl.Pos()).Line > pos.Line || pkg.Fset.Position(decl.End()).Line < pos.Line {
+				continue
+			}
+
+			return declNames(decl)
+		}
+	}
+
+	return nil
+}
+
+func declNames(decl ast.Decl) (names []string) {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv != nil && len(decl.Recv.List) > 0 {
+			if name := recvTypeName(decl.Recv.List[0].Type); name != "" {
+				return []string{name + "." + decl.Name.Name, name}
+			}
+		}
+		names = append(names, decl.Name.Name)
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func recvTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// findDeclSource возвращает отметку первого из имен объявления, для которого она есть
+func findDeclSource(declNames []string, sources map[string]*DeclSource) *DeclSource {
+	for _, name := range declNames {
+		if source, ok := sources[name]; ok && source.NameTypeInfo != nil {
+			return source
+		}
+	}
+	return nil
+}
+
+func annotationKeys(annotations Annotations) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(annotations))
+	for _, a := range annotations {
+		keys = append(keys, "@"+a.Key)
+	}
+
+	return i18n.T("typecheck.annotations", strings.Join(uniqueSorted(keys), ", "))
+}
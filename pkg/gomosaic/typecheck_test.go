@@ -0,0 +1,156 @@
+package gomosaic
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestParseErrorPos(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want token.Position
+	}{
+		{name: "строка и колонка", s: "/out/gen.go:12:5", want: token.Position{Filename: "/out/gen.go", Line: 12, Column: 5}},
+		{name: "только строка", s: "/out/gen.go:12", want: token.Position{Filename: "/out/gen.go", Line: 12}},
+		{name: "двоеточие в имени файла", s: "C:/out/gen.go:3:1", want: token.Position{Filename: "C:/out/gen.go", Line: 3, Column: 1}},
+		{name: "без позиции", s: "-", want: token.Position{Filename: "-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseErrorPos(tt.s); got != tt.want {
+				t.Errorf("parseErrorPos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDeclSource(t *testing.T) {
+	get := &MethodInfo{Name: "Get"}
+	getUser := &MethodInfo{Name: "GetUser"}
+	userService := &NameTypeInfo{Name: "UserService", Type: &TypeInfo{Interface: &InterfaceInfo{Methods: []*MethodInfo{get, getUser}}}}
+
+	f := NewGoFile(&ModuleInfo{}, "out")
+	f.SetDeclSource("GetterOpts", userService, nil)
+	f.SetDeclSource("UserServiceLogMiddleware", userService, nil)
+	f.SetDeclSource("UserServiceLogMiddleware.Get", userService, get)
+	f.SetDeclSource("UserServiceLogMiddleware.GetUser", userService, getUser)
+	sources := f.DeclSources()
+
+	src := `package out
+
+type GetterOpts struct{}
+
+func (o *GetterOpts) Apply() {}
+
+func (m *UserServiceLogMiddleware) Get() {}
+
+func (m *UserServiceLogMiddleware) GetUser() {}
+
+func (m *UserServiceLogMiddleware) Other() {}
+
+func helper() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "gen.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		decl       int
+		wantType   string
+		wantMethod string
+	}{
+		{name: "имя типа содержит имя метода", decl: 0, wantType: "UserService"},
+		{name: "метод отмеченного типа", decl: 1, wantType: "UserService"},
+		{name: "отмеченный метод", decl: 2, wantType: "UserService", wantMethod: "Get"},
+		{name: "метод с более длинным именем", decl: 3, wantType: "UserService", wantMethod: "GetUser"},
+		{name: "неотмеченный метод отмеченного получателя", decl: 4, wantType: "UserService"},
+		{name: "не отмечено", decl: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotType, gotMethod string
+			if source := findDeclSource(declNames(file.Decls[tt.decl]), sources); source != nil {
+				gotType = source.NameTypeInfo.Name
+				if source.Method != nil {
+					gotMethod = source.Method.Name
+				}
+			}
+
+			if gotType != tt.wantType || gotMethod != tt.wantMethod {
+				t.Errorf("findDeclSource() = %s.%s, want %s.%s", gotType, gotMethod, tt.wantType, tt.wantMethod)
+			}
+		})
+	}
+}
+
+// brokenPlugin генерирует для каждого метода интерфейса код, который не компилируется
+type brokenPlugin struct{}
+
+func (p *brokenPlugin) Name() string { return "broken" }
+
+func (p *brokenPlugin) Generate(ctx context.Context, module *ModuleInfo, types []*NameTypeInfo) (map[string]File, error) {
+	f := NewGoFile(module, OutputDirFromContext(ctx))
+	for _, t := range types {
+		f.SetDeclSource(t.Name+"Broken", t, nil)
+		f.Type().Id(t.Name + "Broken").Struct()
+		for _, m := range t.Type.Interface.Methods {
+			f.SetDeclSource(t.Name+"Broken."+m.Name, t, m)
+			f.Func().Params(jen.Id("b").Op("*").Id(t.Name + "Broken")).Id(m.Name).Params().Block(
+				jen.Var().Id("_").Int().Op("=").Lit("not an int"),
+			)
+		}
+	}
+	return map[string]File{"broken_gen.go": f}, nil
+}
+
+func TestRunTypeCheckMapsErrorToMethod(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.22\n",
+		"svc/svc.go": "package svc\n\n// @gomosaic\ntype UserService interface {\n" +
+			"\t// @broken-mode fast\n\tGetUser(id int) error\n}\n",
+	})
+	outputDir := filepath.Join(dir, "gen")
+	if err := os.MkdirAll(outputDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewPluginManager()
+	pm.RegisterPlugin(new(brokenPlugin))
+
+	cfg := &Config{
+		ModFile: filepath.Join(dir, "go.mod"),
+		Jobs:    []*JobConfig{{Plugins: []string{"broken"}, Packages: []string{"./svc"}, Output: outputDir}},
+	}
+
+	_, err := Run(context.Background(), cfg, RunWithPluginManager(pm), RunWithTypeCheck())
+	if !hasFailed(err) {
+		t.Fatalf("Run() error = %v, want ошибку проверки типов", err)
+	}
+
+	diags := Diagnostics(err)
+	if len(diags) != 1 || MessageID(diags[0]) != "typecheck.method" {
+		t.Fatalf("Run() diagnostics = %v, want typecheck.method", diags)
+	}
+
+	pos, message := DiagnosticPosition(diags[0])
+	want := token.Position{Filename: filepath.Join(dir, "svc", "svc.go"), Line: 6, Column: 2}
+	if pos != want {
+		t.Errorf("позиция = %v, want %v (метод GetUser)", pos, want)
+	}
+	if !strings.Contains(message, "UserService.GetUser") || !strings.Contains(message, "@broken-mode") {
+		t.Errorf("сообщение = %q, want метод UserService.GetUser и аннотацию @broken-mode", message)
+	}
+}
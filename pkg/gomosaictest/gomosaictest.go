@@ -0,0 +1,396 @@
+// Package gomosaictest содержит средства для тестирования плагинов gomosaic.
+//
+// Пакеты-фикстуры располагаются в GOPATH-подобной директории testdata/src/<путь пакета>.
+// Плагин запускается так, будто директорией вывода является директория пакета-фикстуры,
+// а каждый сгенерированный файл сравнивается с эталоном <имя файла>.golden рядом с ним.
+// Эталоны обновляются запуском тестов с флагом -update.
+//
+// Ожидаемые диагностики описываются комментариями вида
+//
+//	// want "регулярное выражение"
+//
+// на строке, к которой относится ошибка или предупреждение плагина. Если диагностика
+// относится к другой строке (например, к аннотации в doc-комментарии), перед шаблонами
+// указывается смещение строки: // want -1 "регулярное выражение".
+package gomosaictest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+// Update перезаписывает эталонные файлы результатами генерации
+var Update = flag.Bool("update", false, "обновить эталонные файлы gomosaictest")
+
+const (
+	goldenExt = ".golden"
+	version   = "test"
+
+	// concurrency число слотов ParallelMap независимо от GOMAXPROCS,
+	// чтобы тесты с -race проверяли параллельную генерацию интерфейсов
+	concurrency = 4
+)
+
+// Testing часть интерфейса testing.TB, используемая пакетом
+type Testing interface {
+	Errorf(format string, args ...any)
+	Helper()
+}
+
+// Result результат запуска плагина для одного пакета-фикстуры
+type Result struct {
+	Pkg   *packages.Package // Пакет-фикстура
+	Files map[string][]byte // Сгенерированные файлы (с нормализованным заголовком)
+	Err   error             // Ошибки и предупреждения плагина
+}
+
+// TestData возвращает абсолютный путь к директории testdata текущего пакета
+func TestData() string {
+	testdata, err := filepath.Abs("testdata")
+	if err != nil {
+		panic(err)
+	}
+	return testdata
+}
+
+// Run загружает пакеты-фикстуры из testdata/src, запускает плагин для каждого пакета,
+// сравнивает сгенерированные файлы с эталонами и проверяет ожидаемые диагностики.
+func Run(t Testing, testdata string, gen gomosaic.Generator, patterns ...string) []*Result {
+	return run(t, testdata, gen, false, nil, patterns)
+}
+
+// RunWithOptions делает то же, что и Run, передавая плагину параметры,
+// как если бы они были заданы флагами --opt plugin.key=value
+func RunWithOptions(t Testing, testdata string, gen gomosaic.Generator, options map[string]string, patterns ...string) []*Result {
+	return run(t, testdata, gen, false, options, patterns)
+}
+
+// RunWithTypeCheck делает то же, что и Run, и дополнительно проверяет типы
+// сгенерированного кода вместе с исходным кодом пакета-фикстуры.
+func RunWithTypeCheck(t Testing, testdata string, gen gomosaic.Generator, patterns ...string) []*Result {
+	return run(t, testdata, gen, true, nil, patterns)
+}
+
+func run(t Testing, testdata string, gen gomosaic.Generator, typeCheck bool, options map[string]string, patterns []string) (results []*Result) {
+	t.Helper()
+
+	pkgs, err := loadPackages(testdata, nil, patterns)
+	if err != nil {
+		t.Errorf("не удалось загрузить пакеты %v: %v", patterns, err)
+		return nil
+	}
+
+	module := &gomosaic.ModuleInfo{Dir: filepath.Join(testdata, "src")}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			t.Errorf("пакет %s содержит ошибки: %v", pkg.PkgPath, pkg.Errors)
+			continue
+		}
+
+		result := generate(t, module, gen, options, pkg)
+		if result == nil {
+			continue
+		}
+		results = append(results, result)
+
+		checkDiagnostics(t, pkg, result.Err)
+		checkGolden(t, pkg, result.Files)
+
+		if typeCheck && len(result.Files) > 0 {
+			checkTypes(t, testdata, pkg, result.Files)
+		}
+	}
+
+	return results
+}
+
+func loadPackages(testdata string, overlay map[string][]byte, patterns []string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:     filepath.Join(testdata, "src"),
+		Env:     append(os.Environ(), "GOPATH="+testdata, "GO111MODULE=off", "GOPROXY=off", "GOFLAGS="),
+		Overlay: overlay,
+		Tests:   overlay != nil,
+	}
+
+	return packages.Load(cfg, patterns...)
+}
+
+func generate(t Testing, module *gomosaic.ModuleInfo, gen gomosaic.Generator, options map[string]string, pkg *packages.Package) *Result {
+	types, err := gomosaic.ParseLoadedPackages([]*packages.Package{pkg})
+	if err != nil {
+		t.Errorf("%s: не удалось разобрать пакет: %v", pkg.PkgPath, err)
+		return nil
+	}
+
+	outputDir := packageDir(pkg)
+	ctx := gomosaic.ContextWithOutputDir(context.Background(), outputDir)
+	ctx = gomosaic.ContextWithPluginOptions(ctx, &gomosaic.PluginOptions{Plugin: gen.Name(), Values: options})
+	ctx = gomosaic.ContextWithConcurrency(ctx, concurrency)
+
+	var (
+		files  map[string]gomosaic.File
+		genErr error
+	)
+	if programGen, ok := gen.(gomosaic.ProgramGenerator); ok {
+		program := gomosaic.NewProgram([]*packages.Package{pkg})
+		files, genErr = programGen.GenerateProgram(ctx, module, types, program)
+	} else {
+		files, genErr = gen.Generate(ctx, module, types)
+	}
+
+	result := &Result{Pkg: pkg, Files: make(map[string][]byte, len(files)), Err: genErr}
+
+	fs := gomosaic.NewFileSystem(version, outputDir)
+	for filename, file := range files {
+		data, err := fs.RenderFile(file)
+		if err != nil {
+			t.Errorf("%s: %s: %v", pkg.PkgPath, filename, err)
+			continue
+		}
+		result.Files[filename] = normalizeHeader(data)
+	}
+
+	return result
+}
+
+var headerRe = regexp.MustCompile(`(?m)\A// Code generated by .*; DO NOT EDIT\.$`)
+
+// normalizeHeader убирает из заголовка аргументы командной строки тестового бинарника
+func normalizeHeader(data []byte) []byte {
+	return headerRe.ReplaceAll(data, []byte("// Code generated by gomosaic; DO NOT EDIT."))
+}
+
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) == 0 {
+		return ""
+	}
+	return filepath.Dir(pkg.GoFiles[0])
+}
+
+func checkGolden(t Testing, pkg *packages.Package, files map[string][]byte) {
+	t.Helper()
+
+	dir := packageDir(pkg)
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		golden := filepath.Join(dir, filename+goldenExt)
+
+		if *Update {
+			if err := os.WriteFile(golden, files[filename], 0o600); err != nil {
+				t.Errorf("не удалось обновить эталон %s: %v", golden, err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("%s: эталон не найден, запустите тест с флагом -update: %v", golden, err)
+			continue
+		}
+
+		if got := files[filename]; !bytes.Equal(got, want) {
+			t.Errorf("%s: сгенерированный код отличается от эталона:\n%s", golden, lineDiff(string(want), string(got)))
+		}
+	}
+}
+
+func checkTypes(t Testing, testdata string, pkg *packages.Package, files map[string][]byte) {
+	t.Helper()
+
+	dir := packageDir(pkg)
+
+	overlay := make(map[string][]byte, len(files))
+	for filename, data := range files {
+		if strings.HasSuffix(filename, ".go") {
+			overlay[filepath.Join(dir, filename)] = data
+		}
+	}
+
+	pkgs, err := loadPackages(testdata, overlay, []string{pkg.PkgPath})
+	if err != nil {
+		t.Errorf("%s: не удалось проверить типы сгенерированного кода: %v", pkg.PkgPath, err)
+		return
+	}
+
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			t.Errorf("%s: ошибка в сгенерированном коде: %v", pkg.PkgPath, e)
+		}
+	})
+}
+
+// expectation ожидаемая диагностика
+type expectation struct {
+	pos token.Position
+	re  *regexp.Regexp
+	met bool
+}
+
+func checkDiagnostics(t Testing, pkg *packages.Package, err error) {
+	t.Helper()
+
+	expectations, parseErr := parseExpectations(pkg)
+	if parseErr != nil {
+		t.Errorf("%s: %v", pkg.PkgPath, parseErr)
+		return
+	}
+
+	for _, diag := range Diagnostics(err) {
+		pos := diagnosticPosition(diag)
+		matched := false
+		for _, exp := range expectations {
+			if exp.met || !samePosition(exp.pos, pos) {
+				continue
+			}
+			if exp.re.MatchString(diag.Error()) {
+				exp.met, matched = true, true
+				break
+			}
+		}
+		if !matched {
+			t.Errorf("неожиданная диагностика: %v", diag)
+		}
+	}
+
+	for _, exp := range expectations {
+		if !exp.met {
+			t.Errorf("%s: ожидаемая диагностика не найдена: %s", exp.pos, exp.re)
+		}
+	}
+}
+
+// Diagnostics разворачивает ошибки плагина в плоский список диагностик
+func Diagnostics(err error) []error {
+	return gomosaic.Diagnostics(err)
+}
+
+var positionRe = regexp.MustCompile(`([^\s:]+\.go):(\d+)(?::(\d+))?`)
+
+// diagnosticPosition возвращает позицию диагностики: из ошибок gomosaic напрямую,
+// из остальных ошибок по тексту вида file.go:line:column.
+func diagnosticPosition(err error) token.Position {
+	if pos, _ := gomosaic.DiagnosticPosition(err); pos.IsValid() {
+		return pos
+	}
+
+	m := positionRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return token.Position{}
+	}
+
+	line, _ := strconv.Atoi(m[2])
+	return token.Position{Filename: m[1], Line: line}
+}
+
+func samePosition(a, b token.Position) bool {
+	return a.Line == b.Line && a.Filename != "" && b.Filename != "" &&
+		(a.Filename == b.Filename || filepath.Base(a.Filename) == filepath.Base(b.Filename))
+}
+
+// parseExpectations собирает комментарии // want из файлов пакета
+func parseExpectations(pkg *packages.Package) (expectations []*expectation, err error) {
+	for _, file := range pkg.Syntax {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				text, ok := strings.CutPrefix(comment.Text, "//")
+				if !ok {
+					continue
+				}
+				text, ok = strings.CutPrefix(strings.TrimSpace(text), "want ")
+				if !ok {
+					continue
+				}
+
+				pos := pkg.Fset.Position(comment.Pos())
+
+				exps, err := parseWant(pos, text)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", pos, err)
+				}
+				expectations = append(expectations, exps...)
+			}
+		}
+	}
+
+	return expectations, nil
+}
+
+// parseWant разбирает необязательное смещение строки и список шаблонов в кавычках
+func parseWant(pos token.Position, text string) (expectations []*expectation, err error) {
+	text = strings.TrimSpace(text)
+
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		offset, rest, _ := strings.Cut(text, " ")
+		n, err := strconv.Atoi(offset)
+		if err != nil {
+			return nil, fmt.Errorf("неверное смещение строки %q", offset)
+		}
+		pos.Line += n
+		text = strings.TrimSpace(rest)
+	}
+
+	for text != "" {
+		quoted, err := strconv.QuotedPrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("ожидается шаблон в кавычках: %s", text)
+		}
+		pattern, _ := strconv.Unquote(quoted)
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		expectations = append(expectations, &expectation{pos: pos, re: re})
+
+		text = strings.TrimSpace(text[len(quoted):])
+	}
+
+	if len(expectations) == 0 {
+		return nil, fmt.Errorf("не указан ни один шаблон")
+	}
+
+	return expectations, nil
+}
+
+// lineDiff возвращает построчное отличие строк начиная с первой отличающейся строки
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("строка %d:\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,92 @@
+package gomosaictest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaictest"
+)
+
+// stubPlugin генерирует функцию с именем из аннотации для каждого метода интерфейса
+type stubPlugin struct{}
+
+func (p *stubPlugin) Name() string { return "stub" }
+
+func (p *stubPlugin) Generate(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) (map[string]gomosaic.File, error) {
+	f := gomosaic.NewGoFile(module, gomosaic.OutputDirFromContext(ctx))
+
+	var errs error
+	for _, t := range types {
+		for _, m := range t.Type.Interface.Methods {
+			if !t.Annotations.Has("stub-name") && (len(m.Params) == 0 || !m.Params[0].IsContext) {
+				errs = multierror.Append(errs, gomosaic.Error("первым параметром должен быть context.Context", m.Pos))
+				continue
+			}
+			if a, ok := m.Annotations.Get("stub-name"); ok && a.Value() == "" {
+				errs = multierror.Append(errs, fmt.Errorf("имя метода не указано: %s", a.Position))
+				continue
+			}
+			f.Func().Id(t.Name+m.Name).Params().Qual("fmt", "Stringer").Block(jen.Return(jen.Nil()))
+		}
+	}
+
+	return map[string]gomosaic.File{"stub_gen.go": f}, errs
+}
+
+func TestRun(t *testing.T) {
+	gomosaictest.RunWithTypeCheck(t, gomosaictest.TestData(), new(stubPlugin), "a", "b")
+}
+
+// recorder запоминает сообщения об ошибках вместо провала теста
+type recorder struct {
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestRunReportsMismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  gomosaic.Generator
+		wantErr string
+	}{
+		{
+			name:    "ожидаемая диагностика не найдена",
+			plugin:  new(silentPlugin),
+			wantErr: "ожидаемая диагностика не найдена",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := new(recorder)
+			gomosaictest.Run(r, gomosaictest.TestData(), tt.plugin, "a")
+
+			for _, e := range r.errs {
+				if strings.Contains(e, tt.wantErr) {
+					return
+				}
+			}
+			t.Errorf("Run() errors = %v, want %q", r.errs, tt.wantErr)
+		})
+	}
+}
+
+// silentPlugin не сообщает ни о каких диагностиках и ничего не генерирует
+type silentPlugin struct{}
+
+func (p *silentPlugin) Name() string { return "silent" }
+
+func (p *silentPlugin) Generate(context.Context, *gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo) (map[string]gomosaic.File, error) {
+	return nil, nil
+}
@@ -0,0 +1,9 @@
+package a
+
+import "context"
+
+// @gomosaic
+type Service interface {
+	Get(ctx context.Context, id int) (string, error)
+	Put(id int) error // want "первым параметром должен быть context.Context"
+}
@@ -0,0 +1,10 @@
+package b
+
+// Greeter приветствует пользователей
+// @gomosaic
+// @stub-name Hello
+type Greeter interface {
+	// @stub-name
+	// want -1 "имя метода не указано"
+	Greet(name string) string
+}
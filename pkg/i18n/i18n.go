@@ -0,0 +1,164 @@
+// Package i18n каталог сообщений gomosaic: сообщения имеют устойчивые идентификаторы
+// (например, fs.write), а тексты на каждом языке регистрируются отдельно. Язык выбирается
+// флагом --lang или переменными окружения LC_ALL, LC_MESSAGES, LANG.
+// Собственный дистрибутив может добавить язык или сообщения своих команд через Register.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Lang язык сообщений
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// DefaultLang язык по умолчанию для командной строки и вызовов из библиотеки, если локаль
+	// не задана, равна C, POSIX или не поддерживается. На нем же выводятся сообщения без перевода
+	// на текущий язык
+	DefaultLang = English
+)
+
+var (
+	mu      sync.RWMutex
+	current = DefaultLang
+	catalog = make(map[Lang]map[string]string)
+)
+
+// Register добавляет сообщения языка lang: идентификатор -> формат fmt.
+// Повторная регистрация идентификатора заменяет текст.
+func Register(lang Lang, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if catalog[lang] == nil {
+		catalog[lang] = make(map[string]string, len(messages))
+	}
+	for id, format := range messages {
+		catalog[lang][id] = format
+	}
+}
+
+// SetLang задает текущий язык сообщений
+func SetLang(lang Lang) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = lang
+}
+
+// CurrentLang возвращает текущий язык сообщений
+func CurrentLang() Lang {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Langs возвращает зарегистрированные языки
+func Langs() (langs []Lang) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for lang := range catalog {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}
+
+// T возвращает текст сообщения id на текущем языке, подставляя args как в fmt.Sprintf.
+// Если перевода нет, используется DefaultLang, если нет и его - сам идентификатор.
+func T(id string, args ...any) string {
+	mu.RLock()
+	format, ok := catalog[current][id]
+	if !ok {
+		format, ok = catalog[DefaultLang][id]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		format = id
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Error ошибка с идентификатором сообщения, текст переводится при выводе,
+// поэтому ошибки, созданные при инициализации пакетов, выводятся на выбранном языке
+type Error struct {
+	ID   string
+	Args []any
+}
+
+// Errorf возвращает ошибку с сообщением id. Ошибки среди args доступны через errors.Is и errors.As.
+func Errorf(id string, args ...any) error {
+	return &Error{ID: id, Args: args}
+}
+
+func (e *Error) Error() string {
+	return T(e.ID, e.Args...)
+}
+
+// MessageID возвращает идентификатор сообщения
+func (e *Error) MessageID() string {
+	return e.ID
+}
+
+func (e *Error) Unwrap() (errs []error) {
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ParseLang разбирает язык из значения флага или переменной окружения: en, en_US.UTF-8, ru-RU.
+// Значения C, POSIX и незарегистрированные языки не распознаются.
+func ParseLang(s string) (Lang, bool) {
+	s = strings.ToLower(s)
+	if i := strings.IndexAny(s, "_-.@"); i >= 0 {
+		s = s[:i]
+	}
+	lang := Lang(s)
+
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := catalog[lang]
+	return lang, ok
+}
+
+// Detect выбирает язык по флагу --lang среди аргументов командной строки,
+// затем по переменным окружения LC_ALL, LC_MESSAGES и LANG. Если язык не распознан,
+// возвращается DefaultLang.
+func Detect(args []string, getenv func(string) string) Lang {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		value, ok := strings.CutPrefix(arg, "--lang=")
+		if !ok && arg == "--lang" && i+1 < len(args) {
+			value, ok = args[i+1], true
+		}
+		if ok {
+			if lang, ok := ParseLang(value); ok {
+				return lang
+			}
+		}
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := getenv(name); value != "" {
+			if lang, ok := ParseLang(value); ok {
+				return lang
+			}
+			// Первая заданная переменная определяет язык, как в setlocale
+			break
+		}
+	}
+	return DefaultLang
+}
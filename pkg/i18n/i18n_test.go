@@ -0,0 +1,133 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want Lang
+	}{
+		{
+			name: "по умолчанию",
+			want: DefaultLang,
+		},
+		{
+			name: "флаг через пробел",
+			args: []string{"--lang", "en", "codegen"},
+			env:  map[string]string{"LANG": "ru_RU.UTF-8"},
+			want: English,
+		},
+		{
+			name: "флаг через равно",
+			args: []string{"codegen", "--lang=en"},
+			want: English,
+		},
+		{
+			name: "флаг после --",
+			args: []string{"--", "--lang=en"},
+			want: DefaultLang,
+		},
+		{
+			name: "переменная LANG",
+			env:  map[string]string{"LANG": "en_US.UTF-8"},
+			want: English,
+		},
+		{
+			name: "LC_ALL важнее LANG",
+			env:  map[string]string{"LC_ALL": "ru_RU.UTF-8", "LANG": "en_US.UTF-8"},
+			want: Russian,
+		},
+		{
+			name: "локаль C",
+			env:  map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"},
+			want: DefaultLang,
+		},
+		{
+			name: "локаль C без других переменных",
+			env:  map[string]string{"LANG": "C"},
+			want: English,
+		},
+		{
+			name: "неизвестный язык во флаге",
+			args: []string{"--lang", "fr"},
+			env:  map[string]string{"LANG": "en"},
+			want: English,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.args, func(name string) string { return tt.env[name] })
+			if got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLang(CurrentLang())
+
+	Register(English, map[string]string{"test.only-en": "only %s"})
+	t.Cleanup(func() { delete(catalog[English], "test.only-en") })
+
+	SetLang(English)
+	if got := T("fs.write", "отказано"); got != "failed to create file: отказано" {
+		t.Errorf("T() = %q", got)
+	}
+
+	SetLang(Russian)
+	if got := T("test.only-en", "in English"); got != "only in English" {
+		t.Errorf("T() без перевода = %q", got)
+	}
+	if got := T("test.missing"); got != "test.missing" {
+		t.Errorf("T() без сообщения = %q", got)
+	}
+}
+
+func TestError(t *testing.T) {
+	defer SetLang(CurrentLang())
+
+	err := Errorf("fs.read", fs.ErrNotExist)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is() = false")
+	}
+
+	// Текст ошибки переводится при выводе, а не при создании
+	SetLang(Russian)
+	ru := err.Error()
+	SetLang(English)
+	if en := err.Error(); en == ru || en != "failed to read file: file does not exist" {
+		t.Errorf("Error() = %q, ru %q", en, ru)
+	}
+}
+
+var verbRe = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogParity(t *testing.T) {
+	ru, en := catalog[Russian], catalog[English]
+
+	for id, format := range ru {
+		translation, ok := en[id]
+		if !ok {
+			t.Errorf("%s: нет перевода на английский", id)
+			continue
+		}
+		if want, got := verbRe.FindAllString(format, -1), verbRe.FindAllString(translation, -1); !slices.Equal(want, got) {
+			t.Errorf("%s: параметры перевода %v, ожидается %v", id, got, want)
+		}
+	}
+	for id := range en {
+		if _, ok := ru[id]; !ok {
+			t.Errorf("%s: нет сообщения на русском", id)
+		}
+	}
+}
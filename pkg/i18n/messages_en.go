@@ -0,0 +1,294 @@
+package i18n
+
+// Сообщения на английском языке
+func init() {
+	Register(English, map[string]string{
+		"signature.missing-context": "the first parameter must be of type context.Context",
+		"signature.missing-error":   "the last result must be of type error",
+		"signature.invalid":         "Invalid method signature, %v",
+
+		"annotation.unknown":            "unknown annotation @%s for %s",
+		"annotation.unknown-suggestion": "unknown annotation @%s for %s, did you mean @%s",
+		"annotation.marker.title":       "Marks a type for generation",
+		"annotation.marker.descr":       "Types without this annotation are not added to the model and are not processed by plugins",
+		"annotation.nolint.title":       "Suppresses style rules",
+		"annotation.nolint.descr":       "Disables gomosaic lint rules for the object and the objects nested in it",
+		"annotation.nolint.value":       "rule ids, all rules are disabled without values",
+
+		"artifact.type":  "artifact %s has type %T, expected %T",
+		"artifact.panic": "panic while computing artifact %s: %v",
+
+		"plugin.missing-dependency": "plugin %s depends on plugin %s, which is not listed in the job",
+		"plugin.dependency-cycle":   "dependency cycle between plugins: %v",
+		"plugin.load":               "failed to load plugin: %v",
+		"plugin.symbol":             "plugin symbol not found: %v",
+		"plugin.type":               "invalid plugin type",
+		"plugin.not-found":          "plugin %s not found",
+		"plugin.option-format":      "invalid option format %q, expected plugin.key=value",
+
+		"cache.hash":  "failed to hash file: %v",
+		"cache.mkdir": "failed to create cache directory: %v",
+		"cache.clean": "failed to clean cache: %v",
+		"cache.save":  "failed to save cache: %v",
+
+		"config.read":               "failed to read config file: %v",
+		"config.parse":              "failed to parse config file %s: %v",
+		"config.initialism":         "%s: initialism %q must consist of Latin letters and digits and start with a letter",
+		"config.job-no-plugins":     "%s: job %d: no plugins specified",
+		"config.job-no-packages":    "%s: job %d: no packages specified",
+		"config.job-no-output":      "%s: job %d: no output directory specified",
+		"config.job-unused-options": "%s: job %d: options are set for plugin %s, which is not used in the job",
+
+		"fs.read":   "failed to read file: %v",
+		"fs.render": "failed to render file: %v",
+		"fs.write":  "failed to create file: %v",
+
+		"generate.plugin":           "failed to get plugin: %v",
+		"generate.failed":           "failed to generate code: %v",
+		"generate.file-conflict":    "plugins %s and %s generate the same file %s",
+		"generate.save":             "failed to save file: %v",
+		"generate.program-required": "plugin %s requires a loaded program",
+		"generate.directive":        "expected @%s plugins outputDir",
+		"generate.output-conflict":  "packages %s and %s set different plugin lists for %s with the shared plugin %s",
+
+		"keep.unclosed":          "line %d: region %s is not closed with marker %q",
+		"keep.no-id":             "line %d: region id is missing",
+		"keep.duplicate":         "line %d: region %s is already declared",
+		"keep.no-begin":          "line %d: marker %q without region begin",
+		"keep.generated-invalid": "%s: generated file contains invalid keep regions: %v",
+		"keep.existing-invalid":  "%s: failed to read keep regions, fix or delete the file: %v",
+		"keep.orphaned":          "keep region %s is no longer generated, it was moved to the end of the file: move the code or delete the region",
+
+		"typecheck.failed":      "failed to type-check generated code: %v",
+		"typecheck.import":      "generated code does not compile: %s, check module dependencies (go get)",
+		"typecheck.method":      "generated code does not compile: %s (method %s)",
+		"typecheck.type":        "generated code does not compile: %s (type %s)",
+		"typecheck.error":       "generated code does not compile: %s",
+		"typecheck.annotations": ", annotations: %s",
+
+		"cli.args": "Arguments:",
+
+		"cli.flags": "Flags (optional):",
+
+		"cli.flag.modfile":      "Path to go.mod (may be omitted when run from the project root).",
+		"cli.flag.no-cache":     "Disable the cache and always regenerate code.",
+		"cli.flag.no-typecheck": "Do not type-check generated code.",
+		"cli.flag.opt":          "Plugin option as plugin.key=value, may be repeated (list: gomosaic plugins).",
+		"cli.flag.jobs":         "Maximum number of plugins and interfaces processed concurrently.",
+
+		"cli.usage.config":        "Path to the config file",
+		"cli.usage.no-cache":      "Disable the generation cache",
+		"cli.usage.no-typecheck":  "Disable type checking of generated code",
+		"cli.usage.opt":           "Plugin option as plugin.key=value",
+		"cli.usage.jobs":          "Maximum number of parallel tasks",
+		"cli.usage.snapshot-file": "Path to the lock file",
+
+		"cli.codegen.short":        "The codegen command generates Go code from the given parameters.",
+		"cli.codegen.arg.name":     "Name of the plugin that generates code, several names are separated by commas.",
+		"cli.codegen.arg.packages": "Packages to search for interfaces and structs to generate code for.",
+		"cli.codegen.arg.output":   "Directory to write generated code to.",
+		"cli.codegen.flag.config":  "Path to the config file with generation jobs (instead of arguments).",
+
+		"cli.invalid-args": "invalid arguments",
+
+		"cli.watch.short":         "The watch command watches input packages and regenerates code on changes.",
+		"cli.watch.args":          "Arguments are the same as for the codegen command.",
+		"cli.watch.flag.no-cache": "Disable the cache, so a job regenerates code of all its plugins.",
+		"cli.watch.flag.interval": "File polling interval.",
+		"cli.watch.flag.debounce": "Quiet period after which generation starts.",
+
+		"cli.usage.interval": "File polling interval",
+		"cli.usage.debounce": "Delay before generation after the last change",
+
+		"cli.watch.waiting": "Waiting for changes, press Ctrl+C to exit",
+		"cli.watch.changed": "[%s] changed files:",
+
+		"cli.args-with-config": "arguments are not supported together with a config file",
+
+		"cli.option-unused-plugin": "option %s is set for plugin %s, which is not used",
+
+		"cli.plugin-no-options": "plugin %s does not support options",
+
+		"cli.result.cached": "Generation of %s skipped, no changes",
+		"cli.result.done":   "Generation of %s completed",
+
+		"cli.diagnostics.errors":   "%d errors",
+		"cli.diagnostics.warnings": "%d warnings",
+
+		"cli.cache.short":       "The cache command manages the generation cache.",
+		"cli.cache.clean.short": "Removes all generation cache entries.",
+		"cli.cache.cleaned":     "Cache cleaned:",
+
+		"cli.plugins.short":   "Lists plugins and their options.",
+		"cli.plugins.long":    "Lists registered plugins and the options that can be passed with --opt plugin.key=value\nor in the options section of the config file.",
+		"cli.plugins.depends": "(depends on: %s)",
+		"cli.plugins.default": "default %s",
+
+		"cli.lsp.short": "The lsp command starts the language server for gomosaic annotations (LSP over stdin/stdout).",
+		"cli.lsp.long":  "The language server completes annotation keys and values in comments, shows their documentation,\nchecks annotations with plugin loaders and navigates from @http-path parameters to method parameters.\nConnect it in the editor as an additional server to gopls for Go files.",
+
+		"cli.migrate.short":        "The migrate command rewrites annotations in source code using migration rules.",
+		"cli.migrate.long":         "The migrate command applies migration rules to annotations in package comments:\nrenaming keys, changing value syntax, splitting and merging annotations.\nOnly annotation lines change, the rest of the code and formatting are preserved.",
+		"cli.migrate.flag.rule":    "Rule name, may be repeated (all rules are applied by default).",
+		"cli.migrate.flag.dry-run": "Print changes as a diff without modifying files.",
+		"cli.migrate.flag.list":    "List migration rules.",
+		"cli.migrate.none":         "Annotations need no migration",
+
+		"cli.usage.migrate-rule": "Migration rule name",
+		"cli.usage.dry-run":      "Print changes without modifying files",
+		"cli.usage.migrate-list": "List migration rules",
+
+		"cli.fmt.short":      "The fmt command rewrites annotations in source code to canonical form.",
+		"cli.fmt.long":       "The fmt command parses annotations in package comments and writes them in canonical form:\none space after //, options before key=value params, params sorted by name, consistent quoting.\nAnnotations of one comment are ordered by key, title and description are left unchanged.\nWith -l and -d files are not rewritten, and the command exits with code 1 if there are changes.",
+		"cli.fmt.flag.order": "Order of annotation keys, an item matches a key or a plugin prefix (default gomosaic).",
+		"cli.fmt.flag.list":  "List files whose annotations differ from canonical form.",
+		"cli.fmt.flag.diff":  "Print changes as a diff.",
+
+		"cli.usage.fmt-order": "Order of annotation keys",
+		"cli.usage.fmt-list":  "List files with changes",
+		"cli.usage.diff":      "Print changes as a diff",
+
+		"cli.explain.short":       "The explain command shows how a plugin resolved annotations of an interface or method.",
+		"cli.explain.long":        "The explain command prints plugin option structs resolved from annotations of an interface or method,\nand for each field the annotation with its position, the default value or the rule that set the value.\nIt helps to understand why, for example, a parameter is sent in the request body rather than in the query.",
+		"cli.explain.arg.plugin":  "Name of a plugin that supports explain (list: gomosaic plugins).",
+		"cli.explain.arg.target":  "Package, interface and, optionally, method.",
+		"cli.explain.unsupported": "plugin %s does not support explain",
+		"cli.explain.target":      "invalid format %q, expected package.Interface or package.Interface.Method",
+
+		"cli.api.short":          "The api commands save a snapshot of the generated API and detect breaking changes.",
+		"cli.api.snapshot.short": "The snapshot command saves the API description to a lock file.",
+		"cli.api.snapshot.long":  "The snapshot command saves a normalized description of methods exposed over HTTP: routes,\nparameter locations and names, request and response body schemas. The file is committed to the repository\nand used by gomosaic api diff.",
+		"cli.api.diff.short":     "The diff command compares the current API with the snapshot and exits with code 1 on breaking changes.",
+		"cli.api.diff.long":      "The diff command compares the API of the current code with the snapshot from the lock file or from the lock file at a git revision.\nBreaking changes: removing a route or a response value, a new required parameter,\nmoving a parameter, changing a type. Adding routes, optional parameters and fields is compatible.",
+		"cli.api.flag.file":      "Path to the lock file (default %s).",
+		"cli.api.flag.rev":       "Git revision whose snapshot the API is compared with.",
+		"cli.api.unchanged":      "API has not changed",
+		"cli.api.breaking":       "breaking:",
+		"cli.api.compatible":     "compatible:",
+
+		"cli.usage.rev": "Git revision with the API snapshot",
+
+		"cli.api.rev-failed": "failed to get %s from revision %s: %s",
+
+		"cli.lint.short":       "The lint command checks API style against plugin rules.",
+		"cli.lint.long":        "The lint command checks the resolved API model: kebab-case paths, plural collections,\nno body for GET and DELETE, identifier parameter names, consistent query parameter name format,\nOpenAPI tags. Rule severities are set in the lint section of the config file, a violation is suppressed\nwith @gomosaic-nolint [rules] on an interface, method or parameter.",
+		"cli.lint.flag.config": "Config file with rule severities (default %s if it exists).",
+		"cli.lint.flag.list":   "List rules and their default severities.",
+		"cli.lint.ok":          "no issues found",
+
+		"cli.usage.lint-config": "Config file with rule severities",
+		"cli.usage.lint-list":   "List rules",
+
+		"cli.generate.short":        "The generate command runs generation jobs declared with @%s directives in packages.",
+		"cli.generate.long":         "The generate command looks for directives // @%s plugins outputDir in comments before the package clause\n(including package documentation) and runs the declared jobs.\nThe output directory is relative to the package directory, and the declaring package becomes\nthe job input. Plugins of a directive run as one job, packages with the same plugins and output directory\nare merged, a package used by several jobs is parsed once.",
+		"cli.generate.directive":    "Directive in a package file:",
+		"cli.generate.flag.dry-run": "Print discovered jobs without generating.",
+		"cli.generate.none":         "no @%s directives found",
+
+		"cli.usage.generate-dry-run": "Print jobs without generating",
+		"cli.usage.lang":             "Message language: %s (defaults to LANG)",
+
+		"cli.unknown-lang": "unknown language %q, available: %s",
+
+		"cli.plugin.short":    "Commands of the %s plugin.",
+		"cli.plugin.conflict": "commands of plugin %s are not added: the name matches a gomosaic command",
+
+		"lint.unknown-rule":     "unknown rule %s in lint config (list: gomosaic lint --list)",
+		"lint.unknown-severity": "rule %s: unknown severity %q, valid: error, warning, off",
+
+		"migrate.unknown-rules": "migration rules not found: %s (list: gomosaic migrate --list)",
+
+		"apisnapshot.parse":                "failed to parse API snapshot: %v",
+		"apisnapshot.version":              "unsupported API snapshot version %d, expected %d",
+		"apisnapshot.read":                 "failed to read API snapshot: %v",
+		"apisnapshot.route-removed":        "route removed (%s)",
+		"apisnapshot.route-added":          "route added (%s)",
+		"apisnapshot.request-wrap":         "request body wrapper changed from %q to %q",
+		"apisnapshot.response-wrap":        "response body wrapper changed from %q to %q",
+		"apisnapshot.param-removed":        "parameter %s (%s) removed",
+		"apisnapshot.param-moved":          "parameter %s moved from %s to %s",
+		"apisnapshot.param-required":       "parameter %s (%s) became required",
+		"apisnapshot.param-optional":       "parameter %s (%s) became optional",
+		"apisnapshot.required-param-added": "required parameter %s (%s) added",
+		"apisnapshot.param-added":          "parameter %s (%s) added",
+		"apisnapshot.result-removed":       "response value %s (%s) removed",
+		"apisnapshot.result-moved":         "response value %s moved from %s to %s",
+		"apisnapshot.result-added":         "response value %s (%s) added",
+		"apisnapshot.type-changed":         "%s: type changed from %s to %s",
+		"apisnapshot.field-removed":        "%s: field %s removed",
+		"apisnapshot.field-added":          "%s: field %s added",
+		"apisnapshot.param":                "parameter %s",
+		"apisnapshot.result":               "response value %s",
+
+		"option.required":          "%s is required",
+		"option.in":                "%s valid only params (%s)",
+		"option.describe-required": "required",
+		"option.describe-in":       "one of: %s",
+		"option.unknown":           "unknown option %s of plugin %s, available: %s",
+		"option.source-default":    "default %s",
+		"option.source-unset":      "not set",
+
+		"plugin.filename-ext": "%s: file name must end with .go: %s",
+		"plugin.opt.filename": "Generated file name",
+
+		"implstub.opt.suffix":          "Implementation type name suffix (UserService -> UserServiceImpl)",
+		"implstub.suffix":              "%s: suffix must be a valid part of a Go identifier: %q",
+		"implstub.read":                "failed to read implementation file: %v",
+		"implstub.parse":               "failed to parse implementation file, method stubs were not added: %v",
+		"implstub.missing-type":        "type %s was removed from the implementation file, method stubs were not added",
+		"implstub.missing-constructor": "constructor %s was removed from the implementation file and is not recreated",
+
+		"http.explain.method":      "method %s not found in interface %s",
+		"http.explain.interface":   "interface %s not found among interfaces marked with @gomosaic",
+		"http.explain.name":        "name from annotation",
+		"http.explain.name-format": "from the variable name in %s format",
+		"http.explain.http-type":   "by HTTPType %s",
+		"http.explain.default":     "default %s",
+		"http.explain.inherited":   "inherited from the interface",
+		"http.explain.path-parts":  "path split by dots",
+		"http.explain.path-param":  "path parameter %s",
+
+		"http.migrate.path-params": "Replaces @http-path parameters written as {name} with :name, which the HTTP annotation loader recognizes",
+		"http.opt.trace":           "Generate OpenTelemetry tracing (the tracer and propagator are set through the generated code options)",
+
+		"template.opt.dir":      "Directory with *.tmpl templates relative to the module root",
+		"template.opt.files":    "Template files relative to the module root, separated by spaces",
+		"template.conflict":     "%s: file %s is already produced by another template",
+		"template.read-dir":     "failed to read templates directory: %v",
+		"template.ext":          "template file must have the %s extension: %s",
+		"template.not-found":    "%s templates not found in %s",
+		"template.format":       "%s: failed to format generated code: %v",
+		"template.no-templates": "%s: no templates specified, set the dir or files option",
+
+		"lint.issue": "%s (%s)",
+
+		"http.lint.path-kebab":             "Static path segments in kebab-case",
+		"http.lint.path-plural":            "Collection before a path parameter in plural: /users/:id",
+		"http.lint.no-body":                "GET, HEAD and DELETE methods without a request body",
+		"http.lint.path-param-id":          "Path identifier parameters are named *ID: userID",
+		"http.lint.query-format":           "Consistent query parameter name format (MethodParamNameOpt.Format)",
+		"http.lint.openapi-tags":           "OpenAPI tags on every method",
+		"http.lint.path-kebab.issue":       "path segment %s must be in kebab-case",
+		"http.lint.path-kebab.brace-param": "path segment %s is not recognized as a parameter, use :name (gomosaic migrate --rule http-path-params)",
+		"http.lint.path-plural.issue":      "collection %s before parameter %s must be plural",
+		"http.lint.no-body.issue":          "parameter %s is sent in the %s request body, set @%s-type query, header or cookie",
+		"http.lint.path-param-id.issue":    "path parameter %s must be named %s",
+		"http.lint.openapi-tags.issue":     "method %s has no OpenAPI tags (@%s-openapi-tags)",
+		"http.lint.query-format.issue":     "query parameter %s name format %s differs from format %s of the other parameters",
+
+		"analyzer.parse":       "failed to parse annotations: %v",
+		"analyzer.fix.rename":  "Replace with @%s",
+		"analyzer.fix.context": "Add context.Context parameter",
+		"analyzer.fix.error":   "Add error result",
+
+		"lsp.completion.param":  "@%s parameter",
+		"lsp.completion.option": "@%s option",
+		"lsp.hover.target":      "Target: %s, type: `%s`",
+		"lsp.hover.value":       "Value: %s",
+		"lsp.hover.values":      "Allowed values: %s",
+		"lsp.hover.params":      "Parameters: %s",
+		"lsp.hover.options":     "Options: %s",
+		"lsp.method-not-found":  "method %s is not supported",
+		"lsp.content-length":    "invalid Content-Length header: %v",
+	})
+}
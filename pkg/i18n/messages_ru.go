@@ -0,0 +1,294 @@
+package i18n
+
+// Сообщения на русском языке, язык по умолчанию
+func init() {
+	Register(Russian, map[string]string{
+		"signature.missing-context": "первым параметром обязателен тип context.Context",
+		"signature.missing-error":   "последним параметром результата обязателен тип error",
+		"signature.invalid":         "Не верная сигнатура метода, %v",
+
+		"annotation.unknown":            "неизвестная аннотация @%s для %s",
+		"annotation.unknown-suggestion": "неизвестная аннотация @%s для %s, возможно, имелась в виду @%s",
+		"annotation.marker.title":       "Пометка типа для генерации",
+		"annotation.marker.descr":       "Типы без этой аннотации не попадают в модель и не обрабатываются плагинами",
+		"annotation.nolint.title":       "Подавление правил стиля",
+		"annotation.nolint.descr":       "Отключает правила gomosaic lint для объекта и вложенных в него объектов",
+		"annotation.nolint.value":       "идентификаторы правил, без значений отключаются все правила",
+
+		"artifact.type":  "артефакт %s имеет тип %T, ожидается %T",
+		"artifact.panic": "паника при вычислении артефакта %s: %v",
+
+		"plugin.missing-dependency": "плагин %s зависит от плагина %s, который не указан в задаче",
+		"plugin.dependency-cycle":   "циклическая зависимость между плагинами: %v",
+		"plugin.load":               "не удалось загрузить плагин: %v",
+		"plugin.symbol":             "символ плагина не найден: %v",
+		"plugin.type":               "недопустимый тип плагина",
+		"plugin.not-found":          "плагин %s не найден",
+		"plugin.option-format":      "неверный формат параметра %q, ожидается plugin.key=value",
+
+		"cache.hash":  "не удалось вычислить хеш файла: %v",
+		"cache.mkdir": "не удалось создать директорию кеша: %v",
+		"cache.clean": "не удалось очистить кеш: %v",
+		"cache.save":  "не удалось сохранить кеш: %v",
+
+		"config.read":               "не удалось прочитать файл конфигурации: %v",
+		"config.parse":              "не удалось разобрать файл конфигурации %s: %v",
+		"config.initialism":         "%s: аббревиатура %q должна состоять из латинских букв и цифр и начинаться с буквы",
+		"config.job-no-plugins":     "%s: задача %d: не указаны плагины",
+		"config.job-no-packages":    "%s: задача %d: не указаны пакеты",
+		"config.job-no-output":      "%s: задача %d: не указана директория вывода",
+		"config.job-unused-options": "%s: задача %d: параметры заданы для плагина %s, который не используется в задаче",
+
+		"fs.read":   "не удалось прочитать файл: %v",
+		"fs.render": "не удалось записать файл: %v",
+		"fs.write":  "не удалось создать файл: %v",
+
+		"generate.plugin":           "не удалось получить плагин: %v",
+		"generate.failed":           "не удалось сгенерировать код: %v",
+		"generate.file-conflict":    "плагины %s и %s генерируют один и тот же файл %s",
+		"generate.save":             "не удалось сохранить файл: %v",
+		"generate.program-required": "плагину %s требуется загруженная программа",
+		"generate.directive":        "ожидается @%s plugins outputDir",
+		"generate.output-conflict":  "пакеты %s и %s задают для %s разные наборы плагинов с общим плагином %s",
+
+		"keep.unclosed":          "строка %d: область %s не закрыта маркером %q",
+		"keep.no-id":             "строка %d: не указан идентификатор области",
+		"keep.duplicate":         "строка %d: область %s уже объявлена",
+		"keep.no-begin":          "строка %d: маркер %q без начала области",
+		"keep.generated-invalid": "%s: сгенерированный файл содержит некорректные защищенные области: %v",
+		"keep.existing-invalid":  "%s: не удалось прочитать защищенные области, исправьте файл или удалите его: %v",
+		"keep.orphaned":          "защищенная область %s больше не генерируется, она перенесена в конец файла: перенесите код или удалите область",
+
+		"typecheck.failed":      "не удалось проверить типы сгенерированного кода: %v",
+		"typecheck.import":      "сгенерированный код не компилируется: %s, проверьте зависимости модуля (go get)",
+		"typecheck.method":      "сгенерированный код не компилируется: %s (метод %s)",
+		"typecheck.type":        "сгенерированный код не компилируется: %s (тип %s)",
+		"typecheck.error":       "сгенерированный код не компилируется: %s",
+		"typecheck.annotations": ", аннотации: %s",
+
+		"cli.args": "Параметры:",
+
+		"cli.flags": "Флаги (опционально):",
+
+		"cli.flag.modfile":      "Путь к файлу go.mod (при запуске из под корня проекта флаг можно не указывать).",
+		"cli.flag.no-cache":     "Отключить кеш и всегда генерировать код заново.",
+		"cli.flag.no-typecheck": "Не проверять типы сгенерированного кода.",
+		"cli.flag.opt":          "Параметр плагина в виде plugin.key=value, флаг можно повторять (список: gomosaic plugins).",
+		"cli.flag.jobs":         "Максимальное число одновременно работающих плагинов и интерфейсов.",
+
+		"cli.usage.config":        "Путь к файлу конфигурации",
+		"cli.usage.no-cache":      "Отключить кеш генерации",
+		"cli.usage.no-typecheck":  "Отключить проверку типов сгенерированного кода",
+		"cli.usage.opt":           "Параметр плагина в виде plugin.key=value",
+		"cli.usage.jobs":          "Максимальное число параллельных задач",
+		"cli.usage.snapshot-file": "Путь к lock файлу",
+
+		"cli.codegen.short":        "Команда codegen используется для автоматической генерации различного кода на языке Go (Golang) на основе переданных параметров.",
+		"cli.codegen.arg.name":     "Имя раширения которое будет генерировать код, несколько имен перечисляются через запятую.",
+		"cli.codegen.arg.packages": "Список пакетов в которых необходимо искать интерфейсы и структуры для генерации кода.",
+		"cli.codegen.arg.output":   "Директория, в которую будет сохранен сгенерированный код.",
+		"cli.codegen.flag.config":  "Путь к файлу конфигурации с задачами генерации (вместо параметров).",
+
+		"cli.invalid-args": "не верные аргументы",
+
+		"cli.watch.short":         "Команда watch отслеживает изменения входных пакетов и перегенерирует код.",
+		"cli.watch.args":          "Параметры совпадают с командой codegen.",
+		"cli.watch.flag.no-cache": "Отключить кеш, при этом задача перегенерирует код всех своих плагинов.",
+		"cli.watch.flag.interval": "Интервал опроса файлов.",
+		"cli.watch.flag.debounce": "Время без изменений, после которого запускается генерация.",
+
+		"cli.usage.interval": "Интервал опроса файлов",
+		"cli.usage.debounce": "Задержка перед генерацией после последнего изменения",
+
+		"cli.watch.waiting": "Ожидание изменений, для выхода нажмите Ctrl+C",
+		"cli.watch.changed": "[%s] изменены файлы:",
+
+		"cli.args-with-config": "аргументы не поддерживаются вместе с файлом конфигурации",
+
+		"cli.option-unused-plugin": "параметр %s задан для плагина %s, который не используется",
+
+		"cli.plugin-no-options": "плагин %s не поддерживает параметры",
+
+		"cli.result.cached": "Генерация %s пропущена, изменений нет",
+		"cli.result.done":   "Генерация %s успешно завершена",
+
+		"cli.diagnostics.errors":   "%d ошибки",
+		"cli.diagnostics.warnings": "%d предупреждения",
+
+		"cli.cache.short":       "Команда cache используется для управления кешем генерации.",
+		"cli.cache.clean.short": "Удаляет все записи кеша генерации.",
+		"cli.cache.cleaned":     "Кеш очищен:",
+
+		"cli.plugins.short":   "Выводит список плагинов и их параметров.",
+		"cli.plugins.long":    "Выводит список зарегистрированных плагинов и параметров, которые можно передать флагом --opt plugin.key=value\nили в разделе options файла конфигурации.",
+		"cli.plugins.depends": "(зависит от: %s)",
+		"cli.plugins.default": "по умолчанию %s",
+
+		"cli.lsp.short": "Команда lsp запускает языковой сервер для аннотаций gomosaic (LSP через stdin/stdout).",
+		"cli.lsp.long":  "Языковой сервер дополняет ключи и значения аннотаций в комментариях, показывает их документацию,\nпроверяет аннотации загрузчиками плагинов и переходит от параметров @http-path к параметрам метода.\nСервер подключается в редакторе как дополнительный к gopls для файлов Go.",
+
+		"cli.migrate.short":        "Команда migrate переписывает аннотации в исходном коде по правилам миграции.",
+		"cli.migrate.long":         "Команда migrate применяет правила миграции к аннотациям в комментариях пакетов:\nпереименование ключей, изменение синтаксиса значений, разделение и объединение аннотаций.\nИзменяются только строки с аннотациями, остальной код и форматирование сохраняются.",
+		"cli.migrate.flag.rule":    "Имя правила, флаг можно повторять (по умолчанию применяются все правила).",
+		"cli.migrate.flag.dry-run": "Вывести изменения в формате diff без изменения файлов.",
+		"cli.migrate.flag.list":    "Вывести список правил миграции.",
+		"cli.migrate.none":         "Аннотации не требуют миграции",
+
+		"cli.usage.migrate-rule": "Имя правила миграции",
+		"cli.usage.dry-run":      "Вывести изменения без изменения файлов",
+		"cli.usage.migrate-list": "Вывести список правил миграции",
+
+		"cli.fmt.short":      "Команда fmt приводит аннотации в исходном коде к каноническому виду.",
+		"cli.fmt.long":       "Команда fmt разбирает аннотации в комментариях пакетов и записывает их в каноническом виде:\nодин пробел после //, опции перед параметрами key=value, параметры по имени, единый стиль кавычек.\nАннотации одного комментария упорядочиваются по ключам, заголовок и описание не изменяются.\nС флагами -l и -d файлы не перезаписываются, а при наличии изменений команда завершается с кодом 1.",
+		"cli.fmt.flag.order": "Порядок ключей аннотаций, элемент совпадает с ключом или префиксом плагина (по умолчанию gomosaic).",
+		"cli.fmt.flag.list":  "Вывести список файлов, аннотации в которых отличаются от канонического вида.",
+		"cli.fmt.flag.diff":  "Вывести изменения в формате diff.",
+
+		"cli.usage.fmt-order": "Порядок ключей аннотаций",
+		"cli.usage.fmt-list":  "Вывести список файлов с изменениями",
+		"cli.usage.diff":      "Вывести изменения в формате diff",
+
+		"cli.explain.short":       "Команда explain показывает, как плагин разобрал аннотации интерфейса или метода.",
+		"cli.explain.long":        "Команда explain выводит структуры параметров плагина, полученные из аннотаций интерфейса или метода,\nи для каждого поля аннотацию с позицией, значение по умолчанию или правило, которое задало значение.\nПомогает понять, почему, например, параметр передается в теле запроса, а не в query.",
+		"cli.explain.arg.plugin":  "Имя плагина, который поддерживает explain (список: gomosaic plugins).",
+		"cli.explain.arg.target":  "Пакет, интерфейс и, опционально, метод.",
+		"cli.explain.unsupported": "плагин %s не поддерживает explain",
+		"cli.explain.target":      "не верный формат %q, ожидается package.Interface или package.Interface.Method",
+
+		"cli.api.short":          "Команды api сохраняют снимок генерируемого API и находят несовместимые изменения.",
+		"cli.api.snapshot.short": "Команда snapshot сохраняет описание API в lock файл.",
+		"cli.api.snapshot.long":  "Команда snapshot сохраняет нормализованное описание методов, доступных по HTTP: маршруты,\nрасположение и имена параметров, схемы тела запроса и ответа. Файл добавляется в репозиторий\nи используется командой gomosaic api diff.",
+		"cli.api.diff.short":     "Команда diff сравнивает текущее API со снимком и завершается с кодом 1 при несовместимых изменениях.",
+		"cli.api.diff.long":      "Команда diff сравнивает API текущего кода со снимком из lock файла или из lock файла в ревизии git.\nНесовместимые изменения: удаление маршрута или значения ответа, новый обязательный параметр,\nперенос параметра, изменение типа. Добавление маршрутов, необязательных параметров и полей совместимо.",
+		"cli.api.flag.file":      "Путь к lock файлу (по умолчанию %s).",
+		"cli.api.flag.rev":       "Ревизия git, со снимком из которой сравнивается API.",
+		"cli.api.unchanged":      "API не изменилось",
+		"cli.api.breaking":       "несовместимо:",
+		"cli.api.compatible":     "совместимо:",
+
+		"cli.usage.rev": "Ревизия git со снимком API",
+
+		"cli.api.rev-failed": "не удалось получить %s из ревизии %s: %s",
+
+		"cli.lint.short":       "Команда lint проверяет стиль API по правилам плагинов.",
+		"cli.lint.long":        "Команда lint проверяет разобранную модель API: пути в kebab-case, коллекции во множественном числе,\nотсутствие тела у GET и DELETE, имена параметров-идентификаторов, единый формат имен query параметров,\nтеги OpenAPI. Уровни правил задаются в разделе lint файла конфигурации, нарушение подавляется\nаннотацией @gomosaic-nolint [правила] на интерфейсе, методе или параметре.",
+		"cli.lint.flag.config": "Файл конфигурации с уровнями правил (по умолчанию %s, если существует).",
+		"cli.lint.flag.list":   "Вывести правила и их уровни по умолчанию.",
+		"cli.lint.ok":          "нарушений не найдено",
+
+		"cli.usage.lint-config": "Файл конфигурации с уровнями правил",
+		"cli.usage.lint-list":   "Вывести правила",
+
+		"cli.generate.short":        "Команда generate выполняет задачи генерации, объявленные директивами @%s в пакетах.",
+		"cli.generate.long":         "Команда generate ищет в комментариях до объявления package (в том числе в документации пакета)\nдирективы вида // @%s plugins outputDir и выполняет объявленные задачи.\nДиректория вывода указывается относительно директории пакета, пакет с директивой становится\nвходным пакетом задачи. Плагины директивы выполняются одной задачей, пакеты с одинаковыми плагинами\nи директорией вывода объединяются, пакет, используемый в нескольких задачах, разбирается один раз.",
+		"cli.generate.directive":    "Директива в файле пакета:",
+		"cli.generate.flag.dry-run": "Вывести найденные задачи без генерации.",
+		"cli.generate.none":         "директивы @%s не найдены",
+
+		"cli.usage.generate-dry-run": "Вывести задачи без генерации",
+		"cli.usage.lang":             "Язык сообщений: %s (по умолчанию по LANG)",
+
+		"cli.unknown-lang": "неизвестный язык %q, доступные: %s",
+
+		"cli.plugin.short":    "Команды плагина %s.",
+		"cli.plugin.conflict": "команды плагина %s не добавлены: имя совпадает с командой gomosaic",
+
+		"lint.unknown-rule":     "неизвестное правило %s в конфигурации lint (список: gomosaic lint --list)",
+		"lint.unknown-severity": "правило %s: неизвестный уровень %q, допустимые: error, warning, off",
+
+		"migrate.unknown-rules": "правила миграции не найдены: %s (список: gomosaic migrate --list)",
+
+		"apisnapshot.parse":                "не удалось разобрать снимок API: %v",
+		"apisnapshot.version":              "неподдерживаемая версия снимка API %d, ожидается %d",
+		"apisnapshot.read":                 "не удалось прочитать снимок API: %v",
+		"apisnapshot.route-removed":        "маршрут удален (%s)",
+		"apisnapshot.route-added":          "маршрут добавлен (%s)",
+		"apisnapshot.request-wrap":         "обертка тела запроса изменена с %q на %q",
+		"apisnapshot.response-wrap":        "обертка тела ответа изменена с %q на %q",
+		"apisnapshot.param-removed":        "параметр %s (%s) удален",
+		"apisnapshot.param-moved":          "параметр %s перенесен из %s в %s",
+		"apisnapshot.param-required":       "параметр %s (%s) стал обязательным",
+		"apisnapshot.param-optional":       "параметр %s (%s) стал необязательным",
+		"apisnapshot.required-param-added": "добавлен обязательный параметр %s (%s)",
+		"apisnapshot.param-added":          "добавлен параметр %s (%s)",
+		"apisnapshot.result-removed":       "значение ответа %s (%s) удалено",
+		"apisnapshot.result-moved":         "значение ответа %s перенесено из %s в %s",
+		"apisnapshot.result-added":         "добавлено значение ответа %s (%s)",
+		"apisnapshot.type-changed":         "%s: тип изменен с %s на %s",
+		"apisnapshot.field-removed":        "%s: поле %s удалено",
+		"apisnapshot.field-added":          "%s: добавлено поле %s",
+		"apisnapshot.param":                "параметр %s",
+		"apisnapshot.result":               "значение ответа %s",
+
+		"option.required":          "%s is required",
+		"option.in":                "%s valid only params (%s)",
+		"option.describe-required": "обязательный",
+		"option.describe-in":       "одно из: %s",
+		"option.unknown":           "неизвестный параметр %s плагина %s, доступные: %s",
+		"option.source-default":    "по умолчанию %s",
+		"option.source-unset":      "не задано",
+
+		"plugin.filename-ext": "%s: имя файла должно оканчиваться на .go: %s",
+		"plugin.opt.filename": "Имя сгенерированного файла",
+
+		"implstub.opt.suffix":          "Суффикс имени типа реализации (UserService -> UserServiceImpl)",
+		"implstub.suffix":              "%s: суффикс должен быть допустимой частью идентификатора Go: %q",
+		"implstub.read":                "не удалось прочитать файл реализации: %v",
+		"implstub.parse":               "не удалось разобрать файл реализации, заготовки методов не добавлены: %v",
+		"implstub.missing-type":        "тип %s удален из файла реализации, заготовки методов не добавлены",
+		"implstub.missing-constructor": "конструктор %s удален из файла реализации и не создается заново",
+
+		"http.explain.method":      "метод %s не найден в интерфейсе %s",
+		"http.explain.interface":   "интерфейс %s не найден среди интерфейсов, помеченных @gomosaic",
+		"http.explain.name":        "имя из аннотации",
+		"http.explain.name-format": "из имени переменной в формате %s",
+		"http.explain.http-type":   "по HTTPType %s",
+		"http.explain.default":     "по умолчанию %s",
+		"http.explain.inherited":   "наследуется от интерфейса",
+		"http.explain.path-parts":  "путь разделен по точкам",
+		"http.explain.path-param":  "параметр пути %s",
+
+		"http.migrate.path-params": "Параметры пути @http-path вида {name} заменяются на :name, которые распознает загрузчик HTTP аннотаций",
+		"http.opt.trace":           "Генерировать трассировку OpenTelemetry (tracer и propagator задаются в опциях сгенерированного кода)",
+
+		"template.opt.dir":      "Директория с шаблонами *.tmpl относительно корня модуля",
+		"template.opt.files":    "Файлы шаблонов относительно корня модуля, через пробел",
+		"template.conflict":     "%s: файл %s уже создается другим шаблоном",
+		"template.read-dir":     "не удалось прочитать директорию шаблонов: %v",
+		"template.ext":          "файл шаблона должен иметь расширение %s: %s",
+		"template.not-found":    "шаблоны %s не найдены в %s",
+		"template.format":       "%s: сгенерированный код не удалось отформатировать: %v",
+		"template.no-templates": "%s: не указаны шаблоны, задайте параметр dir или files",
+
+		"lint.issue": "%s (%s)",
+
+		"http.lint.path-kebab":             "Статические сегменты пути в kebab-case",
+		"http.lint.path-plural":            "Коллекция перед параметром пути во множественном числе: /users/:id",
+		"http.lint.no-body":                "Методы GET, HEAD и DELETE без тела запроса",
+		"http.lint.path-param-id":          "Параметры-идентификаторы пути называются *ID: userID",
+		"http.lint.query-format":           "Единый формат имен query параметров (MethodParamNameOpt.Format)",
+		"http.lint.openapi-tags":           "Теги OpenAPI у каждого метода",
+		"http.lint.path-kebab.issue":       "сегмент пути %s должен быть в kebab-case",
+		"http.lint.path-kebab.brace-param": "сегмент пути %s не распознается как параметр, используйте :name (gomosaic migrate --rule http-path-params)",
+		"http.lint.path-plural.issue":      "коллекция %s перед параметром %s должна быть во множественном числе",
+		"http.lint.no-body.issue":          "параметр %s передается в теле запроса %s, укажите @%s-type query, header или cookie",
+		"http.lint.path-param-id.issue":    "параметр пути %s должен называться %s",
+		"http.lint.openapi-tags.issue":     "у метода %s нет тегов OpenAPI (@%s-openapi-tags)",
+		"http.lint.query-format.issue":     "формат имени query параметра %s %s отличается от формата %s остальных параметров",
+
+		"analyzer.parse":       "не удалось разобрать аннотации: %v",
+		"analyzer.fix.rename":  "Заменить на @%s",
+		"analyzer.fix.context": "Добавить параметр context.Context",
+		"analyzer.fix.error":   "Добавить результат error",
+
+		"lsp.completion.param":  "параметр @%s",
+		"lsp.completion.option": "опция @%s",
+		"lsp.hover.target":      "Объект: %s, тип: `%s`",
+		"lsp.hover.value":       "Значение: %s",
+		"lsp.hover.values":      "Допустимые значения: %s",
+		"lsp.hover.params":      "Параметры: %s",
+		"lsp.hover.options":     "Опции: %s",
+		"lsp.method-not-found":  "метод %s не поддерживается",
+		"lsp.content-length":    "некорректный заголовок Content-Length: %v",
+	})
+}
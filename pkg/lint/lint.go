@@ -0,0 +1,171 @@
+// Package lint проверяет стиль API по правилам плагинов: правила имеют идентификаторы
+// и уровни, которые переопределяются в конфигурации проекта, а нарушения подавляются
+// аннотацией @gomosaic-nolint на интерфейсе, методе или параметре.
+package lint
+
+import (
+	"context"
+	"go/token"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// Severity уровень нарушения правила
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // Нарушение считается ошибкой
+	SeverityWarning Severity = "warning" // Нарушение выводится как предупреждение
+	SeverityOff     Severity = "off"     // Правило отключено
+)
+
+// Rule правило стиля
+type Rule struct {
+	ID          string   // Идентификатор, например http-no-body
+	Description string   // Описание правила
+	Severity    Severity // Уровень по умолчанию
+}
+
+// Issue нарушение правила
+type Issue struct {
+	Rule     string            // Идентификатор правила
+	Message  string            // Описание нарушения
+	Position *gomosaic.PosInfo // Позиция в исходном коде
+	// Аннотации объекта и объектов, в которые он вложен (параметр, метод, интерфейс),
+	// в которых ищется @gomosaic-nolint
+	Annotations []gomosaic.Annotations
+}
+
+// Linter необязательный интерфейс плагина, который проверяет стиль API.
+// Плагины, читающие одни и те же аннотации, возвращают одни и те же правила,
+// такие правила проверяются один раз.
+type Linter interface {
+	LintRules() []*Rule
+	Lint(ctx context.Context, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo) ([]*Issue, error)
+}
+
+// linters возвращает плагины, реализующие Linter, без повторяющихся наборов правил
+func linters(pm *gomosaic.PluginManager) (result []Linter, rules []*Rule) {
+	seen := make(map[string]bool)
+	for _, plugin := range pm.Plugins() {
+		linter, ok := plugin.(Linter)
+		if !ok {
+			continue
+		}
+		pluginRules := linter.LintRules()
+		if len(pluginRules) == 0 || !slices.ContainsFunc(pluginRules, func(r *Rule) bool { return !seen[r.ID] }) {
+			continue
+		}
+		for _, rule := range pluginRules {
+			if !seen[rule.ID] {
+				seen[rule.ID] = true
+				rules = append(rules, rule)
+			}
+		}
+		result = append(result, linter)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return result, rules
+}
+
+// Rules возвращает правила плагинов менеджера pm, отсортированные по идентификатору
+func Rules(pm *gomosaic.PluginManager) []*Rule {
+	_, rules := linters(pm)
+	return rules
+}
+
+// Run проверяет модель правилами плагинов менеджера pm. Уровни правил из severities
+// (идентификатор правила -> error, warning, off) заменяют уровни по умолчанию.
+// Нарушения возвращаются как ошибки gomosaic.Error и предупреждения gomosaic.Warn.
+func Run(ctx context.Context, pm *gomosaic.PluginManager, module *gomosaic.ModuleInfo, types []*gomosaic.NameTypeInfo, severities map[string]string) (errs error) {
+	linters, rules := linters(pm)
+
+	levels := make(map[string]Severity, len(rules))
+	for _, rule := range rules {
+		levels[rule.ID] = rule.Severity
+	}
+	ids := make([]string, 0, len(severities))
+	for id := range severities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		level := severities[id]
+		if _, ok := levels[id]; !ok {
+			errs = multierror.Append(errs, i18n.Errorf("lint.unknown-rule", id))
+			continue
+		}
+		switch severity := Severity(level); severity {
+		case SeverityError, SeverityWarning, SeverityOff:
+			levels[id] = severity
+		default:
+			errs = multierror.Append(errs, i18n.Errorf("lint.unknown-severity", id, level))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	var issues []*Issue
+	for _, linter := range linters {
+		linterIssues, err := linter.Lint(ctx, module, gomosaic.CloneNameTypesInfo(types))
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		issues = append(issues, linterIssues...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Position, issues[j].Position
+		if a == nil || b == nil {
+			return b != nil
+		}
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	for _, issue := range issues {
+		if suppressed(issue) {
+			continue
+		}
+		switch levels[issue.Rule] {
+		case SeverityError:
+			errs = multierror.Append(errs, gomosaic.Errorf("lint.issue", issue.Position, issue.Message, issue.Rule))
+		case SeverityWarning:
+			errs = multierror.Append(errs, gomosaic.Warnf("lint.issue", position(issue.Position), issue.Message, issue.Rule))
+		}
+	}
+
+	return errs
+}
+
+// suppressed проверяет, подавлено ли нарушение аннотацией @gomosaic-nolint
+// без параметров или с идентификатором правила
+func suppressed(issue *Issue) bool {
+	for _, annotations := range issue.Annotations {
+		for _, a := range annotations.GetSlice(gomosaic.NolintAnnotation) {
+			if len(a.Options) == 0 || slices.Contains(a.Options, issue.Rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func position(pos *gomosaic.PosInfo) token.Position {
+	if pos == nil || !pos.IsValid {
+		return token.Position{}
+	}
+	return token.Position{Filename: pos.Filename, Line: pos.Line, Column: pos.Column}
+}
@@ -0,0 +1,128 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
+type stylePlugin struct {
+	name   string
+	issues []*Issue
+}
+
+func (p *stylePlugin) Name() string { return p.name }
+
+func (p *stylePlugin) Generate(context.Context, *gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo) (map[string]gomosaic.File, error) {
+	return nil, nil
+}
+
+func (p *stylePlugin) LintRules() []*Rule {
+	return []*Rule{
+		{ID: "no-body", Severity: SeverityError},
+		{ID: "plural", Severity: SeverityWarning},
+	}
+}
+
+func (p *stylePlugin) Lint(context.Context, *gomosaic.ModuleInfo, []*gomosaic.NameTypeInfo) ([]*Issue, error) {
+	return p.issues, nil
+}
+
+func nolint(tb testing.TB, value string) gomosaic.Annotations {
+	tb.Helper()
+	a, err := annotation.Parse(value)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return gomosaic.Annotations{{Annotation: a, Position: &gomosaic.PosInfo{}}}
+}
+
+func TestRun(t *testing.T) {
+	// Позиции без IsValid упорядочивают нарушения, но не выводятся в тексте ошибок
+	pos := func(line int) *gomosaic.PosInfo {
+		return &gomosaic.PosInfo{Filename: "svc.go", Line: line}
+	}
+
+	tests := []struct {
+		name       string
+		issues     []*Issue
+		severities map[string]string
+		want       []string // ожидаемые сообщения, предупреждения с префиксом warning:
+	}{
+		{
+			name: "уровни по умолчанию",
+			issues: []*Issue{
+				{Rule: "plural", Message: "коллекция user", Position: pos(5)},
+				{Rule: "no-body", Message: "тело у GET", Position: pos(3)},
+			},
+			want: []string{"тело у GET (no-body)", "warning: коллекция user (plural)"},
+		},
+		{
+			name: "уровни из конфигурации",
+			issues: []*Issue{
+				{Rule: "plural", Message: "коллекция user", Position: pos(5)},
+				{Rule: "no-body", Message: "тело у GET", Position: pos(3)},
+			},
+			severities: map[string]string{"plural": "error", "no-body": "off"},
+			want:       []string{"коллекция user (plural)"},
+		},
+		{
+			name: "подавление аннотацией",
+			issues: []*Issue{
+				{Rule: "plural", Message: "все правила", Position: pos(1), Annotations: []gomosaic.Annotations{nil, nolint(t, "@gomosaic-nolint")}},
+				{Rule: "plural", Message: "правило plural", Position: pos(2), Annotations: []gomosaic.Annotations{nolint(t, "@gomosaic-nolint plural")}},
+				{Rule: "no-body", Message: "другое правило", Position: pos(3), Annotations: []gomosaic.Annotations{nolint(t, "@gomosaic-nolint plural")}},
+			},
+			want: []string{"другое правило (no-body)"},
+		},
+		{
+			name:       "неизвестное правило и уровень",
+			issues:     []*Issue{{Rule: "plural", Message: "не проверяется", Position: pos(1)}},
+			severities: map[string]string{"singular": "error", "plural": "fatal"},
+			want: []string{
+				`правило plural: неизвестный уровень "fatal", допустимые: error, warning, off`,
+				"неизвестное правило singular в конфигурации lint (список: gomosaic lint --list)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := gomosaic.NewPluginManager()
+			// Плагины с одинаковыми правилами проверяются один раз
+			pm.RegisterPlugin(&stylePlugin{name: "client", issues: tt.issues})
+			pm.RegisterPlugin(&stylePlugin{name: "server", issues: tt.issues})
+
+			err := Run(context.Background(), pm, &gomosaic.ModuleInfo{}, nil, tt.severities)
+
+			var got []string
+			var merr *multierror.Error
+			if errors.As(err, &merr) {
+				for _, e := range merr.Errors {
+					text := e.Error()
+					if gomosaic.IsErrWarning(e) {
+						text = "warning: " + text
+					}
+					got = append(got, text)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-mosaic/gomosaic/internal/rewrite"
+)
+
+// Result результат миграции файла
+type Result struct {
+	rewrite.Result
+	Rules []string // Примененные правила
+}
+
+// Migrate применяет правила к файлам пакетов, подходящих под шаблоны paths,
+// и возвращает результаты только для измененных файлов. Файлы не перезаписываются.
+func Migrate(dir string, paths []string, rules []Rule) (results []*Result, err error) {
+	return rewrite.Files(dir, paths, func(filename string, src []byte) (*Result, error) {
+		return MigrateSource(filename, src, rules)
+	})
+}
+
+// annotationComment комментарий, который целиком занимает строку и содержит аннотацию
+type annotationComment struct {
+	comment    *ast.Comment
+	annotation *Annotation
+	prefix     string // начало комментария до @, например "// "
+	indent     string // отступ строки комментария
+}
+
+// MigrateSource применяет правила к аннотациям в комментариях файла.
+// Изменяются только комментарии, аннотации которых изменили правила: остальной код
+// и форматирование файла сохраняются.
+func MigrateSource(filename string, src []byte, rules []Rule) (*Result, error) {
+	result := &Result{Result: rewrite.Result{Filename: filename, Original: src, Rewritten: src}}
+	if !bytes.Contains(src, []byte("@")) {
+		return result, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	tokFile := fset.File(file.Pos())
+
+	var edits []rewrite.Edit
+	for _, group := range file.Comments {
+		comments := annotationComments(tokFile, src, group)
+		if len(comments) == 0 {
+			continue
+		}
+
+		annotations := make([]*Annotation, len(comments))
+		for i, c := range comments {
+			annotations[i] = c.annotation
+		}
+
+		migrated := annotations
+		for _, rule := range rules {
+			next := rule.Apply(migrated)
+			if !equalAnnotations(migrated, next) {
+				if !slices.Contains(result.Rules, rule.Name()) {
+					result.Rules = append(result.Rules, rule.Name())
+				}
+				migrated = next
+			}
+		}
+		if equalAnnotations(annotations, migrated) {
+			continue
+		}
+
+		edits = append(edits, groupEdits(tokFile, comments, migrated)...)
+	}
+
+	result.Rewritten = rewrite.Apply(src, edits)
+
+	return result, nil
+}
+
+// annotationComments возвращает комментарии группы с аннотациями,
+// которые целиком занимают строку (// @key value)
+func annotationComments(tokFile *token.File, src []byte, group *ast.CommentGroup) (comments []*annotationComment) {
+	for _, comment := range group.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		text := strings.TrimPrefix(comment.Text, "//")
+		trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+		if !strings.HasPrefix(trimmed, "@") {
+			continue
+		}
+
+		start := tokFile.Offset(comment.Pos())
+		lineStart := tokFile.Offset(tokFile.LineStart(tokFile.Line(comment.Pos())))
+		indent := string(src[lineStart:start])
+		if strings.TrimSpace(indent) != "" {
+			continue
+		}
+
+		key, value := trimmed[1:], ""
+		if i := strings.IndexFunc(key, unicode.IsSpace); i != -1 {
+			key, value = key[:i], strings.TrimSpace(key[i:])
+		}
+		comments = append(comments, &annotationComment{
+			comment:    comment,
+			annotation: &Annotation{Key: key, Value: value},
+			prefix:     "//" + text[:len(text)-len(trimmed)],
+			indent:     indent,
+		})
+	}
+	return comments
+}
+
+// groupEdits заменяет аннотации комментариев comments на migrated. Лишние строки удаляются,
+// новые аннотации добавляются после последней строки с аннотацией с тем же отступом.
+func groupEdits(tokFile *token.File, comments []*annotationComment, migrated []*Annotation) (edits []rewrite.Edit) {
+	for i, c := range comments {
+		start, end := tokFile.Offset(c.comment.Pos()), tokFile.Offset(c.comment.End())
+		if i >= len(migrated) {
+			// Удаляем строку вместе с переводом строки перед ней
+			lineStart := tokFile.Offset(tokFile.LineStart(tokFile.Line(c.comment.Pos())))
+			edits = append(edits, rewrite.Edit{Start: max(lineStart-1, 0), End: end})
+			continue
+		}
+		if *migrated[i] != *c.annotation {
+			edits = append(edits, rewrite.Edit{Start: start, End: end, Text: c.prefix + migrated[i].String()})
+		}
+	}
+
+	if len(migrated) > len(comments) {
+		last := comments[len(comments)-1]
+		end := tokFile.Offset(last.comment.End())
+
+		var text strings.Builder
+		for _, a := range migrated[len(comments):] {
+			text.WriteString("\n" + last.indent + last.prefix + a.String())
+		}
+		edits = append(edits, rewrite.Edit{Start: end, End: end, Text: text.String()})
+	}
+
+	return edits
+}
+
+func equalAnnotations(a, b []*Annotation) bool {
+	return slices.EqualFunc(a, b, func(x, y *Annotation) bool { return *x == *y })
+}
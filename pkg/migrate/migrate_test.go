@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+const source = `package svc
+
+// UserService сервис пользователей
+// @gomosaic
+type UserService interface {
+	// GetUser возвращает пользователя
+	// @http-method GET
+	// @http-path /users/{id}
+	GetUser(ctx context.Context, id string) (string, error) // @http-old
+	//@http-headers Accept,Content-Type
+	List(ctx context.Context) error
+}
+`
+
+func TestMigrateSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []Rule
+		want      string
+		wantRules []string
+	}{
+		{
+			name:  "правила не изменили аннотации",
+			rules: []Rule{RenameKey("rename", "", "http-unknown", "http-known")},
+			want:  source,
+		},
+		{
+			name:      "переименование ключа",
+			rules:     []Rule{RenameKey("rename", "", "http-method", "http-verb")},
+			want:      strings.Replace(source, "@http-method GET", "@http-verb GET", 1),
+			wantRules: []string{"rename"},
+		},
+		{
+			name: "изменение значения",
+			rules: []Rule{RewriteValue("braces", "", "http-path", func(value string) string {
+				return strings.NewReplacer("{", ":", "}", "").Replace(value)
+			})},
+			want:      strings.Replace(source, "/users/{id}", "/users/:id", 1),
+			wantRules: []string{"braces"},
+		},
+		{
+			name: "разделение аннотации с сохранением префикса комментария",
+			rules: []Rule{Split("split", "", "http-headers", func(a *Annotation) (result []*Annotation) {
+				for _, header := range strings.Split(a.Value, ",") {
+					result = append(result, &Annotation{Key: "http-header", Value: header})
+				}
+				return result
+			})},
+			want:      strings.Replace(source, "\t//@http-headers Accept,Content-Type\n", "\t//@http-header Accept\n\t//@http-header Content-Type\n", 1),
+			wantRules: []string{"split"},
+		},
+		{
+			name: "объединение аннотаций",
+			rules: []Rule{Merge("merge", "", []string{"http-method", "http-path"}, func(parts []*Annotation) *Annotation {
+				return &Annotation{Key: "http-route", Value: parts[0].Value + " " + parts[1].Value}
+			})},
+			want:      strings.Replace(source, "\t// @http-method GET\n\t// @http-path /users/{id}\n", "\t// @http-route GET /users/{id}\n", 1),
+			wantRules: []string{"merge"},
+		},
+		{
+			name: "удаление аннотации",
+			rules: []Rule{NewRule("drop", "", func(annotations []*Annotation) (result []*Annotation) {
+				for _, a := range annotations {
+					if a.Key != "gomosaic" {
+						result = append(result, a)
+					}
+				}
+				return result
+			})},
+			want:      strings.Replace(source, "// @gomosaic\n", "", 1),
+			wantRules: []string{"drop"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MigrateSource("svc.go", []byte(source), tt.rules)
+			if err != nil {
+				t.Fatalf("MigrateSource() error = %v", err)
+			}
+			if got := string(result.Rewritten); got != tt.want {
+				t.Errorf("MigrateSource() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if strings.Join(result.Rules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("MigrateSource() rules = %v, want %v", result.Rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestResultDiff(t *testing.T) {
+	result, err := MigrateSource("svc.go", []byte(source), []Rule{
+		RenameKey("rename", "", "gomosaic", "gomosaic-gen"),
+		RenameKey("rename", "", "http-headers", "http-header"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `--- svc.go
++++ svc.go
+@@ -1,12 +1,12 @@
+ package svc
+ 
+ // UserService сервис пользователей
+-// @gomosaic
++// @gomosaic-gen
+ type UserService interface {
+ 	// GetUser возвращает пользователя
+ 	// @http-method GET
+ 	// @http-path /users/{id}
+ 	GetUser(ctx context.Context, id string) (string, error) // @http-old
+-	//@http-headers Accept,Content-Type
++	//@http-header Accept,Content-Type
+ 	List(ctx context.Context) error
+ }
+`
+	if got := result.Diff(); got != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", got, want)
+	}
+}
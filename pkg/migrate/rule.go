@@ -0,0 +1,187 @@
+// Package migrate переписывает аннотации gomosaic в исходном коде по правилам миграции:
+// переименование ключей, изменение синтаксиса значений, разделение и объединение аннотаций.
+// Плагины регистрируют правила при изменении своих аннотаций, а команда gomosaic migrate
+// применяет их к пакетам проекта.
+package migrate
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// Annotation аннотация в комментарии: // @key value
+type Annotation struct {
+	Key   string // Ключ без @
+	Value string // Текст после ключа как есть: значения, параметры name=value и опции
+}
+
+func (a *Annotation) String() string {
+	if a.Value == "" {
+		return "@" + a.Key
+	}
+	return "@" + a.Key + " " + a.Value
+}
+
+// Rule правило миграции. Apply получает аннотации одного комментария
+// (интерфейса, метода, параметра) и возвращает аннотации после миграции.
+// Неизмененные аннотации должны возвращаться как есть.
+type Rule interface {
+	Name() string
+	Description() string
+	Apply(annotations []*Annotation) []*Annotation
+}
+
+type rule struct {
+	name, description string
+	apply             func([]*Annotation) []*Annotation
+}
+
+func (r *rule) Name() string        { return r.name }
+func (r *rule) Description() string { return i18n.T(r.description) }
+
+func (r *rule) Apply(annotations []*Annotation) []*Annotation {
+	return r.apply(annotations)
+}
+
+// NewRule создает правило из функции. Описание может быть идентификатором сообщения
+// каталога i18n, тогда оно выводится на текущем языке, иначе выводится как есть.
+func NewRule(name, description string, apply func([]*Annotation) []*Annotation) Rule {
+	return &rule{name: name, description: description, apply: apply}
+}
+
+// RenameKey переименовывает ключ from в to
+func RenameKey(name, description, from, to string) Rule {
+	return NewRule(name, description, func(annotations []*Annotation) []*Annotation {
+		result := make([]*Annotation, len(annotations))
+		for i, a := range annotations {
+			if a.Key == from {
+				a = &Annotation{Key: to, Value: a.Value}
+			}
+			result[i] = a
+		}
+		return result
+	})
+}
+
+// RewriteValue изменяет значение аннотаций с ключом key
+func RewriteValue(name, description, key string, rewrite func(value string) string) Rule {
+	return NewRule(name, description, func(annotations []*Annotation) []*Annotation {
+		result := make([]*Annotation, len(annotations))
+		for i, a := range annotations {
+			if a.Key == key {
+				if value := rewrite(a.Value); value != a.Value {
+					a = &Annotation{Key: a.Key, Value: value}
+				}
+			}
+			result[i] = a
+		}
+		return result
+	})
+}
+
+// Split заменяет каждую аннотацию с ключом key на аннотации, которые вернула split
+func Split(name, description, key string, split func(a *Annotation) []*Annotation) Rule {
+	return NewRule(name, description, func(annotations []*Annotation) (result []*Annotation) {
+		for _, a := range annotations {
+			if a.Key == key {
+				result = append(result, split(a)...)
+				continue
+			}
+			result = append(result, a)
+		}
+		return result
+	})
+}
+
+// Merge объединяет аннотации с ключами keys в одну аннотацию, которую вернула merge.
+// Аннотация ставится на место первой из объединяемых.
+func Merge(name, description string, keys []string, merge func(parts []*Annotation) *Annotation) Rule {
+	return NewRule(name, description, func(annotations []*Annotation) []*Annotation {
+		var (
+			parts  []*Annotation
+			result []*Annotation
+			at     = -1
+		)
+		for _, a := range annotations {
+			if !slices.Contains(keys, a.Key) {
+				result = append(result, a)
+				continue
+			}
+			if at == -1 {
+				at = len(result)
+			}
+			parts = append(parts, a)
+		}
+		if len(parts) < 2 { //nolint: mnd
+			return annotations
+		}
+		return append(result[:at], append([]*Annotation{merge(parts)}, result[at:]...)...)
+	})
+}
+
+// Registry реестр правил миграции
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// NewRegistry создает пустой реестр правил
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]Rule)}
+}
+
+// DefaultRegistry реестр, в котором регистрируют правила встроенные плагины
+var DefaultRegistry = NewRegistry()
+
+// Register регистрирует правила в DefaultRegistry
+func Register(rules ...Rule) {
+	DefaultRegistry.Register(rules...)
+}
+
+// Register регистрирует правила, правило с тем же именем заменяется
+func (r *Registry) Register(rules ...Rule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rule := range rules {
+		r.rules[rule.Name()] = rule
+	}
+}
+
+// Rules возвращает правила, отсортированные по имени
+func (r *Registry) Rules() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name() < rules[j].Name() })
+	return rules
+}
+
+// Lookup возвращает правила по именам
+func (r *Registry) Lookup(names ...string) ([]Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]Rule, 0, len(names))
+	var unknown []string
+	for _, name := range names {
+		rule, ok := r.rules[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(unknown) > 0 {
+		return nil, i18n.Errorf("migrate.unknown-rules", strings.Join(unknown, ", "))
+	}
+	return rules, nil
+}
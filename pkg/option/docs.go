@@ -0,0 +1,219 @@
+package option
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/vmihailenco/tagparser/v2"
+
+	"github.com/go-mosaic/gomosaic/pkg/annotation"
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+const docgenPrefix = "docgen-"
+
+// FieldDoc документация поля структуры аннотаций из комментариев @docgen-*
+type FieldDoc struct {
+	Title       string   // @docgen-title "заголовок"
+	Description string   // @docgen-descr "описание" и @docgen-option "имя" "описание"
+	ValueDescr  string   // @docgen-option-descr "описание значения"
+	Examples    []string // @docgen-example "заголовок" "пример"
+}
+
+// Docs документация полей структур аннотаций по ключу "Тип.Поле"
+type Docs map[string]*FieldDoc
+
+// ParseDocs собирает комментарии @docgen-* полей структур из исходного кода Go.
+// Плагины встраивают исходный код структур аннотаций через go:embed,
+// чтобы документация была доступна в собранном бинарном файле.
+func ParseDocs(sources ...[]byte) (Docs, error) {
+	docs := make(Docs)
+	for _, src := range sources {
+		file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range st.Fields.List {
+				doc := parseFieldDoc(field.Doc)
+				if doc == nil {
+					continue
+				}
+				for _, name := range field.Names {
+					docs[spec.Name.Name+"."+name.Name] = doc
+				}
+			}
+			return false
+		})
+	}
+	return docs, nil
+}
+
+func parseFieldDoc(group *ast.CommentGroup) *FieldDoc {
+	if group == nil {
+		return nil
+	}
+
+	var (
+		doc   FieldDoc
+		found bool
+	)
+	for _, comment := range group.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(text, "@"+docgenPrefix) {
+			continue
+		}
+		a, err := annotation.Parse(text)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		switch strings.TrimPrefix(a.Key, docgenPrefix) {
+		case "title":
+			doc.Title = a.Value()
+		case "descr":
+			doc.Description = joinLines(doc.Description, a.Value())
+		case "option-descr":
+			doc.ValueDescr = a.Value()
+		case "option":
+			if len(a.Options) > 1 {
+				doc.Description = joinLines(doc.Description, a.Options[0]+": "+a.Options[1])
+			}
+		case "example":
+			if len(a.Options) > 1 {
+				doc.Examples = append(doc.Examples, a.Options[1])
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &doc
+}
+
+func joinLines(s, line string) string {
+	if s == "" {
+		return line
+	}
+	return s + "\n" + line
+}
+
+// DescribeAnnotations возвращает описание аннотаций, которые Unmarshal читает
+// в структуру v с префиксом prefix, дополненное документацией docs.
+func DescribeAnnotations(prefix string, target gomosaic.AnnotationTarget, v any, docs Docs) []*gomosaic.AnnotationDoc {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return describeAnnotations(prefix, target, t, docs)
+}
+
+func describeAnnotations(prefix string, target gomosaic.AnnotationTarget, t reflect.Type, docs Docs) (result []*gomosaic.AnnotationDoc) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, ok := parseTag(field)
+		if !ok || name == "" {
+			continue
+		}
+
+		doc := &gomosaic.AnnotationDoc{
+			Key:    prefix + "-" + name,
+			Target: target,
+			Type:   field.Type.Kind().String(),
+		}
+
+		fieldType := field.Type
+		switch {
+		case slices.Contains(options, "asFlag"):
+			doc.Type = "flag"
+		case fieldType.Kind() == reflect.Struct && !hasInlineOption(options):
+			result = append(result, describeAnnotations(doc.Key, target, fieldType, docs)...)
+			continue
+		case fieldType.Kind() == reflect.Struct, fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct:
+			if !hasInlineOption(options) {
+				continue
+			}
+			if fieldType.Kind() == reflect.Slice {
+				fieldType = fieldType.Elem()
+			}
+			doc.Type = "inline"
+			describeInline(doc, fieldType, docs)
+		case fieldType.Kind() == reflect.Slice:
+			doc.Type = "[]" + fieldType.Elem().Kind().String()
+		}
+
+		if values := validValues(field); values != nil {
+			doc.Values = values
+		}
+		applyFieldDoc(doc, docs[t.Name()+"."+field.Name])
+
+		result = append(result, doc)
+	}
+	return result
+}
+
+// describeInline дополняет описание inline аннотации ее параметрами, флагами и описанием значения
+func describeInline(doc *gomosaic.AnnotationDoc, t reflect.Type, docs Docs) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, ok := parseTag(field)
+		if !ok {
+			continue
+		}
+		fieldDoc := docs[t.Name()+"."+field.Name]
+
+		switch {
+		case slices.Contains(options, "fromParam"):
+			doc.Params = append(doc.Params, name)
+		case slices.Contains(options, "fromOption"):
+			doc.Options = append(doc.Options, name)
+		case slices.Contains(options, "fromValue"):
+			doc.Values = validValues(field)
+			if fieldDoc != nil && doc.ValueDescr == "" {
+				doc.ValueDescr = fieldDoc.ValueDescr
+			}
+		}
+	}
+}
+
+func applyFieldDoc(doc *gomosaic.AnnotationDoc, fieldDoc *FieldDoc) {
+	if fieldDoc == nil {
+		return
+	}
+	doc.Title = fieldDoc.Title
+	doc.Description = fieldDoc.Description
+	if fieldDoc.ValueDescr != "" {
+		doc.ValueDescr = fieldDoc.ValueDescr
+	}
+	doc.Examples = fieldDoc.Examples
+}
+
+// validValues возвращает допустимые значения из тега valid:"in,params:'...'"
+func validValues(field reflect.StructField) []string {
+	validTag, ok := field.Tag.Lookup("valid")
+	if !ok {
+		return nil
+	}
+	tag := tagparser.Parse(validTag)
+	if tag.Name != "in" {
+		return nil
+	}
+	return strings.Fields(tag.Options["params"])
+}
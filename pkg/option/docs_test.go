@@ -0,0 +1,91 @@
+package option
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+const docsSource = `package option
+
+type testOption struct {
+	// @docgen-title "Имя"
+	// @docgen-descr "Имя теста"
+	// @docgen-option "complex" "Сложное значение"
+	// @docgen-example "Пример" "@test-name complex"
+	Name string
+	Foo  string
+}
+
+type ErrorWrapper struct {
+	// @docgen-option-descr "Путь к ошибке"
+	Path string
+}
+`
+
+func TestDescribeAnnotations(t *testing.T) {
+	docs, err := ParseDocs([]byte(docsSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]gomosaic.AnnotationDoc)
+	for _, doc := range DescribeAnnotations("test", gomosaic.TargetInterface, &testOption{}, docs) {
+		got[doc.Key] = *doc
+	}
+
+	tests := []struct {
+		name string
+		want gomosaic.AnnotationDoc
+	}{
+		{
+			name: "документация и значения valid:in",
+			want: gomosaic.AnnotationDoc{
+				Key:         "test-name",
+				Target:      gomosaic.TargetInterface,
+				Type:        "string",
+				Title:       "Имя",
+				Description: "Имя теста\ncomplex: Сложное значение",
+				Values:      []string{"complex", "value"},
+				Examples:    []string{"@test-name complex"},
+			},
+		},
+		{
+			name: "флаг",
+			want: gomosaic.AnnotationDoc{Key: "test-api-doc", Target: gomosaic.TargetInterface, Type: "flag"},
+		},
+		{
+			name: "вложенная структура",
+			want: gomosaic.AnnotationDoc{Key: "test-openapi-tags", Target: gomosaic.TargetInterface, Type: "[]string"},
+		},
+		{
+			name: "inline срез структур",
+			want: gomosaic.AnnotationDoc{
+				Key:     "test-openapi-header",
+				Target:  gomosaic.TargetInterface,
+				Type:    "inline",
+				Params:  []string{"title"},
+				Options: []string{"required"},
+			},
+		},
+		{
+			name: "inline структура с описанием значения",
+			want: gomosaic.AnnotationDoc{
+				Key:        "test-error-wrapper",
+				Target:     gomosaic.TargetInterface,
+				Type:       "inline",
+				ValueDescr: "Путь к ошибке",
+				Params:     []string{"iface"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if doc := got[tt.want.Key]; !reflect.DeepEqual(doc, tt.want) {
+				t.Errorf("DescribeAnnotations() %s = %+v, want %+v", tt.want.Key, doc, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package option
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// Source источник значения поля структуры параметров
+type Source struct {
+	Annotation *gomosaic.AnnotationInfo // Аннотация, из которой получено значение
+	Rule       string                   // Правило по умолчанию, наследования или вычисления значения
+}
+
+func (s *Source) String() string {
+	switch {
+	case s.Annotation != nil && s.Rule != "":
+		return s.Rule + " (" + s.Annotation.String() + ")"
+	case s.Annotation != nil:
+		return s.Annotation.String()
+	}
+	return s.Rule
+}
+
+// Trace источники значений полей структуры параметров по пути поля
+// относительно структуры: Path, Default.Accept, NameOpt.Value, Values[0].Name.
+type Trace map[string]*Source
+
+// Set записывает источник значения поля: аннотацию, правило или и то, и другое
+func (t Trace) Set(field string, annotation *gomosaic.AnnotationInfo, rule string) {
+	t[field] = &Source{Annotation: annotation, Rule: rule}
+}
+
+// Annotation возвращает аннотацию, которая задала значение поля
+func (t Trace) Annotation(field string) *gomosaic.AnnotationInfo {
+	if s, ok := t[field]; ok {
+		return s.Annotation
+	}
+	return nil
+}
+
+// Explain возвращает значения полей структуры с тегами option и их источники из trace.
+// Поля без тега option выводятся, только если для них записан источник.
+func Explain(v any, trace Trace) []*gomosaic.ExplainField {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return explain("", rv, trace)
+}
+
+func explain(prefix string, rv reflect.Value, trace Trace) (fields []*gomosaic.ExplainField) {
+	t := rv.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		path := prefix + field.Name
+		_, _, tagged := parseTag(field)
+		fv := rv.Field(i)
+
+		switch {
+		case tagged && field.Type.Kind() == reflect.Struct:
+			fields = append(fields, explain(path+".", fv, trace)...)
+			continue
+		case tagged && field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			for j := range fv.Len() {
+				fields = append(fields, explain(fmt.Sprintf("%s[%d].", path, j), fv.Index(j), trace)...)
+			}
+			continue
+		case !tagged && trace[path] == nil:
+			continue
+		}
+
+		f := &gomosaic.ExplainField{Path: path, Value: explainValue(fv)}
+		switch source := trace[path]; {
+		case source != nil:
+			f.Source = source.String()
+			if source.Annotation != nil {
+				f.Position = source.Annotation.Position
+			}
+		case field.Tag.Get("default") != "":
+			f.Source = i18n.T("option.source-default", field.Tag.Get("default"))
+		case fv.IsZero():
+			f.Source = i18n.T("option.source-unset")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func explainValue(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.Interface())
+	}
+	return fmt.Sprint(v.Interface())
+}
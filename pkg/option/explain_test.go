@@ -0,0 +1,89 @@
+package option
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+func TestExplain(t *testing.T) {
+	annotations, err := gomosaic.ParseAnnotations([]*gomosaic.CommentInfo{
+		{Value: "@http-api-doc", IsAnnotation: true, Position: token.Position{Filename: "svc.go", Line: 3, Column: 17}},
+		{Value: "@http-openapi-header name required title=\"oh no\"", IsAnnotation: true, Position: token.Position{Filename: "svc.go", Line: 4, Column: 40}},
+	})
+	if err != nil {
+		t.Fatalf("ParseAnnotations() error = %v", err)
+	}
+
+	v := &testOption{}
+	trace, err := UnmarshalTrace("http", annotations, v)
+	if err != nil {
+		t.Fatalf("UnmarshalTrace() error = %v", err)
+	}
+	trace.Set("Name", nil, "вычислено загрузчиком")
+
+	got := make(map[string]*gomosaic.ExplainField)
+	for _, field := range Explain(v, trace) {
+		got[field.Path] = field
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		value    string
+		source   string
+		position string
+	}{
+		{
+			name:     "флаг из аннотации",
+			path:     "ApiDocEnable",
+			value:    "true",
+			source:   "@http-api-doc",
+			position: "svc.go:3:17",
+		},
+		{
+			name:     "поле inline аннотации в срезе",
+			path:     "OpenAPI.Headers[0].Title",
+			value:    `"oh no"`,
+			source:   `@http-openapi-header name required title="oh no"`,
+			position: "svc.go:4:40",
+		},
+		{
+			name:   "значение по умолчанию из тега",
+			path:   "Foo",
+			value:  `"baz"`,
+			source: "по умолчанию baz",
+		},
+		{
+			name:   "правило загрузчика",
+			path:   "Name",
+			value:  `""`,
+			source: "вычислено загрузчиком",
+		},
+		{
+			name:   "не заданное поле",
+			path:   "OpenAPI.Tags",
+			value:  "[]",
+			source: "не задано",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, ok := got[tt.path]
+			if !ok {
+				t.Fatalf("поле %s не найдено", tt.path)
+			}
+			if field.Value != tt.value || field.Source != tt.source {
+				t.Errorf("Explain() %s = %s — %s, want %s — %s", tt.path, field.Value, field.Source, tt.value, tt.source)
+			}
+			var position string
+			if field.Position != nil {
+				position = field.Position.String()
+			}
+			if position != tt.position {
+				t.Errorf("Explain() %s position = %s, want %s", tt.path, position, tt.position)
+			}
+		})
+	}
+}
@@ -40,24 +40,32 @@ func (d *decodeState) init(annotations gomosaic.Annotations) {
 }
 
 func Unmarshal(prefix string, annotations gomosaic.Annotations, v any) error {
+	_, err := UnmarshalTrace(prefix, annotations, v)
+	return err
+}
+
+// UnmarshalTrace декодирует аннотации как Unmarshal и возвращает аннотации,
+// из которых получены значения полей. Trace не nil и при ошибках, чтобы загрузчики могли дополнять его правилами.
+func UnmarshalTrace(prefix string, annotations gomosaic.Annotations, v any) (Trace, error) {
 	var d decodeState
 	d.init(annotations)
 
 	rv := reflect.ValueOf(v)
-	if (rv.Kind() != reflect.Pointer || rv.IsNil()) && rv.Kind() != reflect.Struct {
-		return &InvalidUnmarshalError{reflect.TypeOf(v)}
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return Trace{}, &InvalidUnmarshalError{reflect.TypeOf(v)}
 	}
-	t := reflect.TypeOf(v)
+	root := rv.Type().Elem().Name()
 
-	if err := d.unmarshal(t.Elem().Name(), prefix, t, rv); err != nil {
-		return err
+	if err := d.unmarshal(root, prefix, rv.Type(), rv); err != nil {
+		return Trace{}, err
 	}
 
-	if d.errs != nil {
-		return d.errs
+	trace := make(Trace, len(d.fieldTag))
+	for path, tag := range d.fieldTag {
+		trace[strings.TrimPrefix(path, root+".")] = &Source{Annotation: tag}
 	}
 
-	return nil
+	return trace, d.errs
 }
 
 func (d *decodeState) unmarshal(path, prefix string, t reflect.Type, rv reflect.Value) error {
@@ -93,6 +101,8 @@ func (d *decodeState) unmarshal(path, prefix string, t reflect.Type, rv reflect.
 				}
 
 				d.validateValue(rv.Field(i), fieldType, t)
+			} else if err := setDefault(rv.Field(i), fieldType); err != nil {
+				return err
 			}
 		case reflect.Struct:
 			if hasInlineOption(options) {
@@ -131,6 +141,20 @@ func (d *decodeState) unmarshal(path, prefix string, t reflect.Type, rv reflect.
 	return nil
 }
 
+// setDefault устанавливает значение из тега default, если аннотации нет, а поле не заполнено заранее
+func setDefault(v reflect.Value, field reflect.StructField) error {
+	defaultValue, ok := field.Tag.Lookup("default")
+	if !ok || !v.IsZero() {
+		return nil
+	}
+	value, err := parseValue(field.Type, defaultValue)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(value))
+	return nil
+}
+
 func (d *decodeState) validateValue(v reflect.Value, field reflect.StructField, t *gomosaic.AnnotationInfo) {
 	defaultValue, _ := field.Tag.Lookup("default")
 
@@ -142,7 +166,7 @@ func (d *decodeState) validateValue(v reflect.Value, field reflect.StructField,
 		switch tag.Name {
 		case "required":
 			if v.IsZero() {
-				d.errs = multierror.Append(d.errs, fmt.Errorf("%s is required: %s", t.Key, t.Position))
+				d.errs = multierror.Append(d.errs, gomosaic.Errorf("option.required", t.Position, t.Key))
 			}
 		case "in":
 			value := v.Interface()
@@ -152,7 +176,7 @@ func (d *decodeState) validateValue(v reflect.Value, field reflect.StructField,
 
 			params := strings.Split(tag.Options["params"], " ")
 			if !isIn(value, params...) {
-				d.errs = multierror.Append(d.errs, fmt.Errorf("%s valid only params (%s): %s", t.Key, tag.Options["params"], t.Position))
+				d.errs = multierror.Append(d.errs, gomosaic.Errorf("option.in", t.Position, t.Key, tag.Options["params"]))
 			}
 		}
 	}
@@ -190,7 +214,11 @@ func (d *decodeState) newInlineElem(path string, tag *gomosaic.AnnotationInfo, t
 			err error
 		)
 		if _, ok := optionMap["fromParam"]; ok {
-			v, err = parseValue(t.Field(j).Type, tag.Params[name])
+			param, ok := tag.Params[name]
+			if !ok {
+				param = t.Field(j).Tag.Get("default")
+			}
+			v, err = parseValue(t.Field(j).Type, param)
 			if err != nil {
 				return reflect.Value{}, err
 			}
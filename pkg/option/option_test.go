@@ -2,12 +2,20 @@ package option
 
 import (
 	"go/token"
+	"os"
 	"reflect"
 	"testing"
 
 	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
 )
 
+// TestMain закрепляет русский язык сообщений, тексты которых проверяют тесты и эталоны
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Russian)
+	os.Exit(m.Run())
+}
+
 type OpenAPI struct {
 	Test    string          `option:"name" valid:"required"`
 	Headers []OpenAPIHeader `option:"header,inline"`
@@ -126,6 +134,28 @@ func TestUnmarshal(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "значения по умолчанию без аннотаций",
+			args: args{
+				prefix: "http",
+				comments: []*gomosaic.CommentInfo{
+					{
+						Value:        "@http-name complex",
+						IsAnnotation: true,
+						Position:     token.Position{},
+					},
+				},
+				v: &testOption{},
+				want: &testOption{
+					Name: "complex",
+					Foo:  "baz",
+					OpenAPI: OpenAPI{
+						Headers: []OpenAPIHeader{},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
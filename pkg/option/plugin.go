@@ -0,0 +1,121 @@
+package option
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/vmihailenco/tagparser/v2"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+	"github.com/go-mosaic/gomosaic/pkg/i18n"
+)
+
+// pluginOptionsPrefix префикс, под которым параметры плагина декодируются как аннотации
+const pluginOptionsPrefix = "opt"
+
+// Info описание параметра плагина для справки
+type Info struct {
+	Name    string // Имя параметра (вложенные структуры разделяются дефисом)
+	Type    string // Тип значения
+	Usage   string // Описание из тега usage: идентификатор сообщения каталога i18n или текст
+	Valid   string // Ограничения из тега valid
+	Default string // Значение по умолчанию из тега default
+}
+
+// Describe возвращает описание параметров структуры с тегами option.
+// Ненулевые значения полей переданной структуры считаются значениями по умолчанию.
+func Describe(v any) []*Info {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return describe("", rv)
+}
+
+func describe(prefix string, rv reflect.Value) (infos []*Info) {
+	t := rv.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, ok := parseTag(field)
+		if !ok || name == "" {
+			continue
+		}
+
+		info := &Info{
+			Name:    prefix + name,
+			Type:    field.Type.String(),
+			Usage:   i18n.T(field.Tag.Get("usage")),
+			Valid:   describeValid(field.Tag.Get("valid")),
+			Default: field.Tag.Get("default"),
+		}
+
+		switch {
+		case slices.Contains(options, "asFlag"):
+			info.Type = "flag"
+		case field.Type.Kind() == reflect.Struct && !hasInlineOption(options):
+			infos = append(infos, describe(info.Name+"-", rv.Field(i))...)
+			continue
+		}
+
+		if fv := rv.Field(i); !fv.IsZero() {
+			info.Default = fmt.Sprint(fv.Interface())
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+func describeValid(validTag string) string {
+	if validTag == "" {
+		return ""
+	}
+	tag := tagparser.Parse(validTag)
+	switch tag.Name {
+	case "required":
+		return i18n.T("option.describe-required")
+	case "in":
+		return i18n.T("option.describe-in", tag.Options["params"])
+	}
+	return validTag
+}
+
+// UnmarshalPluginOptions декодирует параметры плагина, переданные через --opt или конфигурацию,
+// в структуру с тегами option. Проверки тега valid выполняются так же, как для аннотаций,
+// а неизвестные параметры считаются ошибкой.
+func UnmarshalPluginOptions(opts *gomosaic.PluginOptions, v any) (errs error) {
+	known := make(map[string]bool)
+	for _, info := range Describe(v) {
+		known[info.Name] = true
+	}
+
+	for _, key := range sortedKeys(opts.Values) {
+		if !known[key] {
+			errs = multierror.Append(errs, i18n.Errorf("option.unknown",
+				key, opts.Plugin, strings.Join(sortedKeys(known), ", ")))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	annotations, err := opts.Annotations(pluginOptionsPrefix)
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(pluginOptionsPrefix, annotations, v)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
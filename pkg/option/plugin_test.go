@@ -0,0 +1,76 @@
+package option
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+type testPluginOpt struct {
+	Filename string `option:"filename" usage:"Имя файла"`
+	Mode     string `option:"mode" valid:"in,params:'fast slow'" default:"fast"`
+	Debug    bool   `option:"debug,asFlag"`
+	Limits   struct {
+		Max int `option:"max"`
+	} `option:"limits"`
+}
+
+func TestDescribe(t *testing.T) {
+	got := Describe(&testPluginOpt{Filename: "gen.go"})
+	want := []*Info{
+		{Name: "filename", Type: "string", Usage: "Имя файла", Default: "gen.go"},
+		{Name: "mode", Type: "string", Valid: "одно из: fast slow", Default: "fast"},
+		{Name: "debug", Type: "flag"},
+		{Name: "limits-max", Type: "int"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		for _, info := range got {
+			t.Logf("%+v", info)
+		}
+		t.Errorf("Describe() mismatch")
+	}
+}
+
+func TestUnmarshalPluginOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    testPluginOpt
+		wantErr bool
+	}{
+		{
+			name:   "значения полей сохраняются",
+			values: map[string]string{},
+			want:   testPluginOpt{Filename: "gen.go", Mode: "fast"},
+		},
+		{
+			name:   "значения переопределяются",
+			values: map[string]string{"filename": "api.go", "mode": "slow", "debug": ""},
+			want:   testPluginOpt{Filename: "api.go", Mode: "slow", Debug: true},
+		},
+		{
+			name:    "неизвестный параметр",
+			values:  map[string]string{"file": "api.go"},
+			wantErr: true,
+		},
+		{
+			name:    "значение не из списка",
+			values:  map[string]string{"mode": "medium"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testPluginOpt{Filename: "gen.go"}
+			err := UnmarshalPluginOptions(&gomosaic.PluginOptions{Plugin: "test", Values: tt.values}, &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalPluginOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalPluginOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
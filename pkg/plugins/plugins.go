@@ -0,0 +1,14 @@
+// Package plugins регистрирует встроенные плагины gomosaic в gomosaic.DefaultPluginManager.
+// Пакет импортируется ради побочного эффекта теми, кто вызывает gomosaic.Run или создает
+// анализатор без командной строки gomosaic:
+//
+//	import _ "github.com/go-mosaic/gomosaic/pkg/plugins"
+package plugins
+
+import (
+	_ "github.com/go-mosaic/gomosaic/internal/plugin/http"
+	_ "github.com/go-mosaic/gomosaic/internal/plugin/implstub"
+	_ "github.com/go-mosaic/gomosaic/internal/plugin/logmiddleware"
+	_ "github.com/go-mosaic/gomosaic/internal/plugin/metricmiddleware"
+	_ "github.com/go-mosaic/gomosaic/internal/plugin/template"
+)
@@ -0,0 +1,25 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/go-mosaic/gomosaic/pkg/gomosaic"
+)
+
+func TestBuiltinPlugins(t *testing.T) {
+	names := []string{
+		"http-server-chi",
+		"http-server-echo",
+		"http-client",
+		"http-client-test",
+		"impl-stub",
+		"log-middleware",
+		"metric-middleware",
+		"template",
+	}
+	for _, name := range names {
+		if _, err := gomosaic.DefaultPluginManager.GetPlugin(name); err != nil {
+			t.Errorf("встроенный плагин %s не зарегистрирован: %v", name, err)
+		}
+	}
+}
@@ -1,146 +1,192 @@
 package strcase
 
-var uppercaseAcronym = map[string]string{
-	"AFK":     "afk",
-	"BBIAB":   "bbiab",
-	"BBL":     "bbl",
-	"BBS":     "bbs",
-	"BEG":     "beg",
-	"BRB":     "brb",
-	"BTW":     "btw",
-	"EG":      "eg",
-	"FISH":    "fish",
-	"IDK":     "idk",
-	"IMO":     "imo",
-	"IRL":     "irl",
-	"KISS":    "kiss",
-	"LMK":     "lmk",
-	"LOL":     "lol",
-	"NYOB":    "nyob",
-	"OFC":     "ofc",
-	"OMG":     "omg",
-	"PANS":    "pans",
-	"PHAT":    "phat",
-	"POS":     "pos",
-	"ROFL":    "rofl",
-	"SMH":     "smh",
-	"TTYL":    "ttyl",
-	"YOLO":    "yolo",
-	"WTH":     "wth",
-	"ASAP":    "asap",
-	"AWOL":    "awol",
-	"CIA":     "cia",
-	"CPL":     "cpl",
-	"CPS":     "cps",
-	"CPT":     "cpt",
-	"CSI":     "csi",
-	"DAFB":    "dafb",
-	"DMV":     "dmv",
-	"DNC":     "dnc",
-	"DOD":     "dod",
-	"DON":     "don",
-	"DZ":      "dz",
-	"FBI":     "fbi",
-	"FUBAR":   "fubar",
-	"GIB":     "gib",
-	"MAJ":     "maj",
-	"MIA":     "mia",
-	"OSHA":    "osha",
-	"NAFTA":   "nafta",
-	"NASA":    "nasa",
-	"POTUS":   "potus",
-	"POW":     "pow",
-	"RNC":     "rnc",
-	"REAP":    "reap",
-	"SCOTUS":  "scotus",
-	"SGT":     "sgt",
-	"SWAT":    "swat",
-	"UN":      "un",
-	"USAF":    "usaf",
-	"ADD":     "add",
-	"ADHD":    "adhd",
-	"AIDS":    "aids",
-	"AMA":     "ama",
-	"CDC":     "cdc",
-	"DARE":    "dare",
-	"DOA":     "doa",
-	"DOB":     "dob",
-	"DIY":     "diy",
-	"ESL":     "esl",
-	"FAQ":     "faq",
-	"GIF":     "gif",
-	"HIV":     "hiv",
-	"ID":      "id",
-	"IQ":      "iq",
-	"MD":      "md",
-	"OTC":     "otc",
-	"PPV":     "ppv",
-	"PS":      "ps",
-	"RADAR":   "radar",
-	"SONAR":   "sonar",
-	"SUV":     "suv",
-	"TBA":     "tba",
-	"UFO":     "ufo",
-	"ADP":     "adp",
-	"AKA":     "aka",
-	"CAPTCHA": "captcha",
-	"CC":      "cc",
-	"CST":     "cst",
-	"DAEMON":  "daemon",
-	"DBA":     "dba",
-	"DND":     "dnd",
-	"EDS":     "eds",
-	"EOD":     "eod",
-	"EST":     "est",
-	"ETA":     "eta",
-	"FYI":     "fyi",
-	"HR":      "hr",
-	"MBA":     "mba",
-	"MST":     "mst",
-	"NASDAQ":  "nasdaq",
-	"OT":      "ot",
-	"PR":      "pr",
-	"PST":     "pst",
-	"SWOT":    "swot",
-	"SMART":   "smart",
-	"TBD":     "tbd",
-	"TED":     "ted",
-	"AA":      "aa",
-	"AAAS":    "aaas",
-	"AARP":    "aarp",
-	"ADA":     "ada",
-	"AFL":     "afl",
-	"APA":     "apa",
-	"BBW":     "bbw",
-	"BF":      "bf",
-	"ESPN":    "espn",
-	"F2F":     "f2f",
-	"FLAG":    "flag",
-	"GF":      "gf",
-	"IR":      "ir",
-	"LDR":     "ldr",
-	"MADD":    "madd",
-	"MLA":     "mla",
-	"NBA":     "nba",
-	"NFL":     "nfl",
-	"NHL":     "nhl",
-	"PAWS":    "paws",
-	"PGA":     "pga",
-	"SPCA":    "spca",
-	"SO":      "so",
-	"SWF":     "swf",
-	"SWM":     "swm",
-	"TS":      "ts",
-	"VBD":     "vbd",
-	"WWE":     "wwe",
-	"ZIP":     "zip",
-	"SKU":     "sku",
-	"URL":     "url",
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultInitialisms Go initialisms that are written in a single case inside identifiers
+// (userID, APIURL, HTTPServer), see https://go.dev/wiki/CodeReviewComments#initialisms
+var defaultInitialisms = map[string]bool{
+	"ACL":   true,
+	"AMQP":  true,
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"CSS":   true,
+	"DB":    true,
+	"DNS":   true,
+	"EOF":   true,
+	"GID":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"JWT":   true,
+	"LHS":   true,
+	"QPS":   true,
+	"RAM":   true,
+	"RHS":   true,
+	"RPC":   true,
+	"SKU":   true,
+	"SLA":   true,
+	"SMTP":  true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"TTL":   true,
+	"UDP":   true,
+	"UI":    true,
+	"UID":   true,
+	"URI":   true,
+	"URL":   true,
+	"UTF8":  true,
+	"UUID":  true,
+	"VM":    true,
+	"XML":   true,
+	"XMPP":  true,
+	"XSRF":  true,
+	"XSS":   true,
+}
+
+// Caser converts identifiers between cases using its own initialisms table,
+// so that different generation runs do not share additional initialisms
+type Caser struct {
+	mu          sync.RWMutex
+	initialisms map[string]bool
+}
+
+// New returns a Caser with the default initialisms table extended by the words
+func New(words ...string) *Caser {
+	c := &Caser{initialisms: make(map[string]bool, len(defaultInitialisms)+len(words))}
+	for word := range defaultInitialisms {
+		c.initialisms[word] = true
+	}
+	c.AddInitialisms(words...)
+	return c
+}
+
+var defaultCaser = New()
+
+// Default returns the Caser used by the package level functions
+func Default() *Caser {
+	return defaultCaser
+}
+
+// AddInitialisms extends the initialisms table, e.g. AddInitialisms("GRPC", "OTP")
+func (c *Caser) AddInitialisms(words ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, word := range words {
+		c.initialisms[strings.ToUpper(word)] = true
+	}
+}
+
+// IsInitialism reports whether the word (in any case) is an initialism
+func (c *Caser) IsInitialism(word string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.initialisms[strings.ToUpper(word)]
+}
+
+// Initialisms returns the sorted initialisms table
+func (c *Caser) Initialisms() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	words := make([]string, 0, len(c.initialisms))
+	for word := range c.initialisms {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// AddInitialisms extends the initialisms table of the package level functions
+func AddInitialisms(words ...string) {
+	defaultCaser.AddInitialisms(words...)
+}
+
+// IsInitialism reports whether the word (in any case) is an initialism of the package level functions
+func IsInitialism(word string) bool {
+	return defaultCaser.IsInitialism(word)
+}
+
+// Initialisms returns the sorted initialisms table of the package level functions
+func Initialisms() []string {
+	return defaultCaser.Initialisms()
+}
+
+// AddAcronym adds an initialism.
+//
+// Deprecated: use AddInitialisms, the replacement value is ignored.
+func AddAcronym(acronym, _ string) {
+	AddInitialisms(acronym)
 }
 
-func AddAcronym(acronym, v string) {
-	if _, ok := uppercaseAcronym[acronym]; ok {
-		panic("acronym exists: " + acronym)
+// splitInitialisms inserts the delimiter between initialisms written together
+// in a single run of capital letters (APIURL -> API.URL), so that the run is
+// split into words. Runs that are not made of initialisms are left intact.
+func (c *Caser) splitInitialisms(s string, delimiter uint8) string {
+	var n strings.Builder
+	for i := 0; i < len(s); {
+		if !isUpper(s[i]) {
+			n.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(s) && isUpper(s[j]) {
+			j++
+		}
+		// the last capital letter before a lowercase one starts the next word (JSONData)
+		end := j
+		if j < len(s) && isLower(s[j]) && j-i > 1 {
+			end = j - 1
+		}
+
+		if parts := c.initialismParts(s[i:end]); len(parts) > 1 {
+			n.WriteString(strings.Join(parts, string(delimiter)))
+		} else {
+			n.WriteString(s[i:end])
+		}
+		i = end
+		if end < j {
+			n.WriteString(s[end:j])
+			i = j
+		}
 	}
-	uppercaseAcronym[acronym] = v
+	return n.String()
 }
+
+// initialismParts splits the run into initialisms, preferring the longest ones.
+// It returns nil if the run can not be fully split.
+func (c *Caser) initialismParts(run string) []string {
+	if run == "" {
+		return nil
+	}
+	for size := len(run); size > 0; size-- {
+		if !c.IsInitialism(run[:size]) {
+			continue
+		}
+		if size == len(run) {
+			return []string{run}
+		}
+		if rest := c.initialismParts(run[size:]); rest != nil {
+			return append([]string{run[:size]}, rest...)
+		}
+	}
+	return nil
+}
+
+func isUpper(b byte) bool { return b >= 'A' && b <= 'Z' }
+
+func isLower(b byte) bool { return b >= 'a' && b <= 'z' }
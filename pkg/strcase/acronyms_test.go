@@ -0,0 +1,73 @@
+package strcase
+
+import (
+	"testing"
+)
+
+func TestInitialismsRoundTrip(t *testing.T) {
+	identifiers := []string{
+		"UserID",
+		"APIURL",
+		"HTTPServer",
+		"JSONData",
+		"GetUserByID",
+		"XMLHTTPRequest",
+		"NewUUID",
+		"HTMLToPdf",
+		"SQLDB",
+	}
+	for _, id := range identifiers {
+		if got := ToCamel(ToSnake(id)); got != id {
+			t.Errorf("ToCamel(ToSnake(%q)) = %q", id, got)
+		}
+		if got := ToCamel(ToKebab(id)); got != id {
+			t.Errorf("ToCamel(ToKebab(%q)) = %q", id, got)
+		}
+		if got := ToCamel(ToLowerCamel(id)); got != id {
+			t.Errorf("ToCamel(ToLowerCamel(%q)) = %q", id, got)
+		}
+	}
+
+	snakes := []string{
+		"user_id",
+		"api_url",
+		"http_server",
+		"order_uuid",
+		"xml_http_request",
+	}
+	for _, s := range snakes {
+		if got := ToSnake(ToCamel(s)); got != s {
+			t.Errorf("ToSnake(ToCamel(%q)) = %q", s, got)
+		}
+		if got := ToSnake(ToLowerCamel(s)); got != s {
+			t.Errorf("ToSnake(ToLowerCamel(%q)) = %q", s, got)
+		}
+	}
+}
+
+func TestCaserInitialisms(t *testing.T) {
+	c := New("grpc")
+
+	if got := c.ToCamel("grpc_client"); got != "GRPCClient" {
+		t.Errorf("ToCamel() = %q, want GRPCClient", got)
+	}
+	if got := c.ToSnake("GRPCHTTPGateway"); got != "grpc_http_gateway" {
+		t.Errorf("ToSnake() = %q, want grpc_http_gateway", got)
+	}
+
+	// additional initialisms do not leak into the package level table and other instances
+	if got := ToCamel("grpc_client"); got != "GrpcClient" {
+		t.Errorf("ToCamel() = %q, want GrpcClient", got)
+	}
+	if got := New().ToCamel("grpc_client"); got != "GrpcClient" {
+		t.Errorf("New().ToCamel() = %q, want GrpcClient", got)
+	}
+
+	c.AddInitialisms("otp")
+	if got := c.ToLowerCamel("otp_code"); got != "otpCode" {
+		t.Errorf("ToLowerCamel() = %q, want otpCode", got)
+	}
+	if got := c.ToCamel("otp_code"); got != "OTPCode" {
+		t.Errorf("ToCamel() = %q, want OTPCode", got)
+	}
+}
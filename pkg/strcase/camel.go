@@ -4,53 +4,71 @@ import (
 	"strings"
 )
 
-// Converts a string to CamelCase
-func toCamelInitCase(s string, initCase bool) string {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return s
-	}
-
-	if a, ok := uppercaseAcronym[s]; ok {
-		s = a
-	}
+// Converts a string to CamelCase.
+// The string is split into words the same way as ToSnake does, and initialisms
+// are written in upper case: user_id -> UserID, api_url -> APIURL.
+func (c *Caser) toCamelInitCase(s string, initCase bool) string {
+	words := strings.FieldsFunc(c.ToSnake(s), func(r rune) bool {
+		return r == '_' || r == '.'
+	})
 
 	n := strings.Builder{}
 	n.Grow(len(s))
-	capNext := initCase
-	for i, v := range []byte(s) {
-		vIsCap := v >= 'A' && v <= 'Z'
-		vIsLow := v >= 'a' && v <= 'z'
-		if capNext {
-			if vIsLow {
-				v += 'A'
-				v -= 'a'
-			}
-		} else if i == 0 {
-			if vIsCap {
-				v += 'a'
-				v -= 'A'
-			}
+	for _, word := range words {
+		word = alphanumeric(word)
+		if word == "" {
+			continue
 		}
-		if vIsCap || vIsLow {
-			n.WriteByte(v)
-			capNext = false
-		} else if vIsNum := v >= '0' && v <= '9'; vIsNum {
-			n.WriteByte(v)
-			capNext = true
-		} else {
-			capNext = v == '_' || v == ' ' || v == '-' || v == '.'
+		if n.Len() == 0 && !initCase {
+			n.WriteString(word)
+			continue
 		}
+		n.WriteString(c.capitalize(word))
 	}
 	return n.String()
 }
 
+// capitalize writes an initialism (or the letters of a word before digits, ID3 -> ID3)
+// in upper case, and capitalizes the first letter of any other word
+func (c *Caser) capitalize(word string) string {
+	if c.IsInitialism(word) {
+		return strings.ToUpper(word)
+	}
+
+	letters := strings.IndexFunc(word, func(r rune) bool { return r >= '0' && r <= '9' })
+	if letters > 0 && c.IsInitialism(word[:letters]) {
+		return strings.ToUpper(word[:letters]) + word[letters:]
+	}
+
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+// alphanumeric drops everything except ASCII letters and digits
+func alphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// ToCamel converts a string to CamelCase
+func (c *Caser) ToCamel(s string) string {
+	return c.toCamelInitCase(s, true)
+}
+
+// ToLowerCamel converts a string to lowerCamelCase
+func (c *Caser) ToLowerCamel(s string) string {
+	return c.toCamelInitCase(s, false)
+}
+
 // ToCamel converts a string to CamelCase
 func ToCamel(s string) string {
-	return toCamelInitCase(s, true)
+	return defaultCaser.ToCamel(s)
 }
 
 // ToLowerCamel converts a string to lowerCamelCase
 func ToLowerCamel(s string) string {
-	return toCamelInitCase(s, false)
+	return defaultCaser.ToLowerCamel(s)
 }
@@ -16,7 +16,16 @@ func toCamel(tb testing.TB) {
 		{"AnyKind of_string", "AnyKindOfString"},
 		{"odd-fix", "OddFix"},
 		{"numbers2And55with000", "Numbers2And55With000"},
-		{"ID", "Id"},
+		{"ID", "ID"},
+		{"user_id", "UserID"},
+		{"userId", "UserID"},
+		{"api_url", "APIURL"},
+		{"apiURL", "APIURL"},
+		{"http-server", "HTTPServer"},
+		{"JSONData", "JSONData"},
+		{"uuid", "UUID"},
+		{"id3_ver", "ID3Ver"},
+		{"xml_http_request", "XMLHTTPRequest"},
 	}
 	for _, i := range cases {
 		in := i[0]
@@ -44,6 +53,10 @@ func toLowerCamel(tb testing.TB) {
 		{"AnyKind of_string", "anyKindOfString"},
 		{"AnyKind.of-string", "anyKindOfString"},
 		{"ID", "id"},
+		{"user_id", "userID"},
+		{"URLPath", "urlPath"},
+		{"api_url", "apiURL"},
+		{"HTTPServer", "httpServer"},
 		{"some string", "someString"},
 		{" some string", "someString"},
 	}
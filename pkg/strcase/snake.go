@@ -5,40 +5,43 @@ import (
 )
 
 // ToSnake converts a string to snake_case
-func ToSnake(s string) string {
-	return ToDelimited(s, '_')
+func (c *Caser) ToSnake(s string) string {
+	return c.ToDelimited(s, '_')
 }
 
-func ToSnakeWithIgnore(s string, ignore uint8) string {
-	return ToScreamingDelimited(s, '_', ignore, false)
+func (c *Caser) ToSnakeWithIgnore(s string, ignore uint8) string {
+	return c.ToScreamingDelimited(s, '_', ignore, false)
 }
 
 // ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE
-func ToScreamingSnake(s string) string {
-	return ToScreamingDelimited(s, '_', 0, true)
+func (c *Caser) ToScreamingSnake(s string) string {
+	return c.ToScreamingDelimited(s, '_', 0, true)
 }
 
 // ToKebab converts a string to kebab-case
-func ToKebab(s string) string {
-	return ToDelimited(s, '-')
+func (c *Caser) ToKebab(s string) string {
+	return c.ToDelimited(s, '-')
 }
 
 // ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE
-func ToScreamingKebab(s string) string {
-	return ToScreamingDelimited(s, '-', 0, true)
+func (c *Caser) ToScreamingKebab(s string) string {
+	return c.ToScreamingDelimited(s, '-', 0, true)
 }
 
 // ToDelimited converts a string to delimited.snake.case
 // (in this case `delimiter = '.'`)
-func ToDelimited(s string, delimiter uint8) string {
-	return ToScreamingDelimited(s, delimiter, 0, false)
+func (c *Caser) ToDelimited(s string, delimiter uint8) string {
+	return c.ToScreamingDelimited(s, delimiter, 0, false)
 }
 
 // ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE
 // (in this case `delimiter = '.'; screaming = true`)
 // or delimited.snake.case
-// (in this case `delimiter = '.'; screaming = false`)
-func ToScreamingDelimited(s string, delimiter uint8, ignore uint8, screaming bool) string {
+// (in this case `delimiter = '.'; screaming = false`).
+// Initialisms written together are separate words: APIURL -> api.url
+func (c *Caser) ToScreamingDelimited(s string, delimiter uint8, ignore uint8, screaming bool) string {
+	s = c.splitInitialisms(s, delimiter)
+
 	n := strings.Builder{}
 	// nominal 2 bytes of extra space for inserted delimiters
 	n.Grow(len(s) + 2) //nolint: mnd
@@ -106,3 +109,37 @@ func ToScreamingDelimited(s string, delimiter uint8, ignore uint8, screaming boo
 
 	return n.String()
 }
+
+// ToSnake converts a string to snake_case
+func ToSnake(s string) string {
+	return defaultCaser.ToSnake(s)
+}
+
+func ToSnakeWithIgnore(s string, ignore uint8) string {
+	return defaultCaser.ToSnakeWithIgnore(s, ignore)
+}
+
+// ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE
+func ToScreamingSnake(s string) string {
+	return defaultCaser.ToScreamingSnake(s)
+}
+
+// ToKebab converts a string to kebab-case
+func ToKebab(s string) string {
+	return defaultCaser.ToKebab(s)
+}
+
+// ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE
+func ToScreamingKebab(s string) string {
+	return defaultCaser.ToScreamingKebab(s)
+}
+
+// ToDelimited converts a string to delimited.snake.case
+func ToDelimited(s string, delimiter uint8) string {
+	return defaultCaser.ToDelimited(s, delimiter)
+}
+
+// ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE or delimited.snake.case
+func ToScreamingDelimited(s string, delimiter uint8, ignore uint8, screaming bool) string {
+	return defaultCaser.ToScreamingDelimited(s, delimiter, ignore, screaming)
+}
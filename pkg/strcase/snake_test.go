@@ -22,6 +22,9 @@ func toSnake(tb testing.TB) {
 		{"numbers2and55with000", "numbers2_and55_with000"},
 		{"JSONData", "json_data"},
 		{"userID", "user_id"},
+		{"APIURL", "api_url"},
+		{"XMLHTTPRequest", "xml_http_request"},
+		{"GetUserByIDAndURL", "get_user_by_id_and_url"},
 		{"AAAbbb", "aa_abbb"},
 		{"1A2", "1_a2"},
 		{"A1B", "a1_b"},